@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// answersFileName is the per-project file holding the wizard answers
+const answersFileName = answers.FileName
+
+// answersStore persists wizard answers and tracks their lifecycle state
+var answersStore = answers.New()
+
+// loadAnswersFile reads persisted wizard answers and enforces domain invariants.
+// All violations are returned together as domain.ValidationErrors.
+func loadAnswersFile(path string) (*domain.SafeProjectConfig, error) {
+	return answersStore.LoadConfig(context.Background(), path)
+}
+
+// decodeAnswersFile parses the answers file without validating invariants
+func decodeAnswersFile(path string) (*domain.SafeProjectConfig, error) {
+	return answers.Read(path)
+}
+
+// saveAnswersFile validates wizard answers and writes them for generate,
+// diff and update
+func saveAnswersFile(path string, config *domain.SafeProjectConfig) error {
+	ctx := context.Background()
+	result, err := answersStore.ValidateConfig(ctx, config)
+	if err != nil {
+		return err
+	}
+	if err := result.Err(); err != nil {
+		return err
+	}
+	return answersStore.SaveConfig(ctx, config, path)
+}
+
+// recordGeneration persists that artifacts were generated from the answers
+func recordGeneration(path string, config *domain.SafeProjectConfig) error {
+	if err := answersStore.CompleteGeneration(config); err != nil {
+		return err
+	}
+	return answersStore.SaveConfig(context.Background(), config, path)
+}
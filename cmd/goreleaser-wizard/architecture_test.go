@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/state"
 	"github.com/charmbracelet/log"
 )
 
@@ -16,6 +19,11 @@ func TestJobManager(t *testing.T) {
 	logger := log.New(os.Stderr)
 	jm := NewJobManager(logger)
 
+	// Create a basic project to validate
+	projectDir := t.TempDir()
+	os.WriteFile(filepath.Join(projectDir, "go.mod"), []byte("module github.com/user/job-manager-test\ngo 1.21\n"), 0644)
+	os.WriteFile(filepath.Join(projectDir, "main.go"), []byte("package main\n\nfunc main() {}"), 0644)
+
 	// Test basic job manager
 	tests := []struct {
 		name     string
@@ -27,7 +35,7 @@ func TestJobManager(t *testing.T) {
 			name: "sequential_success",
 			setup: func(jm *JobManager) {
 				jm.SetParallel(false)
-				jm.AddJob(NewProjectValidationJob(".", logger))
+				jm.AddJob(NewProjectValidationJob(projectDir, logger))
 			},
 			parallel: false,
 			wantErr:  false,
@@ -37,7 +45,7 @@ func TestJobManager(t *testing.T) {
 			setup: func(jm *JobManager) {
 				jm.SetParallel(true)
 				jm.SetMaxJobs(2)
-				jm.AddJob(NewProjectValidationJob(".", logger))
+				jm.AddJob(NewProjectValidationJob(projectDir, logger))
 			},
 			parallel: true,
 			wantErr:  false,
@@ -105,7 +113,7 @@ go 1.21
 				BinaryName:  "job-test",
 				MainPath:    ".",
 				GitProvider: "GitHub",
-			}, false, logger),
+			}, false, ".", NewBackupRecorder(".", "test", logger), logger),
 			wantErr: false,
 		},
 		{
@@ -159,7 +167,7 @@ go 1.21
 				BinaryName:  "rollback-test",
 				MainPath:    ".",
 				GitProvider: "GitHub",
-			}, false, logger),
+			}, false, ".", NewBackupRecorder(".", "test", logger), logger),
 			executeRollback: true,
 		},
 		{
@@ -236,7 +244,7 @@ go 1.21
 					BinaryName:  "workflow-test",
 					MainPath:    ".",
 					GitProvider: "GitHub",
-				}, false, logger))
+				}, false, ".", NewBackupRecorder(".", "test", logger), logger))
 				wf.SetTimeout(5 * time.Minute)
 				return wf
 			}(),
@@ -292,15 +300,17 @@ go 1.21
 	config := &ProjectConfig{
 		ProjectName:        "builder-test",
 		ProjectDescription: "A test project for workflow builder",
-		ProjectType:        "CLI Application",
+		ProjectType:        domain.ProjectTypeCLI,
 		BinaryName:         "builder-test",
 		MainPath:           ".",
-		Platforms:          []string{"linux", "darwin"},
-		Architectures:      []string{"amd64"},
-		CGOEnabled:         false,
-		GitProvider:        "GitHub",
-		GenerateActions:    true,
-		ActionsOn:          []string{"On version tags (v*)"},
+		Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin},
+		Architectures:      []domain.Architecture{domain.ArchitectureAMD64},
+		CGOStatus:          domain.CGOStatusDisabled,
+		GitProvider:        domain.GitProviderGitHub,
+		DockerSupport:      domain.DockerSupportNone,
+		SigningLevel:       domain.SigningLevelNone,
+		ActionLevel:        domain.ActionLevelBasic,
+		ActionsOn:          []domain.ActionTrigger{domain.ActionTriggerVersionTags},
 	}
 
 	tests := []struct {
@@ -316,9 +326,10 @@ go 1.21
 			wantErr:      false,
 		},
 		{
+			// The full wizard already wrote the configuration
 			name:         "config_only_workflow",
 			workflowType: WorkflowTypeConfigOnly,
-			force:        false,
+			force:        true,
 			wantErr:      false,
 		},
 		{
@@ -410,3 +421,59 @@ func TestConcurrentJobExecution(t *testing.T) {
 		t.Errorf("Expected 3 completed jobs, got %d", len(completed))
 	}
 }
+
+// TestMigrateWorkflow migrates the configuration under any name GoReleaser
+// reads, and opens no backup run when a file cannot be read
+func TestMigrateWorkflow(t *testing.T) {
+	logger := log.New(os.Stderr)
+	config := "project_name: app\narchives:\n  - format: zip\n"
+
+	t.Run("discovered_config_name", func(t *testing.T) {
+		dir := t.TempDir()
+		configPath := filepath.Join(dir, ".goreleaser.yml")
+		os.WriteFile(configPath, []byte(config), 0644)
+
+		wb := NewWorkflowBuilder(logger)
+		wb.SetRootDir(dir)
+		workflow, err := wb.BuildMigrateWorkflow("v1", "v2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := workflow.Execute(context.Background()); err != nil {
+			t.Fatalf("Workflow.Execute() error = %v", err)
+		}
+
+		data, _ := os.ReadFile(configPath)
+		if !strings.Contains(string(data), "formats:") {
+			t.Errorf("configuration was not migrated:\n%s", data)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".goreleaser.yaml")); !os.IsNotExist(err) {
+			t.Error("migration created .goreleaser.yaml")
+		}
+	})
+
+	t.Run("unreadable_file", func(t *testing.T) {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, ".goreleaser.yml"), []byte(config), 0644)
+		// A directory with a workflow name cannot be read
+		os.MkdirAll(filepath.Join(dir, ".github", "workflows", "release.yml"), 0755)
+
+		wb := NewWorkflowBuilder(logger)
+		wb.SetRootDir(dir)
+		workflow, err := wb.BuildMigrateWorkflow("v1", "v2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := workflow.Execute(context.Background()); err == nil {
+			t.Fatal("Workflow.Execute() succeeded with an unreadable workflow")
+		}
+
+		runs, err := state.NewStore(dir).Runs()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(runs) != 0 {
+			t.Errorf("backup runs = %d, expected none", len(runs))
+		}
+	})
+}
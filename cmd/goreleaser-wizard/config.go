@@ -0,0 +1,398 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the wizard answers file",
+	Long: `Inspect and maintain the wizard answers (.goreleaser-wizard.yaml) that
+generate, update and diff render release files from.
+
+Paths follow the keys of the answers file: 'sbom', 'platforms',
+'platforms[0]' or 'build_tags[0].name'. Values are checked against the
+allowed values of each field and the answers must stay valid, so a script can
+flip one option in many repositories:
+
+  goreleaser-wizard config set sbom true --regenerate`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <path>",
+	Short: "Print a value of the answers file",
+	Args:  cobra.ExactArgs(1),
+	Run:   runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <path> <value>",
+	Short: "Change a value of the answers file",
+	Long: `Change a value of the answers file.
+
+Text fields and enums take the value literally; lists, booleans and build
+tags are YAML, e.g. '[linux, darwin]' or '{name: netgo}'. An index one past
+the end of a list appends to it. The answers are rejected, and the file left
+unchanged, if the new value makes them invalid or breaks the organization
+policy.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runConfigSet,
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <path>",
+	Short: "Remove a value from the answers file",
+	Long: `Remove a field, resetting it to its zero value, or remove a list element.
+The answers are rejected, and the file left unchanged, if they become invalid
+or break the organization policy.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runConfigUnset,
+}
+
+var configExplainCmd = &cobra.Command{
+	Use:   "explain [field]",
+	Short: "Show which configuration layer set each answer",
+	Long: `Show where the value of an answers field comes from.
+
+Answers are layered, weakest first: the built-in baseline, the defaults
+recommended for the project type, the organization baseline ('baseline' in
+the user config), the 'defaults' section of the user config, the values an
+organization policy raises those to, and the project answers file. Without a field every field is listed with the layer that set
+it; with a field every layer that gives it a value is shown, strongest first.
+Without an answers file the values init would choose are explained.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runConfigExplain,
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade the answers file to the current answers schema",
+	Long: `Upgrade the wizard answers to the current schema_version.
+
+Every command already reads older answers by upgrading them in memory, for
+example 'docker_enabled: true' becomes 'docker_support: both'. This command
+reports each change with a before/after snippet; --write saves the upgraded
+file, keeping its comments. The previous file can be restored with
+'goreleaser-wizard rollback'.`,
+	Run: runConfigMigrate,
+}
+
+func init() {
+	configCmd.PersistentFlags().String("answers", answersFileName, "wizard answers file")
+	configMigrateCmd.Flags().Bool("write", false, "save the upgraded answers")
+	configMigrateCmd.Flags().Bool("list", false, "list the answers schema migrations")
+	for _, cmd := range []*cobra.Command{configSetCmd, configUnsetCmd} {
+		cmd.Flags().Bool("regenerate", false, "update the release files afterwards, keeping hand edits like 'goreleaser-wizard update'")
+	}
+
+	configCmd.AddCommand(configGetCmd, configSetCmd, configUnsetCmd, configExplainCmd, configMigrateCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("config get command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+
+	config, err := decodeAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	node, err := answers.Get(config, args[0])
+	if err != nil {
+		displayError(asDomainError(err).WithContext(answersPath))
+		os.Exit(1)
+	}
+
+	// Print scalars bare so scripts can use them directly
+	switch {
+	case node.Tag == "!!null":
+		fmt.Println()
+	case node.Kind == yaml.ScalarNode:
+		fmt.Println(node.Value)
+	default:
+		data, err := yaml.Marshal(node)
+		if err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		fmt.Print(string(data))
+	}
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("config set command")
+
+	editAnswers(cmd, "config-set", func(ctx context.Context, config *domain.SafeProjectConfig) (*domain.SafeProjectConfig, error) {
+		return answersStore.SetField(ctx, config, args[0], args[1])
+	})
+}
+
+func runConfigUnset(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("config unset command")
+
+	editAnswers(cmd, "config-unset", func(ctx context.Context, config *domain.SafeProjectConfig) (*domain.SafeProjectConfig, error) {
+		return answersStore.UnsetField(ctx, config, args[0])
+	})
+}
+
+// editAnswers applies an edit to the answers file, reports the changed
+// fields and optionally updates the release files
+func editAnswers(cmd *cobra.Command, command string, edit func(ctx context.Context, config *domain.SafeProjectConfig) (*domain.SafeProjectConfig, error)) {
+	answersPath, _ := cmd.Flags().GetString("answers")
+	regenerate, _ := cmd.Flags().GetBool("regenerate")
+	ctx := context.Background()
+
+	config, err := decodeAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	updated, err := edit(ctx, config)
+	if err != nil {
+		displayError(asDomainError(err).WithContext(answersPath))
+		os.Exit(1)
+	}
+
+	// Edited answers must satisfy the policy just like those init writes
+	rules, err := loadPolicy(filepath.Dir(answersPath))
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if rules != nil {
+		if err := rules.Check(updated).Err(); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+	}
+
+	changes := config.Diff(updated)
+	if len(changes) == 0 {
+		fmt.Println(successStyle.Render("✅ No changes to " + answersPath))
+	} else {
+		backups := NewBackupRecorder(filepath.Dir(answersPath), command, logger)
+		if err := backups.Record(answersPath); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		if err := answersStore.SaveConfig(ctx, updated, answersPath); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		fmt.Println(successStyle.Render("✅ Updated " + answersPath))
+		for _, change := range changes {
+			if change.Path != "state" {
+				fmt.Println(infoStyle.Render("  " + change.String()))
+			}
+		}
+	}
+
+	if !regenerate {
+		return
+	}
+	conflicted, err := updateArtifacts(answersPath, filepath.Dir(answersPath), updated, false, isInteractive())
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if len(conflicted) > 0 {
+		displayConflicts(conflicted)
+		os.Exit(1)
+	}
+	fmt.Println(successStyle.Render("✅ Release artifacts are up to date"))
+}
+
+func runConfigExplain(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("config explain command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+
+	layers, err := loadConfigLayers()
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	project, err := answers.ProjectLayer(answersPath)
+	switch {
+	case err == nil:
+		layers = append(layers, project)
+	case domain.IsErrorCode(err, domain.ErrFileNotFound):
+		fmt.Println(infoStyle.Render(fmt.Sprintf("💡 %s does not exist; showing what 'goreleaser-wizard init' would choose", answersPath)))
+		layers = append(layers, detectedLayer())
+	default:
+		displayError(err)
+		os.Exit(1)
+	}
+
+	rules, err := loadPolicy(".")
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	var resolved *domain.LayeredConfig
+	if rules != nil {
+		resolved, err = resolvePolicyLayer(layers, rules)
+	} else {
+		resolved, err = domain.ResolveLayers(layers...)
+	}
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if len(args) == 0 {
+		fmt.Println(titleStyle.Render("🔍 Answers by Layer"))
+		for _, field := range resolved.Fields() {
+			values, _ := resolved.Explain(field)
+			winner := values[len(values)-1]
+			source := winner.Layer.String()
+			if inherited := inheritedFrom(values); inherited != nil {
+				source += ", same as " + string(inherited.Kind)
+			}
+			fmt.Printf("  %-20s %-36s %s\n", field, formatLayerValue(winner.Value), source)
+		}
+		return
+	}
+
+	values, err := resolved.Explain(args[0])
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	winner := values[len(values)-1]
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🔍 %s = %s", args[0], formatLayerValue(winner.Value))))
+	for i := len(values) - 1; i >= 0; i-- {
+		marker := "   "
+		if i == len(values)-1 {
+			marker = "✅ "
+		}
+		value := values[i]
+		fmt.Printf("  %s%-9s %-36s %s\n", marker, value.Layer.Kind, formatLayerValue(value.Value), value.Layer.Source)
+	}
+}
+
+// inheritedFrom returns the strongest lower layer whose value the winning
+// layer repeats, e.g. project answers written by init from a user default
+func inheritedFrom(values []domain.LayerValue) *domain.Layer {
+	if len(values) < 2 {
+		return nil
+	}
+	winner, below := values[len(values)-1], values[len(values)-2]
+	if !reflect.DeepEqual(winner.Value, below.Value) {
+		return nil
+	}
+	return &below.Layer
+}
+
+// formatLayerValue renders a layer value as JSON, e.g. "advanced" or ["linux"]
+func formatLayerValue(value any) string {
+	if value == nil {
+		return "(none)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(data)
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("config migrate command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+	write, _ := cmd.Flags().GetBool("write")
+	list, _ := cmd.Flags().GetBool("list")
+
+	if list {
+		displayAnswersMigrations()
+		return
+	}
+
+	data, err := os.ReadFile(answersPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			displayError(domain.FileNotFoundError(answersPath, err))
+		} else {
+			displayError(domain.NewSystemError(
+				domain.ErrFileReadFailed,
+				"Failed to read wizard answers",
+				fmt.Sprintf("Cannot read %s", answersPath),
+				err,
+			).WithContext(answersPath))
+		}
+		os.Exit(1)
+	}
+
+	result, err := answers.Migrate(data)
+	if err != nil {
+		displayError(asDomainError(err).WithContext(answersPath))
+		os.Exit(1)
+	}
+	if !result.Changed() {
+		fmt.Println(successStyle.Render(fmt.Sprintf("✅ %s already uses answers schema %d", answersPath, answers.SchemaVersion)))
+		return
+	}
+
+	// Refuse to save answers that would not load afterwards
+	if _, err := answers.Decode(result.Content); err != nil {
+		displayError(asDomainError(err).WithContext(answersPath))
+		os.Exit(1)
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🚚 Migrating Wizard Answers (schema %d → %d)", result.From, result.To)))
+	fmt.Println()
+	displayAnswersChanges(answersPath, result.Changes)
+
+	if !write {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("%d changes would be made. Run with --write to save them.", len(result.Changes))))
+		return
+	}
+
+	backups := NewBackupRecorder(filepath.Dir(answersPath), "config-migrate", logger)
+	if err := backups.Record(answersPath); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(answersPath, result.Content, 0644); err != nil {
+		displayError(domain.FileWriteFailedError(answersPath, err))
+		os.Exit(1)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ Upgraded %s to answers schema %d", answersPath, result.To)))
+	fmt.Println(infoStyle.Render("💡 Undo with 'goreleaser-wizard rollback'"))
+}
+
+// displayAnswersChanges prints each migration change with its before/after snippet
+func displayAnswersChanges(path string, changes []answers.Change) {
+	fmt.Println(diffHeaderStyle.Render(path))
+	for _, change := range changes {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("  [%s] line %d: %s", change.Migration, change.Line, change.Path)))
+		printSnippet("-", change.Before)
+		printSnippet("+", change.After)
+	}
+	fmt.Println()
+}
+
+// displayAnswersMigrations lists every answers schema migration
+func displayAnswersMigrations() {
+	fmt.Println(titleStyle.Render("📜 Answers Schema Migrations"))
+	for _, migration := range answers.Migrations() {
+		fmt.Printf("  %-12s %d → %d  %s\n", migration.ID, migration.From, migration.From+1, migration.Description)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/diff"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Exit codes of the diff command, following diff(1)
+const (
+	diffExitDrift = 1
+	diffExitError = 2
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show how on-disk files differ from what the wizard would generate",
+	Long: `Render all artifacts in memory from the saved wizard answers and compare
+them against the files on disk.
+
+By default a unified diff is printed (colored on terminals unless --no-color
+is set). With --semantic, YAML files are compared by value so formatting,
+comments and key order are ignored.
+
+Exit status is 0 when nothing drifted, 1 when there is drift and 2 on error,
+so the command can gate CI.`,
+	Run: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().String("answers", answersFileName, "wizard answers file to generate from")
+	diffCmd.Flags().String("dir", ".", "directory containing the files to compare")
+	diffCmd.Flags().Bool("semantic", false, "compare YAML files by parsed value instead of text")
+	diffCmd.Flags().Int("context", 3, "number of context lines in unified diffs")
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("diff command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+	dir, _ := cmd.Flags().GetString("dir")
+	semantic, _ := cmd.Flags().GetBool("semantic")
+	contextLines, _ := cmd.Flags().GetInt("context")
+
+	config, err := loadAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(diffExitError)
+	}
+
+	artifacts, err := generator.New().Artifacts(context.Background(), config)
+	if err != nil {
+		displayError(err)
+		os.Exit(diffExitError)
+	}
+
+	drift := false
+	for _, artifact := range artifacts {
+		changed, err := diffArtifact(dir, artifact, semantic, contextLines)
+		if err != nil {
+			displayError(err)
+			os.Exit(diffExitError)
+		}
+		drift = drift || changed
+	}
+
+	if drift {
+		os.Exit(diffExitDrift)
+	}
+	fmt.Println(successStyle.Render("✅ No drift: files on disk match the wizard output"))
+}
+
+// diffArtifact prints the difference for one artifact and reports whether it drifted
+func diffArtifact(dir string, artifact generator.Artifact, semantic bool, contextLines int) (bool, error) {
+	path := filepath.Join(dir, filepath.FromSlash(artifact.Path))
+
+	current, exists, err := readArtifact(path)
+	if err != nil {
+		return false, err
+	}
+
+	if semantic && exists && isYAMLArtifact(artifact.Path) {
+		changes, err := diff.YAML(current, []byte(artifact.Content))
+		if err != nil {
+			return false, asDomainError(err).WithContext(path)
+		}
+		if len(changes) == 0 {
+			return false, nil
+		}
+
+		fmt.Println(diffHeaderStyle.Render(fmt.Sprintf("%s (%d changes)", path, len(changes))))
+		for _, change := range changes {
+			fmt.Println(colorizeDiffLine(change.String()))
+		}
+		fmt.Println()
+		return true, nil
+	}
+
+	oldName := "a/" + artifact.Path
+	if !exists {
+		oldName = "/dev/null"
+	}
+
+	unified := diff.Unified(oldName, "b/"+artifact.Path, string(current), artifact.Content, contextLines)
+	if unified == "" {
+		return false, nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(unified, "\n"), "\n") {
+		fmt.Println(colorizeDiffLine(line))
+	}
+	return true, nil
+}
+
+// readArtifact reads an on-disk artifact; a missing file is not an error
+func readArtifact(path string) ([]byte, bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read file",
+			fmt.Sprintf("Cannot read %s", path),
+			err,
+		).WithContext(path)
+	}
+	return data, true, nil
+}
+
+func isYAMLArtifact(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// Diff line styles
+var (
+	diffHeaderStyle = lipgloss.NewStyle().Bold(true)
+	diffHunkStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("86"))
+	diffAddStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
+	diffDeleteStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	diffChangeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+)
+
+// colorizeDiffLine colors a unified or semantic diff line by its prefix
+func colorizeDiffLine(line string) string {
+	if viper.GetBool("no-color") {
+		return line
+	}
+
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return diffHeaderStyle.Render(line)
+	case strings.HasPrefix(line, "@@"):
+		return diffHunkStyle.Render(line)
+	case strings.HasPrefix(line, "+"):
+		return diffAddStyle.Render(line)
+	case strings.HasPrefix(line, "-"):
+		return diffDeleteStyle.Render(line)
+	case strings.HasPrefix(line, "~"):
+		return diffChangeStyle.Render(line)
+	default:
+		return line
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/diff"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/lint"
+)
+
+// fixableFile holds the fixes lint offered for one file
+type fixableFile struct {
+	path    string
+	content []byte
+	fixes   []*lint.Fix
+}
+
+// fixPlan is the outcome of applying the selected fixes in memory
+type fixPlan struct {
+	files   []plannedFile
+	applied int
+	skipped int // unsafe fixes left out without --fix-unsafe
+}
+
+type plannedFile struct {
+	path     string
+	original []byte
+	fixed    []byte
+}
+
+// planFixes applies the fixes of every file in memory. Unsafe fixes are only
+// included when unsafe is set.
+func planFixes(files []*fixableFile, unsafe bool) (*fixPlan, error) {
+	plan := &fixPlan{}
+	for _, file := range files {
+		var selected []*lint.Fix
+		for _, fix := range file.fixes {
+			if fix.Unsafe && !unsafe {
+				plan.skipped++
+				continue
+			}
+			selected = append(selected, fix)
+		}
+		if len(selected) == 0 {
+			continue
+		}
+
+		fixed, err := lint.Apply(file.content, selected)
+		if err != nil {
+			return nil, asDomainError(err).WithContext(file.path)
+		}
+		plan.files = append(plan.files, plannedFile{path: file.path, original: file.content, fixed: fixed})
+		plan.applied += len(selected)
+	}
+	return plan, nil
+}
+
+// displayFixPreview prints the planned changes as a unified diff
+func displayFixPreview(plan *fixPlan) {
+	fmt.Println(titleStyle.Render("🔧 Fixes"))
+	for _, file := range plan.files {
+		unified := diff.Unified("a/"+file.path, "b/"+file.path, string(file.original), string(file.fixed), 3)
+		for _, line := range strings.Split(strings.TrimSuffix(unified, "\n"), "\n") {
+			fmt.Println(colorizeDiffLine(line))
+		}
+	}
+	fmt.Println()
+}
+
+// writeFixes writes the fixed files, recording them in the backup catalog
+// first so 'goreleaser-wizard rollback' can undo the fixes
+func writeFixes(plan *fixPlan) error {
+	backups := NewBackupRecorder(".", "fix", logger)
+
+	var written []string
+	for _, file := range plan.files {
+		if err := backups.Record(file.path); err != nil {
+			return err
+		}
+		if err := os.WriteFile(file.path, file.fixed, 0644); err != nil {
+			if restoreErr := backups.Restore(append(written, file.path)...); restoreErr != nil {
+				logger.Error("Failed to restore fixed files", "error", restoreErr)
+			}
+			return domain.FileWriteFailedError(file.path, err)
+		}
+		written = append(written, file.path)
+	}
+	return nil
+}
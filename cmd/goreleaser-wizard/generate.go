@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var generateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate GoReleaser configuration",
+	Long: `Generate release artifacts from the saved wizard answers.
+
+The answers file (.goreleaser-wizard.yaml) is the only input, so the same
+answers always render the same files. After a successful run its state is
+set to 'generated'.
+
+This command renders:
+- .goreleaser.yaml
+- .github/workflows/release.yml (when GitHub Actions are enabled)
+- Dockerfile (when Docker images are built)
+
+Use --dry-run to list the files that would be written, or --stdout to
+print a single artifact (goreleaser, workflow, dockerfile) without
+touching the filesystem. Existing files are kept unless --force is given.`,
+	Run: runGenerate,
+}
+
+func init() {
+	generateCmd.Flags().String("answers", answersFileName, "wizard answers file to generate from")
+	generateCmd.Flags().String("output-dir", ".", "directory to write generated files to")
+	generateCmd.Flags().Bool("dry-run", false, "list the files that would be written without writing them")
+	generateCmd.Flags().String("stdout", "", "print a single artifact (goreleaser, workflow, dockerfile) to stdout")
+	generateCmd.Flags().Bool("force", false, "overwrite existing files")
+}
+
+func runGenerate(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("generate command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+	outputDir, _ := cmd.Flags().GetString("output-dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	stdoutArtifact, _ := cmd.Flags().GetString("stdout")
+	force, _ := cmd.Flags().GetBool("force")
+
+	config, err := loadAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	// Print a single artifact without any decoration so it can be piped
+	if stdoutArtifact != "" {
+		if err := checkPlanned(config, generator.ArtifactKind(stdoutArtifact)); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		artifact, err := generator.New().Generate(ctx, config, generator.ArtifactKind(stdoutArtifact))
+		if err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		fmt.Print(artifact.Content)
+		return
+	}
+
+	if dryRun {
+		displayGeneratePlan(config, outputDir, force)
+		return
+	}
+
+	fmt.Println(titleStyle.Render("⚙️  Generating Release Artifacts"))
+	fmt.Println()
+
+	builder := NewWorkflowBuilder(logger)
+	builder.SetRootDir(outputDir)
+
+	workflow, err := builder.BuildWorkflow(WorkflowTypeGenerate, config, force)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if err := answersStore.BeginGeneration(config); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if err := workflow.Execute(ctx); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if err := recordGeneration(answersPath, config); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	for _, kind := range generator.Planned(config) {
+		fmt.Println(successStyle.Render("✅ " + filepath.Join(outputDir, filepath.FromSlash(kind.Path()))))
+	}
+}
+
+// displayGeneratePlan prints the files generate would write and what would happen to each
+func displayGeneratePlan(config *domain.SafeProjectConfig, outputDir string, force bool) {
+	fmt.Println(titleStyle.Render("📋 Generation Plan (dry run)"))
+	fmt.Println()
+
+	for _, kind := range generator.Planned(config) {
+		path := filepath.Join(outputDir, filepath.FromSlash(kind.Path()))
+		fmt.Println(infoStyle.Render(fmt.Sprintf("  %-10s %s", plannedAction(path, force), path)))
+	}
+
+	fmt.Println()
+	fmt.Println("No files were written.")
+}
+
+// plannedAction describes what generate does with an artifact path
+func plannedAction(path string, force bool) string {
+	if _, err := os.Stat(path); err != nil {
+		return "create"
+	}
+	if force {
+		return "overwrite"
+	}
+	return "skip"
+}
+
+// checkPlanned rejects a known artifact the answers do not ask for, such as
+// the Dockerfile of a project without Docker support; unknown kinds are left
+// to the generator to report
+func checkPlanned(config *domain.SafeProjectConfig, kind generator.ArtifactKind) error {
+	if !kind.IsValid() {
+		return nil
+	}
+	planned := generator.Planned(config)
+	for _, k := range planned {
+		if k == kind {
+			return nil
+		}
+	}
+	return domain.NewBusinessRuleError(
+		domain.ErrArtifactNotPlanned,
+		"Artifact not planned",
+		fmt.Sprintf("The answers do not generate '%s'; planned artifacts: %v", kind, planned),
+	).WithField("stdout")
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
 )
 
 func TestRunGenerate(t *testing.T) {
@@ -81,17 +85,17 @@ func TestTemplateGeneration(t *testing.T) {
 				ProjectDescription: "A complete test project",
 				BinaryName:         "complete-test",
 				MainPath:           "./cmd/complete-test",
-				ProjectType:        "CLI Application",
-				Platforms:          []string{"linux", "darwin", "windows"},
-				Architectures:      []string{"amd64", "arm64"},
-				CGOEnabled:         false,
-				GitProvider:        "GitHub",
-				DockerEnabled:      true,
-				DockerRegistry:     "ghcr.io/user",
-				Signing:            true,
+				ProjectType:        domain.ProjectTypeCLI,
+				Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin, domain.PlatformWindows},
+				Architectures:      []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64},
+				CGOStatus:          domain.CGOStatusDisabled,
+				GitProvider:        domain.GitProviderGitHub,
+				DockerSupport:      domain.DockerSupportBoth,
+				DockerRegistry:     domain.DockerRegistryGitHub,
+				SigningLevel:       domain.SigningLevelAdvanced,
 				Homebrew:           true,
-				GenerateActions:    true,
-				ActionsOn:          []string{"On version tags (v*)"},
+				ActionLevel:        domain.ActionLevelBasic,
+				ActionsOn:          []domain.ActionTrigger{domain.ActionTriggerVersionTags},
 			},
 			expectError: false,
 			checks: []string{
@@ -112,7 +116,7 @@ func TestTemplateGeneration(t *testing.T) {
 				ProjectName: "minimal-test",
 				BinaryName:  "minimal-test",
 				MainPath:    ".",
-				GitProvider: "GitHub",
+				GitProvider: domain.GitProviderGitHub,
 			},
 			expectError: false,
 			checks: []string{
@@ -135,11 +139,11 @@ func TestTemplateGeneration(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Generate config
-			err := generateGoReleaserConfig(&tt.config)
+			err := generateArtifact(context.Background(), &tt.config, generator.ArtifactGoReleaserConfig, ".", NewBackupRecorder(".", "test", logger))
 
 			// Check error
 			if (err != nil) != tt.expectError {
-				t.Errorf("generateGoReleaserConfig() error = %v, wantErr %v", err, tt.expectError)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.expectError)
 				return
 			}
 
@@ -173,12 +177,15 @@ func TestGitHubActionsGeneration(t *testing.T) {
 		{
 			name: "actions_with_docker",
 			config: ProjectConfig{
-				ProjectName:     "docker-test",
-				BinaryName:      "docker-test",
-				GenerateActions: true,
-				DockerEnabled:   true,
-				DockerRegistry:  "ghcr.io/user",
-				ActionsOn:       []string{"Manual trigger only"},
+				ProjectName:    "docker-test",
+				ProjectType:    domain.ProjectTypeCLI,
+				BinaryName:     "docker-test",
+				ActionLevel:    domain.ActionLevelBasic,
+				DockerSupport:  domain.DockerSupportBoth,
+				DockerRegistry: domain.DockerRegistryGitHub,
+				Platforms:      []domain.Platform{domain.PlatformLinux},
+				Architectures:  []domain.Architecture{domain.ArchitectureAMD64},
+				ActionsOn:      []domain.ActionTrigger{domain.ActionTriggerManual},
 			},
 			expectError: false,
 			checks: []string{
@@ -191,11 +198,11 @@ func TestGitHubActionsGeneration(t *testing.T) {
 		{
 			name: "actions_with_signing",
 			config: ProjectConfig{
-				ProjectName:     "signing-test",
-				BinaryName:      "signing-test",
-				GenerateActions: true,
-				Signing:         true,
-				ActionsOn:       []string{"On all tags"},
+				ProjectName:  "signing-test",
+				BinaryName:   "signing-test",
+				ActionLevel:  domain.ActionLevelBasic,
+				SigningLevel: domain.SigningLevelAdvanced,
+				ActionsOn:    []domain.ActionTrigger{domain.ActionTriggerAllTags},
 			},
 			expectError: false,
 			checks: []string{
@@ -219,11 +226,11 @@ func TestGitHubActionsGeneration(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Generate actions
-			err := generateGitHubActions(&tt.config)
+			err := generateArtifact(context.Background(), &tt.config, generator.ArtifactWorkflow, ".", NewBackupRecorder(".", "test", logger))
 
 			// Check error
 			if (err != nil) != tt.expectError {
-				t.Errorf("generateGitHubActions() error = %v, wantErr %v", err, tt.expectError)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.expectError)
 				return
 			}
 
@@ -261,11 +268,11 @@ func TestConfigValidation(t *testing.T) {
 				ProjectDescription: "Valid test project",
 				BinaryName:         "valid-test",
 				MainPath:           "./cmd/valid-test",
-				ProjectType:        "CLI Application",
-				Platforms:          []string{"linux", "darwin"},
-				Architectures:      []string{"amd64"},
-				CGOEnabled:         false,
-				GitProvider:        "GitHub",
+				ProjectType:        domain.ProjectTypeCLI,
+				Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin},
+				Architectures:      []domain.Architecture{domain.ArchitectureAMD64},
+				CGOStatus:          domain.CGOStatusDisabled,
+				GitProvider:        domain.GitProviderGitHub,
 			},
 			wantErr: false,
 		},
@@ -310,30 +317,33 @@ func TestConfigValidation(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Test config generation
-			err := generateGoReleaserConfig(&tt.config)
+			err := generateArtifact(context.Background(), &tt.config, generator.ArtifactGoReleaserConfig, ".", NewBackupRecorder(".", "test", logger))
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("generateGoReleaserConfig() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
 
 func TestFileOperations(t *testing.T) {
+	ctx := context.Background()
+	repo := &SimpleFileSystemRepository{}
+
 	tests := []struct {
 		name      string
 		operation func() error
 		wantErr   bool
 	}{
 		{
-			name: "write_new_file_with_safe_write",
+			name: "write_artifact_creates_directories",
 			operation: func() error {
-				return SafeFileWrite("test-safe-write.txt", []byte("test content"), 0644)
+				return generator.WriteArtifact(".", generator.Artifact{Path: ".github/workflows/release.yml", Content: "name: Release\n"})
 			},
 			wantErr: false,
 		},
 		{
-			name: "read_existing_file_with_safe_read",
+			name: "read_existing_file",
 			operation: func() error {
 				content := []byte("test content for reading")
 				err := os.WriteFile("test-safe-read.txt", content, 0644)
@@ -341,7 +351,7 @@ func TestFileOperations(t *testing.T) {
 					return err
 				}
 
-				readContent, err := SafeReadFile("test-safe-read.txt")
+				readContent, err := repo.ReadFile(ctx, "test-safe-read.txt")
 				if err != nil {
 					return err
 				}
@@ -355,13 +365,13 @@ func TestFileOperations(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "create_file_with_safe_create",
+			name: "create_file",
 			operation: func() error {
-				file, err := SafeCreateFile("test-safe-create.txt")
+				file, err := repo.CreateFile(ctx, "test-safe-create.txt")
 				if err != nil {
 					return err
 				}
-				file.WriteString("test content")
+				file.Write([]byte("test content"))
 				file.Close()
 				return nil
 			},
@@ -391,7 +401,7 @@ func TestFileOperations(t *testing.T) {
 }
 
 func TestBackupCreation(t *testing.T) {
-	// Test that backup files are created when overwriting existing files
+	// Test that overwritten files are recorded and restored by the backup run
 	tests := []struct {
 		name            string
 		originalContent string
@@ -433,28 +443,29 @@ func TestBackupCreation(t *testing.T) {
 				}
 			}
 
-			// Write new file (this should create backup)
-			err := SafeFileWrite(testFile, []byte(tt.newContent), 0644)
-			if err != nil {
-				t.Errorf("SafeFileWrite() error = %v", err)
-				return
+			// Record the file, then overwrite it
+			backups := NewBackupRecorder(".", "test", logger)
+			if err := backups.Record(testFile); err != nil {
+				t.Fatalf("Record() error = %v", err)
+			}
+			if backups.RunID() == "" {
+				t.Fatal("Record() did not start a backup run")
+			}
+			if err := os.WriteFile(testFile, []byte(tt.newContent), 0644); err != nil {
+				t.Fatal(err)
 			}
 
-			// Check backup file
-			backupFile := testFile + ".backup"
-			backupInfo, _ := os.Stat(backupFile)
-			backupExists := backupInfo != nil
-
-			if tt.expectBackup && !backupExists {
-				t.Error("Backup file should exist when overwriting existing file")
-			} else if tt.expectBackup && backupExists {
-				// Read backup to ensure it contains original content
-				backupContent, _ := os.ReadFile(backupFile)
-				if string(backupContent) != tt.originalContent {
-					t.Errorf("Backup content = %q, want %q", string(backupContent), tt.originalContent)
+			// Restoring puts back the original, or removes a new file
+			if err := backups.Restore(testFile); err != nil {
+				t.Fatalf("Restore() error = %v", err)
+			}
+			content, err := os.ReadFile(testFile)
+			if tt.expectBackup {
+				if string(content) != tt.originalContent {
+					t.Errorf("Restored content = %q, want %q", string(content), tt.originalContent)
 				}
-			} else if !tt.expectBackup && backupExists {
-				t.Error("Backup file should not exist for new file")
+			} else if !os.IsNotExist(err) {
+				t.Errorf("New file should be removed on restore, got %q, %v", string(content), err)
 			}
 		})
 	}
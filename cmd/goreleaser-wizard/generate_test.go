@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
 )
 
 func TestGenerateGoReleaserConfig(t *testing.T) {
@@ -21,10 +25,10 @@ func TestGenerateGoReleaserConfig(t *testing.T) {
 				ProjectDescription: "A test application",
 				BinaryName:         "test-app",
 				MainPath:           ".",
-				Platforms:          []string{"linux", "darwin"},
-				Architectures:      []string{"amd64", "arm64"},
-				CGOEnabled:         false,
-				GitProvider:        "GitHub",
+				Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin},
+				Architectures:      []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64},
+				CGOStatus:          domain.CGOStatusDisabled,
+				GitProvider:        domain.GitProviderGitHub,
 			},
 			wantErr: false,
 			checks: []string{
@@ -35,36 +39,41 @@ func TestGenerateGoReleaserConfig(t *testing.T) {
 				"- amd64",
 				"- arm64",
 				"CGO_ENABLED=0",
-				`owner: "{{.Env.GITHUB_OWNER}}"`,
-				`name: "{{.Env.GITHUB_REPO}}"`,
+				`owner: "{{ .Env.GITHUB_OWNER }}"`,
+				`name: "{{ .Env.GITHUB_REPO }}"`,
 			},
 		},
 		{
 			name: "docker_enabled",
 			config: ProjectConfig{
 				ProjectName:    "docker-app",
+				ProjectType:    domain.ProjectTypeCLI,
 				BinaryName:     "docker-app",
 				MainPath:       "./cmd/app",
-				DockerEnabled:  true,
-				DockerRegistry: "ghcr.io/testuser",
-				GitProvider:    "GitHub",
+				Platforms:      []domain.Platform{domain.PlatformLinux},
+				Architectures:  []domain.Architecture{domain.ArchitectureAMD64},
+				DockerSupport:  domain.DockerSupportBoth,
+				DockerRegistry: domain.DockerRegistryGitHub,
+				DockerImage:    "testuser/docker-app",
+				GitProvider:    domain.GitProviderGitHub,
 			},
 			wantErr: false,
 			checks: []string{
 				"dockers:",
 				"image_templates:",
-				"ghcr.io/testuser/docker-app:{{.Tag}}",
+				"ghcr.io/testuser/docker-app:{{ .Tag }}-amd64",
+				"docker_manifests:",
 				"dockerfile: Dockerfile",
 			},
 		},
 		{
 			name: "signing_enabled",
 			config: ProjectConfig{
-				ProjectName: "signed-app",
-				BinaryName:  "signed-app",
-				MainPath:    ".",
-				Signing:     true,
-				GitProvider: "GitHub",
+				ProjectName:  "signed-app",
+				BinaryName:   "signed-app",
+				MainPath:     ".",
+				SigningLevel: domain.SigningLevelAdvanced,
+				GitProvider:  domain.GitProviderGitHub,
 			},
 			wantErr: false,
 			checks: []string{
@@ -80,14 +89,16 @@ func TestGenerateGoReleaserConfig(t *testing.T) {
 				ProjectDescription: "App with Homebrew support",
 				BinaryName:         "brew-app",
 				MainPath:           ".",
+				Platforms:          []domain.Platform{domain.PlatformDarwin},
+				Architectures:      []domain.Architecture{domain.ArchitectureARM64},
 				Homebrew:           true,
-				GitProvider:        "GitHub",
+				GitProvider:        domain.GitProviderGitHub,
 			},
 			wantErr: false,
 			checks: []string{
 				"brews:",
 				"repository:",
-				"folder: Formula",
+				"directory: Formula",
 				"App with Homebrew support",
 			},
 		},
@@ -129,11 +140,11 @@ func TestGenerateGoReleaserConfig(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Generate config
-			err = generateGoReleaserConfig(&tt.config)
+			err = generateArtifact(context.Background(), &tt.config, generator.ArtifactGoReleaserConfig, ".", NewBackupRecorder(".", "test", logger))
 
 			// Check error
 			if (err != nil) != tt.wantErr {
-				t.Errorf("generateGoReleaserConfig() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
@@ -175,10 +186,10 @@ func TestGenerateGitHubActions(t *testing.T) {
 		{
 			name: "basic_actions",
 			config: ProjectConfig{
-				ProjectName:     "test-app",
-				BinaryName:      "test-app",
-				GenerateActions: true,
-				ActionsOn:       []string{"On version tags (v*)"},
+				ProjectName: "test-app",
+				BinaryName:  "test-app",
+				ActionLevel: domain.ActionLevelBasic,
+				ActionsOn:   []domain.ActionTrigger{domain.ActionTriggerVersionTags},
 			},
 			wantErr: false,
 			checks: []string{
@@ -194,11 +205,15 @@ func TestGenerateGitHubActions(t *testing.T) {
 		{
 			name: "docker_support",
 			config: ProjectConfig{
-				ProjectName:     "docker-app",
-				DockerEnabled:   true,
-				DockerRegistry:  "ghcr.io/user",
-				GenerateActions: true,
-				ActionsOn:       []string{"Manual trigger only"},
+				ProjectName:    "docker-app",
+				ProjectType:    domain.ProjectTypeCLI,
+				BinaryName:     "docker-app",
+				DockerSupport:  domain.DockerSupportBoth,
+				DockerRegistry: domain.DockerRegistryGitHub,
+				ActionLevel:    domain.ActionLevelBasic,
+				Platforms:      []domain.Platform{domain.PlatformLinux},
+				Architectures:  []domain.Architecture{domain.ArchitectureAMD64},
+				ActionsOn:      []domain.ActionTrigger{domain.ActionTriggerManual},
 			},
 			wantErr: false,
 			checks: []string{
@@ -210,10 +225,11 @@ func TestGenerateGitHubActions(t *testing.T) {
 		{
 			name: "signing_support",
 			config: ProjectConfig{
-				ProjectName:     "signed-app",
-				Signing:         true,
-				GenerateActions: true,
-				ActionsOn:       []string{"On all tags"},
+				ProjectName:  "signed-app",
+				BinaryName:   "signed-app",
+				SigningLevel: domain.SigningLevelAdvanced,
+				ActionLevel:  domain.ActionLevelBasic,
+				ActionsOn:    []domain.ActionTrigger{domain.ActionTriggerAllTags},
 			},
 			wantErr: false,
 			checks: []string{
@@ -245,11 +261,11 @@ func TestGenerateGitHubActions(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Generate actions
-			err = generateGitHubActions(&tt.config)
+			err = generateArtifact(context.Background(), &tt.config, generator.ArtifactWorkflow, ".", NewBackupRecorder(".", "test", logger))
 
 			// Check error
 			if (err != nil) != tt.wantErr {
-				t.Errorf("generateGitHubActions() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
@@ -296,7 +312,7 @@ go 1.21`
 				ProjectName: "myapp",
 				MainPath:    ".",
 				BinaryName:  "myapp",
-				ProjectType: "CLI Application",
+				ProjectType: domain.ProjectTypeCLI,
 			},
 		},
 		{
@@ -318,7 +334,7 @@ go 1.21`
 				ProjectName: "complexapp",
 				MainPath:    "./cmd/complexapp",
 				BinaryName:  "complexapp",
-				ProjectType: "CLI Application",
+				ProjectType: domain.ProjectTypeCLI,
 			},
 		},
 	}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/discovery"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/importer"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create wizard answers from an existing GoReleaser configuration",
+	Long: `Reconstruct the wizard answers from a hand-written GoReleaser configuration
+and its release workflow, so an existing project can adopt the wizard.
+
+Platforms, architectures, CGO, the Docker registry and image, signing,
+Homebrew, Snap and SBOM come from the configuration; triggers, tests and the
+GoReleaser distribution from the workflow. Everything the answers cannot
+represent is listed with its position, since 'goreleaser-wizard generate'
+would drop it; 'goreleaser-wizard update' keeps such hand edits instead.
+
+On this command --config names the GoReleaser configuration to import, not
+the wizard's user config.`,
+	Run: runImport,
+}
+
+func init() {
+	importCmd.Flags().String("config", "", "GoReleaser configuration to import (default: the one in the current directory)")
+	importCmd.Flags().String("workflow", "", "release workflow to import triggers from (default: the first workflow running GoReleaser)")
+	importCmd.Flags().String("answers", answersFileName, "wizard answers file to write")
+	importCmd.Flags().Bool("dry-run", false, "print the answers instead of writing them")
+	importCmd.Flags().Bool("force", false, "overwrite existing wizard answers")
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("import command")
+
+	configPath, _ := cmd.Flags().GetString("config")
+	workflowPath, _ := cmd.Flags().GetString("workflow")
+	answersPath, _ := cmd.Flags().GetString("answers")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	files, err := importFiles(configPath, workflowPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if !dryRun && !force {
+		if _, err := os.Stat(answersPath); err == nil {
+			displayError(domain.NewSystemError(
+				domain.ErrFileWriteFailed,
+				"Wizard answers already exist",
+				fmt.Sprintf("%s already exists (use --force to overwrite)", answersPath),
+				nil,
+			).WithContext(answersPath))
+			os.Exit(1)
+		}
+	}
+
+	result := importer.Import(*files)
+	config := result.Config
+	detectProjectInfo(config)
+	if err := config.ValidateInvariants(); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if err := result.CompareGenerated(context.Background()); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if dryRun {
+		data, err := answers.Encode(config)
+		if err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		fmt.Print(string(data))
+		// The answers alone go to stdout, so they can be redirected to a file
+		if len(result.Unsupported) > 0 {
+			fmt.Fprintln(os.Stderr, "⚠️  Not represented in wizard answers:")
+			for _, problem := range result.Unsupported {
+				fmt.Fprintf(os.Stderr, "  • %s\n", formatViolation(problem))
+				fmt.Fprintf(os.Stderr, "    Details: %s\n", problem.Details)
+			}
+		}
+		return
+	}
+
+	fmt.Println(titleStyle.Render("📥 Importing " + files.ConfigPath))
+	if files.Workflow != nil {
+		fmt.Println(infoStyle.Render("Triggers from " + files.WorkflowPath))
+	}
+	fmt.Println()
+
+	if len(result.Unsupported) > 0 {
+		fmt.Println(infoStyle.Render("⚠️  Not represented in wizard answers:"))
+		displayProblems(result.Unsupported, true)
+	}
+
+	if err := saveAnswersFile(answersPath, config); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(successStyle.Render("✅ Created " + answersPath))
+	if len(result.Unsupported) > 0 {
+		fmt.Println(infoStyle.Render("💡 Use 'goreleaser-wizard diff' to review what generate would change, and 'goreleaser-wizard update' to keep your hand edits"))
+	} else {
+		fmt.Println(infoStyle.Render("💡 Run 'goreleaser-wizard diff' to compare the configuration with generated output"))
+	}
+}
+
+// importFiles reads the configuration and workflow to import, finding them
+// in the current directory when their paths are not given
+func importFiles(configPath, workflowPath string) (*importer.Files, error) {
+	if configPath == "" {
+		for _, name := range discovery.ConfigNames {
+			if fileExists(name) {
+				configPath = name
+				break
+			}
+		}
+		if configPath == "" {
+			return nil, domain.NewSystemError(
+				domain.ErrFileNotFound,
+				"Configuration file not found",
+				fmt.Sprintf("No GoReleaser configuration (%s) in the current directory; name one with --config", strings.Join(discovery.ConfigNames, ", ")),
+				nil,
+			)
+		}
+	}
+	config, err := parseImportFile(configPath, yamlcheck.GoReleaserKinds)
+	if err != nil {
+		return nil, err
+	}
+	files := &importer.Files{Config: config, ConfigPath: configPath}
+
+	if workflowPath == "" {
+		workflows, err := discovery.Workflows(".")
+		if err != nil {
+			return nil, err
+		}
+		for _, workflow := range workflows {
+			if workflow.Release {
+				workflowPath = filepath.FromSlash(workflow.Path)
+				break
+			}
+		}
+	}
+	if workflowPath != "" {
+		workflow, err := parseImportFile(workflowPath, yamlcheck.WorkflowKinds)
+		if err != nil {
+			return nil, err
+		}
+		files.Workflow, files.WorkflowPath = workflow, workflowPath
+	}
+	return files, nil
+}
+
+// parseImportFile parses a file, failing on any YAML problem
+func parseImportFile(path string, kinds yamlcheck.Kinds) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.FileNotFoundError(path, err)
+		}
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read file",
+			fmt.Sprintf("Cannot read %s", path),
+			err,
+		).WithContext(path)
+	}
+
+	root, problems := yamlcheck.Parse(data, kinds)
+	if len(problems) > 0 {
+		for i := range problems {
+			problems[i] = problems[i].WithContext(path)
+		}
+		return nil, problems.Err()
+	}
+	return root, nil
+}
@@ -0,0 +1,357 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/policy"
+	"github.com/spf13/cobra"
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize GoReleaser configuration",
+	Long: `Create the wizard answers file (.goreleaser-wizard.yaml) for the Go module
+in the current directory.
+
+The project name, binary and main package are detected from go.mod and the
+cmd/ layout; everything else starts from the recommended defaults for the
+project type.
+
+Defaults are layered, weakest first: the built-in recommendations for the
+project type, the organization baseline named by 'baseline' in the user
+config (a file or a directory of files), the 'defaults' section of the user
+config ($HOME/.goreleaser-wizard.yaml), the presets chosen with --preset,
+--minimal or --pro, what was detected from go.mod, and --set field=value. 'goreleaser-wizard config explain <field>' shows which
+layer chose a value.
+
+When an organization policy applies (.goreleaser-wizard-policy.yaml, or the
+file named by 'policy' in the user config), the defaults and baselines are
+raised to meet it, e.g. the signing level or the Docker registry; 'config
+explain' shows these values as the policy layer. A preset or --set value
+that violates the policy is an error.
+
+Run 'goreleaser-wizard generate' afterwards to render the release files.`,
+	Run: runInitWizard,
+}
+
+func init() {
+	initCmd.Flags().Bool("force", false, "overwrite existing wizard answers")
+	initCmd.Flags().StringArray("set", nil, "set an answer, e.g. --set signing_level=advanced (repeatable)")
+	initCmd.Flags().StringArray("preset", nil, "start from a preset, see 'goreleaser-wizard presets list' (repeatable)")
+	initCmd.Flags().Bool("minimal", false, "create a minimal configuration (the 'minimal' preset)")
+	initCmd.Flags().Bool("pro", false, "include GoReleaser Pro features (the 'pro' preset)")
+}
+
+func runInitWizard(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("init command")
+
+	force, _ := cmd.Flags().GetBool("force")
+
+	fmt.Println(titleStyle.Render("🚀 GoReleaser Configuration Wizard"))
+
+	if _, err := os.Stat("go.mod"); err != nil {
+		displayError(domain.NewSystemError(
+			domain.ErrFileNotFound,
+			"Go module not found",
+			"init needs a go.mod in the current directory; run 'go mod init' first",
+			err,
+		).WithContext("go.mod"))
+		os.Exit(1)
+	}
+	if _, err := os.Stat(answersFileName); err == nil && !force {
+		displayError(domain.NewSystemError(
+			domain.ErrFileWriteFailed,
+			"Wizard answers already exist",
+			fmt.Sprintf("%s already exists (use --force to overwrite)", answersFileName),
+			nil,
+		).WithContext(answersFileName))
+		os.Exit(1)
+	}
+
+	rules, err := loadPolicy(".")
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	layers, err := initLayers(cmd)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	resolved, err := newInitConfig(layers, rules)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	config := resolved.Config
+	if err := config.ValidateInvariants(); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if rules != nil {
+		if err := rules.Check(config).Err(); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+	}
+
+	if err := saveAnswersFile(answersFileName, config); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	displayInitSummary(resolved, rules)
+}
+
+// initLayers collects the layers of new answers, weakest first: the
+// organization baseline and user defaults, the chosen presets, what was
+// detected from go.mod and --set
+func initLayers(cmd *cobra.Command) ([]domain.Layer, error) {
+	names, _ := cmd.Flags().GetStringArray("preset")
+	minimal, _ := cmd.Flags().GetBool("minimal")
+	pro, _ := cmd.Flags().GetBool("pro")
+	assignments, _ := cmd.Flags().GetStringArray("set")
+
+	layers, err := loadConfigLayers()
+	if err != nil {
+		return nil, err
+	}
+
+	// The shorthands come first so named presets refine them
+	if pro {
+		names = append([]string{"pro"}, names...)
+	}
+	if minimal {
+		names = append([]string{"minimal"}, names...)
+	}
+	if len(names) > 0 {
+		catalog, err := loadPresets()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			preset, err := catalog.Get(name)
+			if err != nil {
+				return nil, err
+			}
+			layers = append(layers, preset.Layer)
+		}
+	}
+
+	layers = append(layers, detectedLayer())
+
+	flags, err := answers.FlagLayer(assignments)
+	if err != nil {
+		return nil, err
+	}
+	if len(flags.Values) > 0 {
+		layers = append(layers, flags)
+	}
+	return layers, nil
+}
+
+// newInitConfig builds the initial answers from their layers. The policy
+// raises what the defaults and baselines chose; values chosen explicitly by
+// a preset, detection or --set that violate it are rejected.
+func newInitConfig(layers []domain.Layer, rules *policy.Policy) (*domain.LayeredConfig, error) {
+	resolved, err := domain.ResolveLayers(layers...)
+	if err != nil || rules == nil {
+		return resolved, err
+	}
+
+	var violations domain.ValidationErrors
+	for _, violation := range rules.Check(resolved.Config) {
+		if source := resolved.Source(violation.Field); !baselineLayer(source.Kind) {
+			violations = append(violations, violation.WithContext(source.String()))
+		}
+	}
+	if err := violations.Err(); err != nil {
+		return nil, err
+	}
+	return resolvePolicyLayer(layers, rules)
+}
+
+// resolvePolicyLayer resolves the layers with the values the policy raises
+// the answers to as a layer of its own, right after the baselines, so
+// 'config explain' shows the policy as their source. Persisted project
+// answers are kept out of the policy's view; they override it like any
+// explicit choice.
+func resolvePolicyLayer(layers []domain.Layer, rules *policy.Policy) (*domain.LayeredConfig, error) {
+	var chosen []domain.Layer
+	for _, layer := range layers {
+		if layer.Kind != domain.LayerProject {
+			chosen = append(chosen, layer)
+		}
+	}
+	resolved, err := domain.ResolveLayers(chosen...)
+	if err != nil {
+		return nil, err
+	}
+	raised := rules.Layer(resolved.Config)
+	if len(raised.Values) == 0 {
+		return domain.ResolveLayers(layers...)
+	}
+
+	at := len(layers)
+	for i, layer := range layers {
+		if !baselineLayer(layer.Kind) {
+			at = i
+			break
+		}
+	}
+	withPolicy := append(append(append([]domain.Layer{}, layers[:at]...), raised), layers[at:]...)
+	return domain.ResolveLayers(withPolicy...)
+}
+
+// baselineLayer reports whether a layer holds defaults shared by projects,
+// which the policy raises, rather than values chosen for this project
+func baselineLayer(kind domain.LayerKind) bool {
+	switch kind {
+	case domain.LayerBuiltin, domain.LayerDefaults, domain.LayerOrg, domain.LayerUser:
+		return true
+	default:
+		return false
+	}
+}
+
+// detectedLayer holds the project name, binary and main package detected from
+// go.mod and the layout of the current directory
+func detectedLayer() domain.Layer {
+	config := &domain.SafeProjectConfig{ProjectType: domain.GetRecommendedProjectType()}
+	detectProjectInfo(config)
+	return domain.Layer{
+		Kind:   domain.LayerDetected,
+		Source: "go.mod",
+		Values: map[string]any{
+			"project_name": config.ProjectName,
+			"binary_name":  config.BinaryName,
+			"main_path":    config.MainPath,
+		},
+	}
+}
+
+// modulePattern matches the module directive of go.mod
+var modulePattern = regexp.MustCompile(`^module\s+"?([^"\s]+)"?`)
+
+// majorSuffix matches the major version suffix of a module path
+var majorSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// detectProjectInfo fills the project name, binary and main package from
+// go.mod and the layout of the current directory, keeping values already set
+func detectProjectInfo(config *domain.SafeProjectConfig) {
+	if config.ProjectType == "" {
+		config.ProjectType = domain.GetRecommendedProjectType()
+	}
+
+	if config.ProjectName == "" {
+		if gomod, err := os.ReadFile("go.mod"); err == nil {
+			scanner := bufio.NewScanner(bytes.NewReader(gomod))
+			for scanner.Scan() {
+				if match := modulePattern.FindStringSubmatch(strings.TrimSpace(scanner.Text())); match != nil {
+					name := path.Base(match[1])
+					if majorSuffix.MatchString(name) {
+						name = path.Base(path.Dir(match[1]))
+					}
+					config.ProjectName = name
+					break
+				}
+			}
+		}
+	}
+	if config.ProjectName == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			config.ProjectName = filepath.Base(cwd)
+		}
+	}
+
+	if config.BinaryName == "" {
+		config.BinaryName = config.ProjectName
+	}
+
+	if config.MainPath == "" {
+		config.MainPath = detectMainPath(config.BinaryName)
+	}
+}
+
+// detectMainPath prefers cmd/<binary>, then a main package in the root, then
+// the only directory below cmd/
+func detectMainPath(binary string) string {
+	if fileExists(filepath.Join("cmd", binary, "main.go")) {
+		return "./cmd/" + binary
+	}
+	if fileExists("main.go") {
+		return "."
+	}
+	entries, err := os.ReadDir("cmd")
+	if err != nil {
+		return "."
+	}
+	var commands []string
+	for _, entry := range entries {
+		if entry.IsDir() && fileExists(filepath.Join("cmd", entry.Name(), "main.go")) {
+			commands = append(commands, entry.Name())
+		}
+	}
+	if len(commands) == 1 {
+		return "./cmd/" + commands[0]
+	}
+	return "."
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// displayInitSummary prints the answers init chose and the next step
+func displayInitSummary(resolved *domain.LayeredConfig, rules *policy.Policy) {
+	config := resolved.Config
+	fmt.Println()
+	for _, layer := range resolved.Layers {
+		switch layer.Kind {
+		case domain.LayerOrg, domain.LayerUser, domain.LayerFlags:
+			fmt.Println(infoStyle.Render("📚 Defaults from " + layer.String()))
+		case domain.LayerPreset:
+			fmt.Println(infoStyle.Render("🎛️  Preset " + layer.Source))
+		}
+	}
+	if rules != nil {
+		fmt.Println(infoStyle.Render("🛡️  Defaults meet the policy in " + rules.Path()))
+	}
+	fmt.Printf("  Project:   %s (%s)\n", config.ProjectName, config.ProjectType)
+	fmt.Printf("  Binary:    %s from %s\n", config.BinaryName, config.MainPath)
+	fmt.Printf("  Platforms: %s\n", joinValues(config.Platforms))
+	for _, variant := range config.ArchitectureVariants {
+		fmt.Printf("  Variants:  %s\n", variant)
+	}
+	fmt.Printf("  CGO:       %s\n", config.CGOStatus)
+	fmt.Printf("  Signing:   %s\n", config.SigningLevel)
+	fmt.Printf("  SBOM:      %t\n", config.SBOM)
+	if config.DockerSupport.IsEnabled() {
+		fmt.Printf("  Docker:    %s to %s\n", config.DockerSupport, config.DockerRegistry)
+	}
+	fmt.Println()
+	fmt.Println(successStyle.Render("✅ Created " + answersFileName))
+	fmt.Println(infoStyle.Render("💡 Run 'goreleaser-wizard generate' to create .goreleaser.yaml and the release workflow"))
+}
+
+// joinValues joins enum values for display
+func joinValues[T ~string](values []T) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = string(value)
+	}
+	return strings.Join(parts, ", ")
+}
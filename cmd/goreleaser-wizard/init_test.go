@@ -3,9 +3,12 @@ package main
 import (
 	"bytes"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/policy"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -14,41 +17,47 @@ func TestInitCommand(t *testing.T) {
 	tests := []struct {
 		name        string
 		args        []string
-		flags       map[string]string
-		setupFunc   func() string
+		goMod       string
+		wantName    string
+		wantSigning domain.SigningLevel
 		expectError bool
 	}{
 		{
-			name:  "basic_init_command",
-			args:  []string{},
-			flags: map[string]string{},
-			setupFunc: func() string {
-				dir, _ := os.MkdirTemp("", "wizard-init-test")
-				goMod := `module github.com/user/test
-go 1.21
-`
-				os.WriteFile(dir+"/go.mod", []byte(goMod), 0644)
-				os.WriteFile(dir+"/main.go", []byte("package main\n\nfunc main() {}"), 0644)
-				return dir
-			},
-			expectError: false,
+			name:     "basic_init_command",
+			goMod:    "module github.com/user/init-test\n\ngo 1.21\n",
+			wantName: "init-test",
 		},
 		{
-			name:  "init_in_non_go_project",
-			args:  []string{},
-			flags: map[string]string{},
-			setupFunc: func() string {
-				dir, _ := os.MkdirTemp("", "wizard-init-test")
-				return dir
-			},
+			name:     "major_version_module",
+			goMod:    "module github.com/user/init-test/v2\n\ngo 1.21\n",
+			wantName: "init-test",
+		},
+		{
+			name:        "set_flag_overrides_defaults",
+			args:        []string{"--set", "signing_level=advanced"},
+			goMod:       "module github.com/user/init-test\n\ngo 1.21\n",
+			wantName:    "init-test",
+			wantSigning: domain.SigningLevelAdvanced,
+		},
+		{
+			name:     "minimal_preset",
+			args:     []string{"--minimal"},
+			goMod:    "module github.com/user/init-test\n\ngo 1.21\n",
+			wantName: "init-test",
+		},
+		{
+			name:        "invalid_set_flag",
+			args:        []string{"--set", "no_such_field=1"},
+			goMod:       "module github.com/user/init-test\n\ngo 1.21\n",
 			expectError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			testDir := tt.setupFunc()
-			defer os.RemoveAll(testDir)
+			testDir := t.TempDir()
+			os.WriteFile(filepath.Join(testDir, "go.mod"), []byte(tt.goMod), 0644)
+			os.WriteFile(filepath.Join(testDir, "main.go"), []byte("package main\n\nfunc main() {}"), 0644)
 
 			// Change to test directory
 			originalDir, _ := os.Getwd()
@@ -58,35 +67,39 @@ go 1.21
 			// Reset viper
 			viper.Reset()
 
-			// Create command
-			cmd := &cobra.Command{
-				Use:   "init",
-				Short: "Initialize GoReleaser configuration",
-				Run:   runInitWizard,
+			// Create command with the init flags; runInitWizard exits the
+			// process on errors, so the layers are resolved directly
+			cmd := &cobra.Command{Use: "init", Run: func(*cobra.Command, []string) {}}
+			cmd.Flags().Bool("force", false, "overwrite existing wizard answers")
+			cmd.Flags().StringArray("set", nil, "set an answer")
+			cmd.Flags().StringArray("preset", nil, "start from a preset")
+			cmd.Flags().Bool("minimal", false, "create a minimal configuration")
+			cmd.Flags().Bool("pro", false, "include GoReleaser Pro features")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags() error = %v", err)
 			}
 
-			// Add flags (simplified version)
-			cmd.Flags().Bool("force", false, "force overwrite existing configuration")
-
-			// Set flag values
-			for flag, value := range tt.flags {
-				cmd.Flags().Set(flag, value)
+			layers, err := initLayers(cmd)
+			var resolved *domain.LayeredConfig
+			if err == nil {
+				resolved, err = newInitConfig(layers, nil)
 			}
-
-			// Execute command (with panic recovery)
-			defer func() {
-				if r := recover(); r != nil {
-					if !tt.expectError {
-						t.Errorf("Init command panicked: %v", r)
-					}
-				}
-			}()
-
-			// Execute command
-			err := cmd.Execute()
-
 			if (err != nil) != tt.expectError {
-				t.Errorf("Init command error = %v, expectError %v", err, tt.expectError)
+				t.Fatalf("init layers error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+
+			config := resolved.Config
+			if config.ProjectName != tt.wantName || config.BinaryName != tt.wantName {
+				t.Errorf("project/binary = %q/%q, want %q", config.ProjectName, config.BinaryName, tt.wantName)
+			}
+			if tt.wantSigning != "" && config.SigningLevel != tt.wantSigning {
+				t.Errorf("SigningLevel = %v, want %v", config.SigningLevel, tt.wantSigning)
+			}
+			if err := config.ValidateInvariants(); err != nil {
+				t.Errorf("ValidateInvariants() error = %v", err)
 			}
 		})
 	}
@@ -113,7 +126,7 @@ go 1.21
 				ProjectName: "simpleapp",
 				MainPath:    ".",
 				BinaryName:  "simpleapp",
-				ProjectType: "CLI Application",
+				ProjectType: domain.ProjectTypeCLI,
 			},
 		},
 		{
@@ -132,7 +145,7 @@ go 1.21
 				ProjectName: "cmdapp",
 				MainPath:    "./cmd/cmdapp",
 				BinaryName:  "cmdapp",
-				ProjectType: "CLI Application",
+				ProjectType: domain.ProjectTypeCLI,
 			},
 		},
 	}
@@ -182,31 +195,31 @@ func TestFormValidation(t *testing.T) {
 		{
 			name:     "valid_project_name",
 			input:    "my-awesome-project",
-			function: validateProjectName,
+			function: domain.ValidateProjectName,
 			wantErr:  false,
 		},
 		{
 			name:     "invalid_empty_project_name",
 			input:    "",
-			function: validateProjectName,
+			function: domain.ValidateProjectName,
 			wantErr:  true,
 		},
 		{
 			name:     "invalid_project_name_too_long",
 			input:    strings.Repeat("a", 65),
-			function: validateProjectName,
+			function: domain.ValidateProjectName,
 			wantErr:  true,
 		},
 		{
 			name:     "valid_binary_name",
 			input:    "my-app",
-			function: validateBinaryName,
+			function: domain.ValidateBinaryName,
 			wantErr:  false,
 		},
 		{
 			name:     "invalid_binary_name_with_spaces",
 			input:    "my app",
-			function: validateBinaryName,
+			function: domain.ValidateBinaryName,
 			wantErr:  true,
 		},
 	}
@@ -234,7 +247,7 @@ func TestCommandHelp(t *testing.T) {
 				Use:   "init",
 				Short: "Initialize GoReleaser configuration",
 				Long:  "Interactive wizard to create GoReleaser configuration",
-				Run:   runInitWizard,
+				Run:   initCmd.Run,
 			},
 			args:        []string{"--help"},
 			expectUsage: true,
@@ -326,23 +339,59 @@ func TestFlagHandling(t *testing.T) {
 	}
 }
 
-// Mock validation functions (would normally be in the main code)
-func validateProjectName(name string) error {
-	if len(name) == 0 {
-		return UserInputError("project name", os.ErrInvalid)
+func TestNewInitConfigPolicy(t *testing.T) {
+	rules, err := policy.Parse([]byte("version: 1\nsigning:\n  min_level: advanced\nsbom:\n  required: true\n"))
+	if err != nil {
+		t.Fatalf("policy.Parse() error = %v", err)
 	}
-	if len(name) > 63 {
-		return UserInputError("project name", os.ErrInvalid)
-	}
-	return nil
-}
+	org := domain.Layer{Kind: domain.LayerOrg, Source: "org.yaml", Values: map[string]any{"signing_level": "basic", "sbom": false}}
 
-func validateBinaryName(name string) error {
-	if len(name) == 0 {
-		return UserInputError("binary name", os.ErrInvalid)
+	tests := []struct {
+		name        string
+		layer       domain.Layer
+		expectError bool
+	}{
+		{
+			name:  "raises_baseline",
+			layer: domain.Layer{Kind: domain.LayerDetected, Source: "go.mod", Values: map[string]any{"project_name": "app"}},
+		},
+		{
+			name:        "rejects_set_flag",
+			layer:       domain.Layer{Kind: domain.LayerFlags, Source: "--set", Values: map[string]any{"signing_level": "none"}},
+			expectError: true,
+		},
+		{
+			name:        "rejects_preset",
+			layer:       domain.Layer{Kind: domain.LayerPreset, Source: "minimal", Values: map[string]any{"sbom": false}},
+			expectError: true,
+		},
 	}
-	if strings.Contains(name, " ") {
-		return UserInputError("binary name", os.ErrInvalid)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved, err := newInitConfig([]domain.Layer{org, tt.layer}, rules)
+			if tt.expectError {
+				if !domain.IsErrorCode(err, domain.ErrPolicyViolation) {
+					t.Errorf("newInitConfig() error = %v, want %s", err, domain.ErrPolicyViolation)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newInitConfig() error = %v", err)
+			}
+
+			config := resolved.Config
+			if config.SigningLevel != domain.SigningLevelAdvanced || !config.SBOM {
+				t.Errorf("signing/sbom = %s/%t, want advanced/true", config.SigningLevel, config.SBOM)
+			}
+			for _, field := range []string{"signing_level", "sbom"} {
+				if source := resolved.Source(field); source.Kind != domain.LayerPolicy {
+					t.Errorf("Source(%s) = %s, want the policy layer", field, source)
+				}
+			}
+			if source := resolved.Source("project_name"); source.Kind != domain.LayerDetected {
+				t.Errorf("Source(project_name) = %s, want the detected layer", source)
+			}
+		})
 	}
-	return nil
 }
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
 )
 
 func TestEndToEndWizard(t *testing.T) {
@@ -64,9 +68,11 @@ require github.com/charmbracelet/bubbletea v0.25.0
 			}
 
 			// Test config generation
-			err := generateGoReleaserConfig(config)
+			ctx := context.Background()
+			backups := NewBackupRecorder(".", "test", logger)
+			err := generateArtifact(ctx, config, generator.ArtifactGoReleaserConfig, ".", backups)
 			if err != nil {
-				t.Errorf("generateGoReleaserConfig() error = %v", err)
+				t.Errorf("generateArtifact() error = %v", err)
 			}
 
 			// Verify .goreleaser.yaml was created
@@ -75,11 +81,11 @@ require github.com/charmbracelet/bubbletea v0.25.0
 			}
 
 			// Test GitHub Actions generation
-			config.GenerateActions = true
-			config.ActionsOn = []string{"On version tags (v*)"}
-			err = generateGitHubActions(config)
+			config.ActionLevel = domain.ActionLevelBasic
+			config.ActionsOn = []domain.ActionTrigger{domain.ActionTriggerVersionTags}
+			err = generateArtifact(ctx, config, generator.ArtifactWorkflow, ".", backups)
 			if err != nil {
-				t.Errorf("generateGitHubActions() error = %v", err)
+				t.Errorf("generateArtifact() error = %v", err)
 			}
 
 			// Verify GitHub Actions workflow was created
@@ -112,13 +118,13 @@ func TestConfigurationValidation(t *testing.T) {
 				ProjectDescription: "A test CLI application",
 				BinaryName:         "test-cli",
 				MainPath:           "./cmd/test-cli",
-				ProjectType:        "CLI Application",
-				Platforms:          []string{"linux", "darwin", "windows"},
-				Architectures:      []string{"amd64", "arm64"},
-				CGOEnabled:         false,
-				GitProvider:        "GitHub",
-				GenerateActions:    true,
-				ActionsOn:          []string{"On version tags (v*)"},
+				ProjectType:        domain.ProjectTypeCLI,
+				Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin, domain.PlatformWindows},
+				Architectures:      []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64},
+				CGOStatus:          domain.CGOStatusDisabled,
+				GitProvider:        domain.GitProviderGitHub,
+				ActionLevel:        domain.ActionLevelBasic,
+				ActionsOn:          []domain.ActionTrigger{domain.ActionTriggerVersionTags},
 			},
 			expectError: false,
 		},
@@ -129,13 +135,13 @@ func TestConfigurationValidation(t *testing.T) {
 				ProjectDescription: "A test web service",
 				BinaryName:         "test-web",
 				MainPath:           ".",
-				ProjectType:        "Web Service",
-				Platforms:          []string{"linux", "darwin"},
-				Architectures:      []string{"amd64"},
-				CGOEnabled:         true,
-				GitProvider:        "GitHub",
-				DockerEnabled:      true,
-				DockerRegistry:     "ghcr.io/user",
+				ProjectType:        domain.ProjectTypeWeb,
+				Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin},
+				Architectures:      []domain.Architecture{domain.ArchitectureAMD64},
+				CGOStatus:          domain.CGOStatusEnabled,
+				GitProvider:        domain.GitProviderGitHub,
+				DockerSupport:      domain.DockerSupportBoth,
+				DockerRegistry:     domain.DockerRegistryGitHub,
 				Homebrew:           true,
 			},
 			expectError: false,
@@ -147,7 +153,7 @@ func TestConfigurationValidation(t *testing.T) {
 				MainPath:   ".",
 			},
 			expectError:   true,
-			errorContains: "project name",
+			errorContains: "project_name",
 		},
 		{
 			name: "missing_binary_name",
@@ -156,7 +162,7 @@ func TestConfigurationValidation(t *testing.T) {
 				MainPath:    ".",
 			},
 			expectError:   true,
-			errorContains: "binary name",
+			errorContains: "binary_name",
 		},
 	}
 
@@ -172,17 +178,17 @@ func TestConfigurationValidation(t *testing.T) {
 			defer os.Chdir(originalDir)
 
 			// Test config generation
-			err := generateGoReleaserConfig(&tt.config)
+			err := generateArtifact(context.Background(), &tt.config, generator.ArtifactGoReleaserConfig, ".", NewBackupRecorder(".", "test", logger))
 
 			// Check error
 			if (err != nil) != tt.expectError {
-				t.Errorf("generateGoReleaserConfig() error = %v, wantErr %v", err, tt.expectError)
+				t.Errorf("generateArtifact() error = %v, wantErr %v", err, tt.expectError)
 				return
 			}
 
 			if tt.expectError {
-				if tt.errorContains != "" && !strings.Contains(err.Error(), tt.errorContains) {
-					t.Errorf("Expected error to contain %q, got %q", tt.errorContains, err.Error())
+				if details := asDomainError(err).Details; tt.errorContains != "" && !strings.Contains(details, tt.errorContains) {
+					t.Errorf("Expected error details to contain %q, got %q", tt.errorContains, details)
 				}
 			} else {
 				// Verify generated file exists and has expected content
@@ -218,41 +224,31 @@ func TestConfigurationValidation(t *testing.T) {
 
 func TestDifferentProjectTypes(t *testing.T) {
 	tests := []struct {
-		name           string
-		projectType    string
-		expectedConfig ProjectConfig
+		name        string
+		projectType domain.ProjectType
+		expectCGO   bool
 	}{
 		{
 			name:        "cli_application",
-			projectType: "CLI Application",
-			expectedConfig: ProjectConfig{
-				ProjectType: "CLI Application",
-				CGOEnabled:  false,
-			},
+			projectType: domain.ProjectTypeCLI,
+			expectCGO:   false,
 		},
 		{
 			name:        "web_service",
-			projectType: "Web Service",
-			expectedConfig: ProjectConfig{
-				ProjectType: "Web Service",
-				CGOEnabled:  true,
-			},
+			projectType: domain.ProjectTypeWeb,
+			expectCGO:   true,
 		},
 		{
 			name:        "library",
-			projectType: "Library",
-			expectedConfig: ProjectConfig{
-				ProjectType: "Library",
-				CGOEnabled:  false,
-			},
+			projectType: domain.ProjectTypeLibrary,
+			expectCGO:   false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create temp directory for test
-			tmpDir, _ := os.MkdirTemp("", "wizard-project-type-test")
-			defer os.RemoveAll(tmpDir)
+			tmpDir := t.TempDir()
 
 			// Change to temp directory
 			originalDir, _ := os.Getwd()
@@ -266,37 +262,26 @@ go 1.21
 			os.WriteFile("go.mod", []byte(goMod), 0644)
 			os.WriteFile("main.go", []byte("package main\n\nfunc main() {}"), 0644)
 
-			// Test project detection
-			config := &ProjectConfig{}
-			detectProjectInfo(config)
-
-			// Override project type for testing
+			// Test project detection, then apply the project type's defaults
+			config := domain.NewSafeProjectConfig()
 			config.ProjectType = tt.projectType
-
-			// Apply project type-specific defaults
-			switch tt.projectType {
-			case "CLI Application":
-				config.CGOEnabled = false
-			case "Web Service":
-				config.CGOEnabled = true
-			case "Library":
-				config.CGOEnabled = false
-			}
+			detectProjectInfo(config)
+			config.SetCGOEnabled(config.ProjectType.DefaultCGOEnabled())
 
 			// Verify project type
-			if config.ProjectType != tt.expectedConfig.ProjectType {
-				t.Errorf("ProjectType = %q, want %q", config.ProjectType, tt.expectedConfig.ProjectType)
+			if config.ProjectType != tt.projectType {
+				t.Errorf("ProjectType = %q, want %q", config.ProjectType, tt.projectType)
 			}
 
 			// Verify CGO setting
-			if config.CGOEnabled != tt.expectedConfig.CGOEnabled {
-				t.Errorf("CGOEnabled = %v, want %v", config.CGOEnabled, tt.expectedConfig.CGOEnabled)
+			if config.GetCGOEnabled() != tt.expectCGO {
+				t.Errorf("GetCGOEnabled() = %v, want %v", config.GetCGOEnabled(), tt.expectCGO)
 			}
 
 			// Generate config to test
-			err := generateGoReleaserConfig(config)
+			err := generateArtifact(context.Background(), config, generator.ArtifactGoReleaserConfig, ".", NewBackupRecorder(".", "test", logger))
 			if err != nil {
-				t.Errorf("generateGoReleaserConfig() error = %v", err)
+				t.Errorf("generateArtifact() error = %v", err)
 			}
 
 			// Verify config file was created
@@ -308,11 +293,10 @@ go 1.21
 }
 
 func TestEdgeCaseScenarios(t *testing.T) {
+	// Without a usable go.mod the project is named after its directory
 	tests := []struct {
-		name        string
-		setupFunc   func() string
-		expectError bool
-		errorMsg    string
+		name      string
+		setupFunc func() string
 	}{
 		{
 			name: "empty_project_directory",
@@ -320,8 +304,6 @@ func TestEdgeCaseScenarios(t *testing.T) {
 				dir, _ := os.MkdirTemp("", "wizard-empty-test")
 				return dir
 			},
-			expectError: true,
-			errorMsg:    "go.mod not found",
 		},
 		{
 			name: "malformed_go_mod",
@@ -330,33 +312,13 @@ func TestEdgeCaseScenarios(t *testing.T) {
 				os.WriteFile(filepath.Join(dir, "go.mod"), []byte("invalid go.mod content"), 0644)
 				return dir
 			},
-			expectError: true,
-			errorMsg:    "go.mod parsing failed",
-		},
-		{
-			name: "read_only_directory",
-			setupFunc: func() string {
-				dir, _ := os.MkdirTemp("", "wizard-readonly-test")
-				// Make directory read-only (this might not work on all systems)
-				os.Chmod(dir, 0444)
-				return dir
-			},
-			expectError: true,
-			errorMsg:    "permission",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			testDir := tt.setupFunc()
-
-			// Ensure we can cleanup even for read-only test
-			defer func() {
-				if testDir != "" {
-					os.Chmod(testDir, 0755) // Restore permissions
-					os.RemoveAll(testDir)
-				}
-			}()
+			defer os.RemoveAll(testDir)
 
 			// Change to test directory
 			originalDir, _ := os.Getwd()
@@ -367,16 +329,14 @@ func TestEdgeCaseScenarios(t *testing.T) {
 			config := &ProjectConfig{}
 			detectProjectInfo(config) // This function doesn't return an error, it modifies config directly
 
-			// Check error expectation (for tests that expect errors, we check config state)
-			if tt.expectError {
-				if tt.errorMsg != "" && config.ProjectName != "" {
-					// If we expected an error but got a valid project, that's an issue
-					t.Errorf("Expected error containing %q, but got valid project %q", tt.errorMsg, config.ProjectName)
-				}
-			} else {
-				if config.ProjectName == "" {
-					t.Error("Expected valid project detection, but got empty project name")
-				}
+			if config.ProjectName != filepath.Base(testDir) {
+				t.Errorf("ProjectName = %q, want the directory name %q", config.ProjectName, filepath.Base(testDir))
+			}
+			if config.BinaryName != config.ProjectName {
+				t.Errorf("BinaryName = %q, want %q", config.BinaryName, config.ProjectName)
+			}
+			if config.MainPath != "." {
+				t.Errorf("MainPath = %q, want %q", config.MainPath, ".")
 			}
 		})
 	}
@@ -6,40 +6,162 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/LarsArtmann/template-GoReleaser/internal/state"
 	"github.com/charmbracelet/log"
 )
 
-// generateGoReleaserConfig generates GoReleaser configuration from SafeProjectConfig
-func generateGoReleaserConfig(config *domain.SafeProjectConfig) error {
-	// TODO: Implement actual configuration generation
-	fmt.Printf("Generating GoReleaser config for project: %s\n", config.ProjectName)
-	return nil
+// artifactGenerator renders the release artifacts written by the jobs
+var artifactGenerator = generator.New()
+
+// generateArtifact renders a single artifact and writes it below rootDir,
+// recording the previous file and merge base in the backup run first
+func generateArtifact(ctx context.Context, config *domain.SafeProjectConfig, kind generator.ArtifactKind, rootDir string, backups *BackupRecorder) error {
+	artifact, err := artifactGenerator.Generate(ctx, config, kind)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range generatedPaths(rootDir, kind) {
+		if err := backups.Record(path); err != nil {
+			return err
+		}
+	}
+
+	if err := generator.WriteArtifact(rootDir, *artifact); err != nil {
+		return err
+	}
+
+	// Remember the generated output as merge base for later updates
+	return state.NewStore(rootDir).SaveBase(artifact.Path, artifact.Content)
+}
+
+// generatedPaths returns the files generating an artifact changes: the artifact and its merge base
+func generatedPaths(rootDir string, kind generator.ArtifactKind) []string {
+	return []string{
+		artifactPath(rootDir, kind.Path()),
+		artifactPath(rootDir, state.BaseFile(kind.Path())),
+	}
+}
+
+// artifactPath resolves an artifact path relative to rootDir
+func artifactPath(rootDir, path string) string {
+	return filepath.Join(rootDir, filepath.FromSlash(path))
+}
+
+// BackupRecorder records every file one workflow touches as a single run in
+// the backup catalog, so the whole run can be rolled back later
+type BackupRecorder struct {
+	store   *state.Store
+	rootDir string
+	command string
+	run     *state.Manifest
+	mu      sync.Mutex
+	logger  *log.Logger
+}
+
+// NewBackupRecorder creates a recorder for a run of command below rootDir.
+// The run is only added to the catalog once a file is recorded.
+func NewBackupRecorder(rootDir, command string, logger *log.Logger) *BackupRecorder {
+	return &BackupRecorder{
+		store:   state.NewStore(rootDir),
+		rootDir: rootDir,
+		command: command,
+		logger:  logger,
+	}
+}
+
+// Record saves the current content of path before it is changed
+func (b *BackupRecorder) Record(path string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	rel, err := b.relative(path)
+	if err != nil {
+		return err
+	}
+
+	if b.run == nil {
+		run, err := b.store.BeginRun(b.command, time.Now())
+		if err != nil {
+			return err
+		}
+		b.run = run
+		b.logger.Debug("Recording backups", "run", run.ID)
+	}
+
+	return b.store.Record(b.run, rel)
 }
 
-// generateGitHubActions generates GitHub Actions workflow from SafeProjectConfig
-func generateGitHubActions(config *domain.SafeProjectConfig) error {
-	// TODO: Implement actual GitHub Actions generation
-	fmt.Printf("Generating GitHub Actions for project: %s\n", config.ProjectName)
+// Restore puts recorded paths back into their state before the run
+func (b *BackupRecorder) Restore(paths ...string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.run == nil {
+		return nil
+	}
+
+	for i := len(paths) - 1; i >= 0; i-- {
+		rel, err := b.relative(paths[i])
+		if err != nil {
+			return err
+		}
+		if !b.run.Has(rel) {
+			continue
+		}
+		if err := b.store.RestoreFile(b.run, rel); err != nil {
+			b.logger.Errorf("Failed to restore %s: %v", paths[i], err)
+			return err
+		}
+		b.logger.Infof("Restored %s", paths[i])
+	}
 	return nil
 }
 
+// RunID returns the catalog id of the run, or "" when nothing was recorded
+func (b *BackupRecorder) RunID() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.run == nil {
+		return ""
+	}
+	return b.run.ID
+}
+
+func (b *BackupRecorder) relative(path string) (string, error) {
+	rel, err := filepath.Rel(b.rootDir, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
 // ConfigGenerationJob generates GoReleaser configuration
 type ConfigGenerationJob struct {
-	id     string
-	config *domain.SafeProjectConfig
-	force  bool
-	logger *log.Logger
+	id      string
+	config  *domain.SafeProjectConfig
+	force   bool
+	rootDir string
+	backups *BackupRecorder
+	written bool
+	logger  *log.Logger
 }
 
-// NewConfigGenerationJob creates a new config generation job
-func NewConfigGenerationJob(config *domain.SafeProjectConfig, force bool, logger *log.Logger) *ConfigGenerationJob {
+// NewConfigGenerationJob creates a new config generation job writing below rootDir
+func NewConfigGenerationJob(config *domain.SafeProjectConfig, force bool, rootDir string, backups *BackupRecorder, logger *log.Logger) *ConfigGenerationJob {
 	return &ConfigGenerationJob{
-		id:     "config-generation",
-		config: config,
-		force:  force,
-		logger: logger,
+		id:      "config-generation",
+		config:  config,
+		force:   force,
+		rootDir: rootDir,
+		backups: backups,
+		logger:  logger,
 	}
 }
 
@@ -64,20 +186,23 @@ func (j *ConfigGenerationJob) Execute(ctx context.Context) error {
 		return fmt.Errorf("project name is required")
 	}
 
+	configPath := artifactPath(j.rootDir, generator.GoReleaserConfigPath)
+
 	// Check existing files
 	if !j.force {
-		if _, err := os.Stat(".goreleaser.yaml"); err == nil {
-			return fmt.Errorf(".goreleaser.yaml already exists (use --force to overwrite)")
+		if _, err := os.Stat(configPath); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", configPath)
 		}
 	}
 
 	// Generate configuration
-	err := generateGoReleaserConfig(j.config)
+	j.written = true
+	err := generateArtifact(ctx, j.config, generator.ArtifactGoReleaserConfig, j.rootDir, j.backups)
 	if err != nil {
 		return fmt.Errorf("failed to generate GoReleaser config: %w", err)
 	}
 
-	j.logger.Info("GoReleaser configuration generated successfully")
+	j.logger.Info("GoReleaser configuration generated successfully", "path", configPath)
 	return nil
 }
 
@@ -89,44 +214,34 @@ func (j *ConfigGenerationJob) Rollback(ctx context.Context) error {
 		return ctx.Err()
 	}
 
-	// Remove generated config
-	if _, err := os.Stat(".goreleaser.yaml"); err == nil {
-		// Check if backup exists
-		if _, err := os.Stat(".goreleaser.yaml.backup"); err == nil {
-			// Restore backup
-			err := os.Rename(".goreleaser.yaml.backup", ".goreleaser.yaml")
-			if err != nil {
-				j.logger.Errorf("Failed to restore backup: %v", err)
-				return err
-			}
-			j.logger.Info("Restored backup configuration")
-		} else {
-			// Remove generated file
-			err := os.Remove(".goreleaser.yaml")
-			if err != nil {
-				j.logger.Errorf("Failed to remove generated config: %v", err)
-				return err
-			}
-			j.logger.Info("Removed generated configuration")
-		}
+	// Nothing was written, so there is nothing to undo
+	if !j.written {
+		return nil
 	}
 
-	return nil
+	return j.backups.Restore(generatedPaths(j.rootDir, generator.ArtifactGoReleaserConfig)...)
 }
 
 // GitHubActionsGenerationJob generates GitHub Actions workflow
 type GitHubActionsGenerationJob struct {
-	id     string
-	config *domain.SafeProjectConfig
-	logger *log.Logger
+	id      string
+	config  *domain.SafeProjectConfig
+	force   bool
+	rootDir string
+	backups *BackupRecorder
+	written bool
+	logger  *log.Logger
 }
 
-// NewGitHubActionsGenerationJob creates a new GitHub Actions generation job
-func NewGitHubActionsGenerationJob(config *domain.SafeProjectConfig, logger *log.Logger) *GitHubActionsGenerationJob {
+// NewGitHubActionsGenerationJob creates a new GitHub Actions generation job writing below rootDir
+func NewGitHubActionsGenerationJob(config *domain.SafeProjectConfig, force bool, rootDir string, backups *BackupRecorder, logger *log.Logger) *GitHubActionsGenerationJob {
 	return &GitHubActionsGenerationJob{
-		id:     "github-actions-generation",
-		config: config,
-		logger: logger,
+		id:      "github-actions-generation",
+		config:  config,
+		force:   force,
+		rootDir: rootDir,
+		backups: backups,
+		logger:  logger,
 	}
 }
 
@@ -152,20 +267,23 @@ func (j *GitHubActionsGenerationJob) Execute(ctx context.Context) error {
 		return nil
 	}
 
-	// Create .github/workflows directory
-	workflowDir := ".github/workflows"
-	err := os.MkdirAll(workflowDir, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create workflow directory: %w", err)
+	workflowPath := artifactPath(j.rootDir, generator.WorkflowPath)
+
+	// Check existing files
+	if !j.force {
+		if _, err := os.Stat(workflowPath); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", workflowPath)
+		}
 	}
 
 	// Generate workflow
-	err = generateGitHubActions(j.config)
+	j.written = true
+	err := generateArtifact(ctx, j.config, generator.ArtifactWorkflow, j.rootDir, j.backups)
 	if err != nil {
 		return fmt.Errorf("failed to generate GitHub Actions workflow: %w", err)
 	}
 
-	j.logger.Info("GitHub Actions workflow generated successfully")
+	j.logger.Info("GitHub Actions workflow generated successfully", "path", workflowPath)
 	return nil
 }
 
@@ -177,29 +295,104 @@ func (j *GitHubActionsGenerationJob) Rollback(ctx context.Context) error {
 		return ctx.Err()
 	}
 
+	// Nothing was written, so there is nothing to undo
+	if !j.written {
+		return nil
+	}
+
 	// Remove generated workflow
-	workflowPath := filepath.Join(".github", "workflows", "release.yml")
-	if _, err := os.Stat(workflowPath); err == nil {
-		err := os.Remove(workflowPath)
-		if err != nil {
-			j.logger.Errorf("Failed to remove generated workflow: %v", err)
-			return err
-		}
-		j.logger.Info("Removed generated workflow")
+	if err := j.backups.Restore(generatedPaths(j.rootDir, generator.ArtifactWorkflow)...); err != nil {
+		return err
 	}
 
 	// Try to remove .github directory if empty
-	workflowDir := filepath.Join(".github", "workflows")
+	workflowDir := artifactPath(j.rootDir, filepath.Dir(generator.WorkflowPath))
 	workflowFiles, err := filepath.Glob(filepath.Join(workflowDir, "*.yml"))
 	if err == nil && len(workflowFiles) == 0 {
 		os.Remove(workflowDir)
-		os.Remove(".github")
+		os.Remove(filepath.Dir(workflowDir))
 		j.logger.Info("Removed empty .github directory")
 	}
 
 	return nil
 }
 
+// DockerfileGenerationJob generates the Dockerfile used by GoReleaser's dockers pipe
+type DockerfileGenerationJob struct {
+	id      string
+	config  *domain.SafeProjectConfig
+	force   bool
+	rootDir string
+	backups *BackupRecorder
+	written bool
+	logger  *log.Logger
+}
+
+// NewDockerfileGenerationJob creates a new Dockerfile generation job writing below rootDir
+func NewDockerfileGenerationJob(config *domain.SafeProjectConfig, force bool, rootDir string, backups *BackupRecorder, logger *log.Logger) *DockerfileGenerationJob {
+	return &DockerfileGenerationJob{
+		id:      "dockerfile-generation",
+		config:  config,
+		force:   force,
+		rootDir: rootDir,
+		backups: backups,
+		logger:  logger,
+	}
+}
+
+func (j *DockerfileGenerationJob) ID() string {
+	return j.id
+}
+
+func (j *DockerfileGenerationJob) Name() string {
+	return "Generate Dockerfile"
+}
+
+func (j *DockerfileGenerationJob) Execute(ctx context.Context) error {
+	j.logger.Info("Generating Dockerfile")
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if !j.config.ShouldGenerateDockerFiles() {
+		j.logger.Info("Docker image builds are disabled, skipping")
+		return nil
+	}
+
+	dockerfilePath := artifactPath(j.rootDir, generator.DockerfilePath)
+	if !j.force {
+		if _, err := os.Stat(dockerfilePath); err == nil {
+			return fmt.Errorf("%s already exists (use --force to overwrite)", dockerfilePath)
+		}
+	}
+
+	j.written = true
+	if err := generateArtifact(ctx, j.config, generator.ArtifactDockerfile, j.rootDir, j.backups); err != nil {
+		return fmt.Errorf("failed to generate Dockerfile: %w", err)
+	}
+
+	j.logger.Info("Dockerfile generated successfully", "path", dockerfilePath)
+	return nil
+}
+
+func (j *DockerfileGenerationJob) Rollback(ctx context.Context) error {
+	j.logger.Info("Rolling back Dockerfile generation")
+
+	// Check if context is cancelled
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	// Nothing was written, so there is nothing to undo
+	if !j.written {
+		return nil
+	}
+
+	return j.backups.Restore(generatedPaths(j.rootDir, generator.ArtifactDockerfile)...)
+}
+
 // ProjectValidationJob validates project structure
 type ProjectValidationJob struct {
 	id         string
@@ -332,16 +525,33 @@ func (j *DependencyCheckJob) Rollback(ctx context.Context) error {
 
 // JobFactory creates jobs for common wizard operations
 type JobFactory struct {
-	logger *log.Logger
+	logger  *log.Logger
+	rootDir string
 }
 
-// NewJobFactory creates a new job factory
+// NewJobFactory creates a new job factory writing into the current directory
 func NewJobFactory(logger *log.Logger) *JobFactory {
 	return &JobFactory{
-		logger: logger,
+		logger:  logger,
+		rootDir: ".",
 	}
 }
 
+// SetRootDir sets the directory generated files are written to
+func (jf *JobFactory) SetRootDir(rootDir string) {
+	jf.rootDir = rootDir
+}
+
+// RootDir returns the directory generated files are written to
+func (jf *JobFactory) RootDir() string {
+	return jf.rootDir
+}
+
+// NewBackupRecorder creates the backup recorder shared by the jobs of one workflow run
+func (jf *JobFactory) NewBackupRecorder(wfType WorkflowType) *BackupRecorder {
+	return NewBackupRecorder(jf.rootDir, string(wfType), jf.logger)
+}
+
 // CreateFullWizardJobs creates all jobs for a complete wizard operation
 func (jf *JobFactory) CreateFullWizardJobs(config *ProjectConfig, force bool) []Job {
 	var jobs []Job
@@ -359,12 +569,27 @@ func (jf *JobFactory) CreateFullWizardJobs(config *ProjectConfig, force bool) []
 	}
 	jobs = append(jobs, NewDependencyCheckJob(dependencies, jf.logger))
 
-	// Add config generation job
-	jobs = append(jobs, NewConfigGenerationJob(config, force, jf.logger))
+	// Add artifact generation jobs
+	jobs = append(jobs, jf.createArtifactJobs(config, force, jf.NewBackupRecorder(WorkflowTypeFullWizard))...)
+
+	return jobs
+}
+
+// CreateGenerateJobs creates the artifact generation jobs without project checks
+func (jf *JobFactory) CreateGenerateJobs(config *ProjectConfig, force bool) []Job {
+	return jf.createArtifactJobs(config, force, jf.NewBackupRecorder(WorkflowTypeGenerate))
+}
+
+// createArtifactJobs creates one generation job per artifact, sharing one backup run
+func (jf *JobFactory) createArtifactJobs(config *ProjectConfig, force bool, backups *BackupRecorder) []Job {
+	jobs := []Job{NewConfigGenerationJob(config, force, jf.rootDir, backups, jf.logger)}
 
-	// Add GitHub Actions generation job
 	if config.GetGenerateActions() {
-		jobs = append(jobs, NewGitHubActionsGenerationJob(config, jf.logger))
+		jobs = append(jobs, NewGitHubActionsGenerationJob(config, force, jf.rootDir, backups, jf.logger))
+	}
+
+	if config.ShouldGenerateDockerFiles() {
+		jobs = append(jobs, NewDockerfileGenerationJob(config, force, jf.rootDir, backups, jf.logger))
 	}
 
 	return jobs
@@ -374,7 +599,7 @@ func (jf *JobFactory) CreateFullWizardJobs(config *ProjectConfig, force bool) []
 func (jf *JobFactory) CreateConfigOnlyJobs(config *ProjectConfig, force bool) []Job {
 	return []Job{
 		NewProjectValidationJob(".", jf.logger),
-		NewConfigGenerationJob(config, force, jf.logger),
+		NewConfigGenerationJob(config, force, jf.rootDir, jf.NewBackupRecorder(WorkflowTypeConfigOnly), jf.logger),
 	}
 }
 
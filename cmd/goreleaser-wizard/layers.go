@@ -0,0 +1,40 @@
+package main
+
+import (
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
+)
+
+// loadConfigLayers loads the layers new answers start from, weakest first:
+// the organization baseline named by 'baseline' in the user config (a file or
+// a directory of files), then the 'defaults' section of the user config.
+func loadConfigLayers() ([]domain.Layer, error) {
+	var layers []domain.Layer
+
+	if path := viper.GetString("baseline"); path != "" {
+		org, err := answers.ReadLayer(domain.LayerOrg, path)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, org...)
+	}
+
+	if defaults := viper.Get("defaults"); defaults != nil {
+		data, err := yaml.Marshal(defaults)
+		if err != nil {
+			return nil, domain.NewConfigurationError(domain.ErrInvalidConfigLayer, "Invalid user defaults", err.Error()).WithCause(err)
+		}
+		source := viper.ConfigFileUsed()
+		if source == "" {
+			source = "user defaults"
+		}
+		user, err := answers.DecodeLayer(domain.LayerUser, source, data)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, user)
+	}
+	return layers, nil
+}
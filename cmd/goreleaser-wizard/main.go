@@ -82,18 +82,18 @@ var (
 	cfgFile string
 )
 
+// Console logger shared by commands and jobs
+var logger = log.New(os.Stderr)
+
 // Domain logger for dependency injection
 var appLogger domain.Logger
 
 // Style definitions
 var titleStyle, successStyle, errorStyle, infoStyle lipgloss.Style
 
-// Initialize logger dependency
-var appLogger domain.Logger
-
 func init() {
 	// Create a logger adapter to satisfy domain.Logger interface
-	appLogger = &LoggerAdapter{logger: log.New(os.Stderr)}
+	appLogger = &LoggerAdapter{logger: logger}
 	
 	// Initialize styles
 	titleStyle = lipgloss.NewStyle().
@@ -161,20 +161,23 @@ func displayError(err error) {
 	}
 	
 	// Convert to domain error if not already
-	var domainErr *domain.DomainError
-	if !errors.As(err, &domainErr) {
-		domainErr = domain.NewSystemError(
-			domain.ErrFileWriteFailed,
-			"Unexpected error",
-			err.Error(),
-			err,
-		)
-	}
+	domainErr := asDomainError(err)
 	
+	// Aggregated validation errors are listed individually
+	var violations domain.ValidationErrors
+	if errors.As(err, &violations) && len(violations) > 1 {
+		displayValidationErrors(violations)
+		return
+	}
+
 	// Display structured error information
 	fmt.Println()
 	fmt.Println(errorStyle.Render("❌ Error: " + domainErr.Message))
 
+	if domainErr.Field != "" {
+		fmt.Println(infoStyle.Render("Field: " + domainErr.Field))
+	}
+
 	if domainErr.Details != "" {
 		fmt.Println(infoStyle.Render("Details: " + domainErr.Details))
 	}
@@ -183,6 +186,10 @@ func displayError(err error) {
 		fmt.Println(infoStyle.Render("Context: " + domainErr.Context))
 	}
 
+	if domainErr.Line > 0 {
+		fmt.Println(infoStyle.Render("Location: " + domainErr.Location()))
+	}
+
 	suggestion := domainErr.GetRecoverySuggestion()
 	if suggestion != "" {
 		suggestStyle := lipgloss.NewStyle().
@@ -200,6 +207,51 @@ func displayError(err error) {
 	)
 }
 
+// asDomainError returns err as a domain error, wrapping foreign errors
+func asDomainError(err error) *domain.DomainError {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return domain.NewSystemError(
+		domain.ErrFileWriteFailed,
+		"Unexpected error",
+		err.Error(),
+		err,
+	)
+}
+
+// displayValidationErrors lists every violation of an aggregated validation error
+func displayValidationErrors(violations domain.ValidationErrors) {
+	fmt.Println()
+	fmt.Println(errorStyle.Render(fmt.Sprintf("❌ Error: %d validation errors", len(violations))))
+
+	for _, violation := range violations {
+		fmt.Printf("  • %s\n", formatViolation(violation))
+		if violation.Details != "" {
+			fmt.Println(infoStyle.Render("    " + violation.Details))
+		}
+	}
+
+	logger.Error("Validation failed", "errors", len(violations), "fields", violations.Fields())
+}
+
+// formatViolation renders a domain error prefixed with its position and field path,
+// followed by the lint rule that reported it
+func formatViolation(err *domain.DomainError) string {
+	message := err.Message
+	if err.Field != "" {
+		message = fmt.Sprintf("%s: %s", err.Field, message)
+	}
+	if err.Line > 0 {
+		message = fmt.Sprintf("%s: %s", err.Location(), message)
+	}
+	if err.Rule != "" {
+		message = fmt.Sprintf("%s [%s]", message, err.Rule)
+	}
+	return message
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -217,6 +269,14 @@ func init() {
 	rootCmd.AddCommand(initCmd)
 	rootCmd.AddCommand(validateCmd)
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(updateCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(rollbackCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(presetsCmd)
+	rootCmd.AddCommand(matrixCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -229,7 +289,7 @@ func initConfig() {
 		viper.SetConfigFile(cfgFile)
 		
 		// Validate the config file exists and is readable using domain types
-		if err := validateFileExists(cfgFile, true); err != nil {
+		if err := validateFileExists(cfgFile, false); err != nil {
 			displayError(err)
 			return
 		}
@@ -256,8 +316,10 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err != nil {
-		// Only log if it's not a "file not found" error for optional config
-		if cfgFile != "" || !os.IsNotExist(err) {
+		// The user config is optional: warn when --config names a file, or
+		// when a config file was found but cannot be parsed
+		var notFound viper.ConfigFileNotFoundError
+		if cfgFile != "" || !errors.As(err, &notFound) {
 			logger.Warn("Config file error", "error", err, "file", viper.ConfigFileUsed())
 		}
 	} else if viper.GetBool("debug") {
@@ -313,31 +375,6 @@ var versionCmd = &cobra.Command{
 	},
 }
 
-// Placeholder command definitions - TODO: Implement actual functionality
-var initCmd = &cobra.Command{
-	Use:   "init",
-	Short: "Initialize GoReleaser configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Initializing GoReleaser configuration...")
-	},
-}
-
-var validateCmd = &cobra.Command{
-	Use:   "validate",
-	Short: "Validate GoReleaser configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Validating GoReleaser configuration...")
-	},
-}
-
-var generateCmd = &cobra.Command{
-	Use:   "generate",
-	Short: "Generate GoReleaser configuration",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Generating GoReleaser configuration...")
-	},
-}
-
 func main() {
 	// Set up global panic recovery
 	defer recoverFromPanic("main")
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/spf13/cobra"
+)
+
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Preview the targets and artifacts a release will build",
+	Long: `Compute the build matrix from the saved wizard answers: every platform and
+architecture pair the Go toolchain can build with the chosen CGO setting,
+once per micro-architecture variant.
+
+Also shown are the ignore entries written to .goreleaser.yaml, the number of
+artifacts per channel (archives, packages, images) and a rough estimate of
+the release workflow's duration on a hosted runner.
+
+--format json prints the same as a JSON document for scripts.`,
+	Args: cobra.NoArgs,
+	Run:  runMatrix,
+}
+
+func init() {
+	matrixCmd.Flags().String("answers", answersFileName, "wizard answers file to compute the matrix from")
+	matrixCmd.Flags().String("format", "text", "output format: text or json")
+}
+
+func runMatrix(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("matrix command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		displayError(domain.NewValidationError(
+			domain.ErrUnknownOutputFormat,
+			"Unknown output format",
+			fmt.Sprintf("'%s' is not one of text, json", format),
+		))
+		os.Exit(1)
+	}
+
+	config, err := loadAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	matrix := generator.BuildMatrix(config)
+
+	if format == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(matrix); err != nil {
+			displayError(domain.NewSystemError(domain.ErrFileWriteFailed, "Failed to write the matrix", err.Error(), err))
+			os.Exit(1)
+		}
+		return
+	}
+	displayMatrix(matrix)
+}
+
+func displayMatrix(matrix *generator.Matrix) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🧮 Build Matrix: %d targets", len(matrix.Targets))))
+	fmt.Printf("  %-10s %-10s %-16s %-5s %-11s %s\n", "GOOS", "GOARCH", "VARIANT", "CGO", "SUPPORT", "ARCHIVE")
+	for _, target := range matrix.Targets {
+		variant := "-"
+		if target.Variant != "" {
+			variant = target.VariantKey + "=" + target.Variant
+		}
+		cgo := "no"
+		if target.CGO {
+			cgo = "yes"
+		}
+		support := "secondary"
+		if target.FirstClass {
+			support = "first-class"
+		}
+		fmt.Printf("  %-10s %-10s %-16s %-5s %-11s %s\n", target.Platform, target.Architecture, variant, cgo, support, target.Archive)
+	}
+
+	fmt.Println()
+	fmt.Println(diffHeaderStyle.Render("Ignored"))
+	if len(matrix.Ignore) == 0 {
+		fmt.Println(infoStyle.Render("  Nothing; every selected pair is built"))
+	}
+	for _, rule := range matrix.Ignore {
+		fmt.Printf("  - goos: %s\n    goarch: %q  %s\n", rule.Platform, rule.Architecture, infoStyle.Render("# "+rule.Reason))
+	}
+
+	fmt.Println()
+	fmt.Println(diffHeaderStyle.Render("Artifacts"))
+	for _, channel := range matrix.Channels {
+		fmt.Printf("  %-10s %4d  %s\n", channel.Name, channel.Count, infoStyle.Render(channel.Details))
+	}
+
+	fmt.Println()
+	fmt.Printf("⏱️  Estimated CI time: ~%s\n", formatEstimate(matrix.EstimatedCI()))
+	fmt.Println(infoStyle.Render("💡 A rough figure for a hosted four-core runner; caches and queueing change it"))
+}
+
+// formatEstimate renders a duration in whole minutes, e.g. "4m", or seconds
+// below one minute
+func formatEstimate(d time.Duration) string {
+	if d < time.Minute {
+		return d.Round(time.Second).String()
+	}
+	return strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+}
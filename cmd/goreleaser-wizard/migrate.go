@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/migrate"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Migrate a GoReleaser v1 configuration to v2",
+	Long: `Upgrade .goreleaser.yaml and the GitHub Actions workflows to GoReleaser v2.
+
+Each deprecation is fixed by a rule, for example:
+- add the 'version: 2' header
+- archives.format becomes formats
+- brews.tap becomes repository
+- snapshot.name_template becomes version_template
+- --rm-dist becomes --clean in workflows
+
+Every change is reported with a before/after snippet. Use --dry-run to only
+report them, and --list to show all rules.`,
+	Run: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().String("dir", ".", "project directory to migrate")
+	migrateCmd.Flags().String("from", "v1", "configuration version to migrate from")
+	migrateCmd.Flags().String("to", "v2", "configuration version to migrate to")
+	migrateCmd.Flags().Bool("dry-run", false, "report changes without writing files")
+	migrateCmd.Flags().Bool("list", false, "list the migration rules")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("migrate command")
+
+	dir, _ := cmd.Flags().GetString("dir")
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	list, _ := cmd.Flags().GetBool("list")
+
+	if list {
+		displayMigrationRules()
+		return
+	}
+
+	if err := migrate.ValidateVersions(from, to); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🚚 Migrating GoReleaser Configuration (%s → %s)", from, to)))
+	fmt.Println()
+
+	// Compute the changes up front so they can be shown before anything is written
+	files, err := migrationFiles(dir)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	total := 0
+	for _, file := range files {
+		result, err := migrateFile(file)
+		if err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		if result == nil || !result.Changed() {
+			continue
+		}
+		displayMigrationChanges(file.Path, result.Changes)
+		total += len(result.Changes)
+	}
+
+	if total == 0 {
+		fmt.Println(successStyle.Render("✅ Nothing to migrate"))
+		return
+	}
+
+	if dryRun {
+		fmt.Println(infoStyle.Render(fmt.Sprintf("%d changes would be made. No files were written.", total)))
+		return
+	}
+
+	builder := NewWorkflowBuilder(logger)
+	builder.SetRootDir(dir)
+
+	workflow, err := builder.BuildMigrateWorkflow(from, to)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if err := workflow.Execute(context.Background()); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ Applied %d changes", total)))
+}
+
+// displayMigrationChanges prints each change of a file with its before/after snippet
+func displayMigrationChanges(path string, changes []migrate.Change) {
+	fmt.Println(diffHeaderStyle.Render(path))
+	for _, change := range changes {
+		location := change.Path
+		if change.Line > 0 {
+			location = fmt.Sprintf("line %d: %s", change.Line, change.Path)
+		}
+		fmt.Println(infoStyle.Render(fmt.Sprintf("  [%s] %s", change.Rule, location)))
+		printSnippet("-", change.Before)
+		printSnippet("+", change.After)
+	}
+	fmt.Println()
+}
+
+func printSnippet(prefix, snippet string) {
+	for _, line := range strings.Split(snippet, "\n") {
+		fmt.Println("    " + colorizeDiffLine(prefix+" "+line))
+	}
+}
+
+// displayMigrationRules lists every migration rule
+func displayMigrationRules() {
+	fmt.Println(titleStyle.Render("📜 Migration Rules"))
+	for _, rule := range migrate.Rules() {
+		fmt.Printf("  %-34s %-11s %s\n", rule.ID, rule.Target, rule.Description)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,8 +9,16 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
 )
 
+// generateInCwd renders an artifact into the current directory
+func generateInCwd(config *ProjectConfig, kind generator.ArtifactKind) error {
+	return generateArtifact(context.Background(), config, kind, ".", NewBackupRecorder(".", "test", logger))
+}
+
 // BenchmarkProjectDetection benchmarks project detection performance
 func BenchmarkProjectDetection(b *testing.B) {
 	// Create a temporary project for benchmarking
@@ -61,23 +70,23 @@ go 1.21
 	config := &ProjectConfig{
 		ProjectName:        "config-benchmark",
 		ProjectDescription: "A benchmark test project",
-		ProjectType:        "CLI Application",
+		ProjectType:        domain.ProjectTypeCLI,
 		BinaryName:         "config-benchmark",
 		MainPath:           ".",
-		Platforms:          []string{"linux", "darwin", "windows"},
-		Architectures:      []string{"amd64", "arm64"},
-		CGOEnabled:         false,
-		GitProvider:        "GitHub",
-		DockerEnabled:      true,
-		DockerRegistry:     "ghcr.io/user",
-		Signing:            true,
+		Platforms:          []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin, domain.PlatformWindows},
+		Architectures:      []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64},
+		CGOStatus:          domain.CGOStatusDisabled,
+		GitProvider:        domain.GitProviderGitHub,
+		DockerSupport:      domain.DockerSupportBoth,
+		DockerRegistry:     domain.DockerRegistryGitHub,
+		SigningLevel:       domain.SigningLevelAdvanced,
 		Homebrew:           true,
-		GenerateActions:    true,
-		ActionsOn:          []string{"On version tags (v*)"},
+		ActionLevel:        domain.ActionLevelBasic,
+		ActionsOn:          []domain.ActionTrigger{domain.ActionTriggerVersionTags},
 	}
 
 	for b.Loop() {
-		err := generateGoReleaserConfig(config)
+		err := generateInCwd(config, generator.ArtifactGoReleaserConfig)
 		if err != nil {
 			b.Fatalf("Config generation failed: %v", err)
 		}
@@ -104,16 +113,16 @@ go 1.21
 	os.Chdir(tmpDir)
 
 	config := &ProjectConfig{
-		ProjectName:     "actions-benchmark",
-		BinaryName:      "actions-benchmark",
-		GenerateActions: true,
-		DockerEnabled:   true,
-		Signing:         true,
-		ActionsOn:       []string{"On all tags"},
+		ProjectName:   "actions-benchmark",
+		BinaryName:    "actions-benchmark",
+		ActionLevel:   domain.ActionLevelBasic,
+		DockerSupport: domain.DockerSupportBoth,
+		SigningLevel:  domain.SigningLevelAdvanced,
+		ActionsOn:     []domain.ActionTrigger{domain.ActionTriggerAllTags},
 	}
 
 	for b.Loop() {
-		err := generateGitHubActions(config)
+		err := generateInCwd(config, generator.ArtifactWorkflow)
 		if err != nil {
 			b.Fatalf("GitHub Actions generation failed: %v", err)
 		}
@@ -126,9 +135,8 @@ func BenchmarkFileOperations(b *testing.B) {
 	tmpDir, _ := os.MkdirTemp("", "wizard-fileops-benchmark")
 	defer os.RemoveAll(tmpDir)
 
-	originalDir, _ := os.Getwd()
-	defer os.Chdir(originalDir)
-	os.Chdir(tmpDir)
+	ctx := context.Background()
+	repo := &SimpleFileSystemRepository{}
 
 	testContent := []string{
 		"Small content for testing file operations",
@@ -138,18 +146,18 @@ func BenchmarkFileOperations(b *testing.B) {
 
 	for i := 0; b.Loop(); i++ {
 		content := testContent[i%len(testContent)]
-		filename := fmt.Sprintf("benchmark-file-%d.txt", i)
+		filename := filepath.Join(tmpDir, fmt.Sprintf("benchmark-file-%d.txt", i))
 
 		// Test write operation
-		err := SafeFileWrite(filename, []byte(content), 0644)
+		err := repo.WriteFile(ctx, filename, []byte(content), 0644)
 		if err != nil {
-			b.Fatalf("SafeFileWrite failed: %v", err)
+			b.Fatalf("WriteFile failed: %v", err)
 		}
 
 		// Test read operation
-		readContent, err := SafeReadFile(filename)
+		readContent, err := repo.ReadFile(ctx, filename)
 		if err != nil {
-			b.Fatalf("SafeReadFile failed: %v", err)
+			b.Fatalf("ReadFile failed: %v", err)
 		}
 
 		if string(readContent) != content {
@@ -191,12 +199,12 @@ func TestPerformanceCharacteristics(t *testing.T) {
 			config := &ProjectConfig{}
 			detectProjectInfo(config)
 
-			err := generateGoReleaserConfig(config)
+			err := generateInCwd(config, generator.ArtifactGoReleaserConfig)
 			if err != nil {
 				t.Errorf("Config generation failed: %v", err)
 			}
 
-			err = generateGitHubActions(config)
+			err = generateInCwd(config, generator.ArtifactWorkflow)
 			if err != nil {
 				t.Errorf("GitHub Actions generation failed: %v", err)
 			}
@@ -240,7 +248,7 @@ func TestMemoryUsage(t *testing.T) {
 		// Run wizard operations
 		config := &ProjectConfig{}
 		detectProjectInfo(config)
-		generateGoReleaserConfig(config)
+		generateInCwd(config, generator.ArtifactGoReleaserConfig)
 	}
 
 	// Get final memory stats
@@ -281,14 +289,14 @@ func TestConcurrentOperations(t *testing.T) {
 			os.WriteFile(filepath.Join(tmpDir, "go.mod"), []byte(goMod), 0644)
 			os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n\nfunc main() {}"), 0644)
 
-			originalDir, _ := os.Getwd()
-			defer os.Chdir(originalDir)
-			os.Chdir(tmpDir)
-
-			// Run wizard operations
-			config := &ProjectConfig{}
-			detectProjectInfo(config)
-			err := generateGoReleaserConfig(config)
+			// Run wizard operations; the working directory is shared, so
+			// each project is generated by path
+			config := &ProjectConfig{
+				ProjectName: fmt.Sprintf("concurrent-test-%d", id),
+				BinaryName:  fmt.Sprintf("concurrent-test-%d", id),
+				MainPath:    ".",
+			}
+			err := generateArtifact(context.Background(), config, generator.ArtifactGoReleaserConfig, tmpDir, NewBackupRecorder(tmpDir, "test", logger))
 
 			if err != nil {
 				errors <- fmt.Errorf("project %d: %v", id, err)
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/LarsArtmann/template-GoReleaser/internal/policy"
+	"github.com/spf13/viper"
+)
+
+// loadPolicy loads the organization policy of a project: the file named by
+// 'policy' in the user config, or .goreleaser-wizard-policy.yaml in root.
+// It returns nil when neither exists.
+func loadPolicy(root string) (*policy.Policy, error) {
+	path := policy.Find(root, viper.GetString("policy"))
+	if path == "" {
+		return nil, nil
+	}
+	return policy.Load(path)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/presets"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var presetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List and inspect answer presets",
+	Long: `Presets are named sets of answers for common release shapes, applied with
+'goreleaser-wizard init --preset <name>'. Several presets can be combined;
+later ones win.
+
+Your own presets live in the directory named by 'presets' in the user config
+($HOME/.goreleaser-wizard.yaml), one file each:
+
+  description: Our API services
+  rationale: Why these answers fit
+  answers:
+    project_type: api
+    docker_registry: quay.io
+
+A preset with the name of a built-in one replaces it.`,
+}
+
+var presetsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available presets",
+	Args:  cobra.NoArgs,
+	Run:   runPresetsList,
+}
+
+var presetsShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show the answers of a preset and why it chooses them",
+	Args:  cobra.ExactArgs(1),
+	Run:   runPresetsShow,
+}
+
+func init() {
+	presetsCmd.AddCommand(presetsListCmd, presetsShowCmd)
+}
+
+// loadPresets loads the built-in presets and those in the directory named by
+// 'presets' in the user config
+func loadPresets() (*presets.Catalog, error) {
+	return presets.Load(viper.GetString("presets"))
+}
+
+func runPresetsList(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("presets list command")
+
+	catalog, err := loadPresets()
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(titleStyle.Render("🎛️  Presets"))
+	for _, preset := range catalog.List() {
+		origin := "built-in"
+		if !preset.Builtin() {
+			origin = preset.Source
+		}
+		fmt.Printf("  %-18s %s %s\n", preset.Name, preset.Description, infoStyle.Render("("+origin+")"))
+	}
+	fmt.Println()
+	fmt.Println(infoStyle.Render("💡 Use 'goreleaser-wizard presets show <name>' for details"))
+}
+
+func runPresetsShow(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("presets show command")
+
+	catalog, err := loadPresets()
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	preset, err := catalog.Get(args[0])
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	data, err := preset.Answers()
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(titleStyle.Render("🎛️  " + preset.Name))
+	fmt.Println(preset.Description)
+	if !preset.Builtin() {
+		fmt.Println(infoStyle.Render("From " + preset.Source))
+	}
+	if preset.Rationale != "" {
+		fmt.Println()
+		fmt.Println(preset.Rationale)
+	}
+	fmt.Println()
+	fmt.Println(diffHeaderStyle.Render("Answers"))
+	fmt.Print(string(data))
+	fmt.Println()
+	fmt.Println(infoStyle.Render(fmt.Sprintf("💡 Apply with 'goreleaser-wizard init --preset %s'", preset.Name)))
+}
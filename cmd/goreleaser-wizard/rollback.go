@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Restore the files changed by a previous run",
+	Long: `Undo a generate, update, migrate or init run.
+
+Before a command changes a file, the original is saved in the backup catalog
+under .goreleaser-wizard/backups. Rollback restores every file of a run at
+once; files the run created are removed.
+
+Without flags the latest run that was not rolled back yet is restored. The
+rollback itself is recorded too, so it can be undone with --to.`,
+	Run: runRollback,
+}
+
+func init() {
+	rollbackCmd.Flags().String("dir", ".", "project directory")
+	rollbackCmd.Flags().Bool("list", false, "list the recorded runs")
+	rollbackCmd.Flags().String("to", "", "id of the run to restore")
+}
+
+func runRollback(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("rollback command")
+
+	dir, _ := cmd.Flags().GetString("dir")
+	list, _ := cmd.Flags().GetBool("list")
+	runID, _ := cmd.Flags().GetString("to")
+
+	if list {
+		if err := displayBackupRuns(dir); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Println(titleStyle.Render("⏪ Rolling Back"))
+	fmt.Println()
+
+	builder := NewWorkflowBuilder(logger)
+	builder.SetRootDir(dir)
+
+	workflow, err := builder.BuildRollbackWorkflow(runID)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if err := workflow.Execute(context.Background()); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(successStyle.Render("✅ Files restored"))
+}
+
+// displayBackupRuns prints the backup catalog, newest run first
+func displayBackupRuns(dir string) error {
+	runs, err := state.NewStore(dir).Runs()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("🗄️  Backup Catalog"))
+	if len(runs) == 0 {
+		fmt.Println(infoStyle.Render("No runs recorded yet"))
+		return nil
+	}
+
+	fmt.Printf("  %-36s %-10s %-20s %5s  %s\n", "ID", "COMMAND", "CREATED", "FILES", "STATUS")
+	for _, run := range runs {
+		status := ""
+		if run.RolledBackAt != nil {
+			status = "rolled back " + run.RolledBackAt.Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Printf("  %-36s %-10s %-20s %5d  %s\n",
+			run.ID,
+			run.Command,
+			run.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+			len(run.Files),
+			status,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/LarsArtmann/template-GoReleaser/internal/merge"
+	"github.com/spf13/cobra"
+)
+
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update generated files while preserving hand edits",
+	Long: `Regenerate release artifacts from the saved wizard answers and merge them
+into the files on disk.
+
+Every generate records its output in .goreleaser-wizard/ as merge base.
+update performs a three-way merge of that base, your edited file and the
+newly generated file, so local edits survive. YAML files are merged per
+key and list item, keeping your comments; other files are merged per
+line. Conflicting edits are resolved interactively; in non-interactive
+mode conflict markers are written and the command exits non-zero.`,
+	Run: runUpdate,
+}
+
+func init() {
+	updateCmd.Flags().String("answers", answersFileName, "wizard answers file to generate from")
+	updateCmd.Flags().String("dir", ".", "directory containing the files to update")
+	updateCmd.Flags().Bool("dry-run", false, "report what would change without writing files")
+	updateCmd.Flags().Bool("non-interactive", false, "write conflict markers instead of prompting")
+}
+
+func runUpdate(cmd *cobra.Command, args []string) {
+	// Set up panic recovery using domain error handling
+	defer recoverFromPanic("update command")
+
+	answersPath, _ := cmd.Flags().GetString("answers")
+	dir, _ := cmd.Flags().GetString("dir")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+
+	config, err := loadAnswersFile(answersPath)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	fmt.Println(titleStyle.Render("🔄 Updating Release Artifacts"))
+	fmt.Println()
+
+	conflicted, err := updateArtifacts(answersPath, dir, config, dryRun, !nonInteractive && isInteractive())
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if dryRun {
+		return
+	}
+
+	// Leave a clear signal when conflict markers were written
+	if len(conflicted) > 0 {
+		displayConflicts(conflicted)
+		os.Exit(1)
+	}
+	fmt.Println(successStyle.Render("✅ Release artifacts are up to date"))
+}
+
+// updateArtifacts merges freshly generated artifacts into the files below dir
+// and records the generation in the answers file. It returns the files left
+// with conflict markers, in which case the generation is not recorded.
+func updateArtifacts(answersPath, dir string, config *ProjectConfig, dryRun, interactive bool) ([]string, error) {
+	builder := NewWorkflowBuilder(logger)
+	builder.SetRootDir(dir)
+	if interactive && !dryRun {
+		builder.SetConflictResolver(newPromptResolver(bufio.NewReader(os.Stdin)))
+	}
+
+	workflow, err := builder.BuildUpdateWorkflow(config, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	if !dryRun {
+		if err := answersStore.BeginGeneration(config); err != nil {
+			return nil, err
+		}
+	}
+	if err := workflow.Execute(context.Background()); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return nil, nil
+	}
+
+	if conflicted := conflictedArtifacts(dir, generator.Planned(config)); len(conflicted) > 0 {
+		return conflicted, nil
+	}
+	return nil, recordGeneration(answersPath, config)
+}
+
+// displayConflicts lists the files that still contain conflict markers
+func displayConflicts(conflicted []string) {
+	fmt.Println(errorStyle.Render("❌ Conflicts need to be resolved in:"))
+	for _, path := range conflicted {
+		fmt.Println(infoStyle.Render("  • " + path))
+	}
+}
+
+// isInteractive reports whether stdin is a terminal
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// conflictedArtifacts returns the artifact paths that still contain conflict markers
+func conflictedArtifacts(dir string, kinds []generator.ArtifactKind) []string {
+	var conflicted []string
+	for _, kind := range kinds {
+		path := artifactPath(dir, kind.Path())
+		data, err := os.ReadFile(path)
+		if err == nil && merge.HasMarkers(string(data)) {
+			conflicted = append(conflicted, path)
+		}
+	}
+	return conflicted
+}
+
+// newPromptResolver asks on the terminal how each conflict is resolved
+func newPromptResolver(input *bufio.Reader) ConflictResolver {
+	return func(path string, index int, conflict merge.Conflict) merge.Resolution {
+		fmt.Println()
+		fmt.Println(errorStyle.Render(fmt.Sprintf("⚠️  Conflict %d in %s", index+1, path)))
+		fmt.Println(diffDeleteStyle.Render("--- " + merge.DefaultLabels.Ours))
+		printConflictSide(conflict.Ours)
+		fmt.Println(diffAddStyle.Render("+++ " + merge.DefaultLabels.Theirs))
+		printConflictSide(conflict.Theirs)
+
+		for {
+			fmt.Print("Keep [l]ocal, [g]enerated, [b]oth, or write [m]arkers? ")
+			answer, err := input.ReadString('\n')
+			if err != nil {
+				return merge.ResolveMarkers
+			}
+
+			switch strings.ToLower(strings.TrimSpace(answer)) {
+			case "l", "local":
+				return merge.ResolveOurs
+			case "g", "generated":
+				return merge.ResolveTheirs
+			case "b", "both":
+				return merge.ResolveBoth
+			case "m", "markers":
+				return merge.ResolveMarkers
+			}
+		}
+	}
+}
+
+func printConflictSide(lines []string) {
+	if len(lines) == 0 {
+		fmt.Println(infoStyle.Render("  (nothing)"))
+		return
+	}
+	for _, line := range lines {
+		fmt.Println("  " + strings.TrimSuffix(line, "\n"))
+	}
+}
@@ -8,11 +8,18 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/LarsArtmann/template-GoReleaser/internal/discovery"
 	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
-	"github.com/charmbracelet/log"
+	"github.com/LarsArtmann/template-GoReleaser/internal/lint"
+	"github.com/LarsArtmann/template-GoReleaser/internal/policy"
+	"github.com/LarsArtmann/template-GoReleaser/internal/report"
+	"github.com/LarsArtmann/template-GoReleaser/internal/schema"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v3"
 )
 
 var (
@@ -27,18 +34,96 @@ var validateCmd = &cobra.Command{
 	Long: `Validate your GoReleaser configuration and check for common issues.
 
 This command will:
-- Check if .goreleaser.yaml exists and is valid YAML
+- Find every GoReleaser configuration (.goreleaser.yml, .goreleaser.yaml,
+  goreleaser.yml, goreleaser.yaml) below --root and check it is valid YAML
+- Validate it offline against the bundled GoReleaser schema; top-level keys
+  the bundled schema does not know are warnings
+- Lint it for logic bugs such as duplicate build ids
+- Cross-check each release workflow against the configuration it releases
+  with (its goreleaser workdir and --config) and that module's go.mod (docker login,
+  id-token permission, fetch-depth, publisher tokens, Go and GoReleaser versions)
+- Flag security risks in every .github/workflows file: actions not pinned to a
+  commit SHA, missing permissions, pull_request_target checking out the pull
+  request, and event data interpolated into run scripts
 - Run goreleaser check if available
 - Verify project structure matches configuration
 - Check for missing dependencies
-- Suggest improvements`,
+- Suggest improvements
+
+In a monorepo every configuration and workflow is validated and reported in
+its own section, and the project structure is checked for every module that
+has a configuration. Workflows are read from the root of the git repository,
+so --root can name a single module. --goreleaser-config limits validation to
+the named configurations.
+
+  goreleaser-wizard validate --root services
+  goreleaser-wizard validate --goreleaser-config api/.goreleaser.yaml --goreleaser-config cli/.goreleaser.yaml
+
+An organization policy in .goreleaser-wizard-policy.yaml (or the file named
+by 'policy' in the user config) is enforced on the wizard answers and every
+configuration; violations are errors reported under policy-* rule ids:
+
+  version: 1
+  signing:
+    min_level: advanced          # signs; docker_signs for published images
+  sbom:
+    required: true
+  docker:
+    allowed_registries: [ghcr.io]
+  cgo:
+    forbidden: [required]        # CGO_ENABLED=1 builds
+  archives:
+    required_files: [LICENSE]
+
+Lint rules can be switched off in the user config:
+
+  lint:
+    disable: [mod-timestamp]   # or [all]
+    enable: []                 # re-enables rules after disable
+
+A single finding is suppressed with a '# wizard:ignore <rule-id>' comment at
+the end of its line or on the line above.
+
+--format selects the output: text (default), json, sarif for code scanning
+uploads, junit for CI dashboards, or github for workflow annotations. Findings
+carry a stable rule id: the lint rule, or the error code. The exit code is 0
+when clean, 1 on errors and 2 on warnings, whatever the format.
+
+Many lint findings come with a fix. They are previewed as a diff; --fix
+applies the safe ones and --fix-unsafe also those that change what a release
+produces or may do, such as archive formats or workflow permissions. Fixed
+files are backed up for 'goreleaser-wizard rollback' and validated again.`,
 	Run: runValidate,
 }
 
 func init() {
 	validateCmd.Flags().Bool("verbose", false, "show detailed validation output")
-	validateCmd.Flags().Bool("fix", false, "attempt to fix common issues")
+	validateCmd.Flags().Bool("fix", false, "apply the safe fixes offered by lint rules")
+	validateCmd.Flags().Bool("fix-unsafe", false, "also apply fixes that change what a release produces or may do")
 	validateCmd.Flags().Bool("project-only", false, "validate project structure only")
+	validateCmd.Flags().String("schema", "auto", "bundled schema to validate against: auto, v1, v2 or pro")
+	validateCmd.Flags().Bool("list-rules", false, "list the lint rules and whether they are enabled")
+	validateCmd.Flags().String("format", "text", "output format: text, json, sarif, junit or github")
+	validateCmd.Flags().StringSlice("goreleaser-config", nil, "GoReleaser configuration to validate; repeatable (default: every configuration found under --root)")
+	validateCmd.Flags().String("root", ".", "project root searched for GoReleaser configurations")
+}
+
+// ValidationOptions selects the files to validate and the checks run on them
+type ValidationOptions struct {
+	SchemaVersion schema.Version // empty to detect it from the configuration
+	Lint          *lint.Engine
+	Root          string         // project root searched for configurations
+	GitRoot       string         // repository root holding the workflows; empty for Root
+	Configs       []string       // GoReleaser configurations; empty to discover them below Root
+	Policy        *policy.Policy // organization policy, nil when none applies
+}
+
+// workflowRoot returns the directory holding .github/workflows
+func (vo *ValidationOptions) workflowRoot() string {
+	if vo.GitRoot != "" {
+		return vo.GitRoot
+	}
+	return vo.Root
 }
 
 func runValidate(cmd *cobra.Command, args []string) {
@@ -47,64 +132,247 @@ func runValidate(cmd *cobra.Command, args []string) {
 
 	verbose, _ := cmd.Flags().GetBool("verbose")
 	fix, _ := cmd.Flags().GetBool("fix")
+	fixUnsafe, _ := cmd.Flags().GetBool("fix-unsafe")
 	projectOnly, _ := cmd.Flags().GetBool("project-only")
+	schemaName, _ := cmd.Flags().GetString("schema")
+	listRules, _ := cmd.Flags().GetBool("list-rules")
+	formatName, _ := cmd.Flags().GetString("format")
+	configs, _ := cmd.Flags().GetStringSlice("goreleaser-config")
+	root, _ := cmd.Flags().GetString("root")
 
-	fmt.Println(titleStyle.Render("🔍 Validating GoReleaser Configuration"))
-	fmt.Println()
+	options, err := newValidationOptions(schemaName)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if err := validateFileExists(root, true); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	options.Root = filepath.Clean(root)
+	options.GitRoot = discovery.GitRoot(options.Root)
+	for _, config := range configs {
+		options.Configs = append(options.Configs, filepath.Clean(config))
+	}
+	if options.Policy, err = loadPolicy(options.Root); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	if listRules {
+		displayLintRules(os.Stdout, options.Lint)
+		return
+	}
+
+	format, err := report.ParseFormat(formatName)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	fix = fix || fixUnsafe
+	if fix && format != report.FormatText {
+		displayError(domain.NewValidationError(
+			domain.ErrUnknownOutputFormat,
+			"--fix needs text output",
+			fmt.Sprintf("Fixes are reported as text and cannot be combined with --format %s", format),
+		))
+		os.Exit(1)
+	}
+
+	if format == report.FormatText {
+		fmt.Println(titleStyle.Render("🔍 Validating GoReleaser Configuration"))
+		fmt.Println()
+	}
 
 	// Initialize dependencies (in real implementation, this would be injected)
 	fileSystemRepo = &SimpleFileSystemRepository{}
-	validationUseCase = domain.NewValidationUseCase(logger, fileSystemRepo)
+	validationUseCase = domain.NewValidationUseCase(appLogger, fileSystemRepo)
+
+	results, err := collectValidationResults(options, projectOnly)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+
+	// Machine-readable formats go to stdout on their own
+	if format != report.FormatText {
+		if err := report.Write(os.Stdout, format, newReport(results, options)); err != nil {
+			displayError(err)
+			os.Exit(1)
+		}
+		os.Exit(results.GetExitCode())
+	}
+
+	// Display results
+	displayValidationResults(results, verbose)
+
+	plan, err := planFixes(results.fixable, fixUnsafe)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	if plan.applied > 0 {
+		displayFixPreview(plan)
+	}
+
+	if !fix {
+		if plan.applied > 0 {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("💡 Run with --fix to apply %d fixes", plan.applied)))
+		}
+		if plan.skipped > 0 {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("💡 %d more fixes change release behavior and need --fix-unsafe", plan.skipped)))
+		}
+		os.Exit(results.GetExitCode())
+	}
+
+	if plan.applied == 0 {
+		fmt.Println(infoStyle.Render("ℹ️  No auto-fixable issues found"))
+		if plan.skipped > 0 {
+			fmt.Println(infoStyle.Render(fmt.Sprintf("💡 %d fixes change release behavior and need --fix-unsafe", plan.skipped)))
+		}
+		os.Exit(results.GetExitCode())
+	}
+
+	if err := writeFixes(plan); err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	fmt.Println(successStyle.Render(fmt.Sprintf("✅ Applied %d fixes", plan.applied)))
+	fmt.Println()
+
+	// Re-validate so the summary and exit code reflect the fixed files
+	fmt.Println(titleStyle.Render("🔍 Re-validating"))
+	fmt.Println()
+	results, err = collectValidationResults(options, projectOnly)
+	if err != nil {
+		displayError(err)
+		os.Exit(1)
+	}
+	displayValidationResults(results, verbose)
 
-	// Collect validation results
+	// Exit with appropriate code
+	os.Exit(results.GetExitCode())
+}
+
+// collectValidationResults runs every validation step
+func collectValidationResults(options *ValidationOptions, projectOnly bool) (*ValidationResults, error) {
 	results := &ValidationResults{}
+	if options.Policy != nil && !projectOnly {
+		results.Policy = options.Policy.Path()
+	}
 
 	if !projectOnly {
+		// Validate persisted wizard answers
+		if err := validateAnswers(results, options); err != nil {
+			return nil, err
+		}
+
 		// Validate GoReleaser configuration
-		if err := validateGoReleaserConfig(&results); err != nil {
-			displayError(err)
-			return
+		if err := validateGoReleaserConfig(results, options); err != nil {
+			return nil, err
 		}
 
 		// Validate GitHub Actions workflow
-		if err := validateGitHubActions(&results); err != nil {
-			displayError(err)
-			return
+		if err := validateGitHubActions(results, options); err != nil {
+			return nil, err
 		}
 	}
 
 	// Validate project structure
-	if err := validateProjectStructure(&results); err != nil {
-		displayError(err)
-		return
+	if err := validateProjectStructure(results, options); err != nil {
+		return nil, err
 	}
 
-	// Display results
-	displayValidationResults(&results, verbose)
+	results.finishFiles()
+	return results, nil
+}
 
-	// Attempt fixes if requested
-	if fix && len(results.Errors) > 0 {
-		if err := attemptFixes(&results); err != nil {
-			displayError(err)
-			return
+// newValidationOptions resolves the --schema flag and the lint rules
+// selected in the user config
+func newValidationOptions(schemaName string) (*ValidationOptions, error) {
+	options := &ValidationOptions{Lint: lint.NewEngine(lint.DefaultRules()...)}
+
+	if schemaName != "auto" {
+		version, err := schema.ParseVersion(schemaName)
+		if err != nil {
+			return nil, err
 		}
+		options.SchemaVersion = version
 	}
 
-	// Exit with appropriate code
-	os.Exit(results.GetExitCode())
+	enable := viper.GetStringSlice("lint.enable")
+	disable := viper.GetStringSlice("lint.disable")
+	if err := options.Lint.Configure(enable, disable); err != nil {
+		return nil, asDomainError(err).WithContext(viper.ConfigFileUsed())
+	}
+	return options, nil
 }
 
 // ValidationResults holds all validation results
 type ValidationResults struct {
+	AnswersExists   bool
+	AnswersValid    bool
 	ConfigExists    bool
 	ConfigValid     bool
 	ActionsExists   bool
 	ActionsValid    bool
 	ProjectValid    bool
 	GoReleaserFound bool
+	Policy          string // policy file enforced, empty when none applies
+	Files           []*FileResult
 	Errors         []*domain.DomainError
 	Warnings       []*domain.DomainError
 	Recommendations []string
+
+	configRoots       map[string]*yaml.Node // parsed configurations, used to cross-check workflows
+	fixable           []*fixableFile        // fixes offered by lint rules, per file
+	goreleaserMissing bool                  // the missing goreleaser binary was reported
+}
+
+// FileResult is the outcome of validating one configuration or workflow
+type FileResult struct {
+	Path          string
+	Kind          string // "goreleaser" or "workflow"
+	Valid         bool
+	SchemaVersion schema.Version // GoReleaser configurations only
+}
+
+// addFile starts the result of a validated file
+func (vr *ValidationResults) addFile(path, kind string) *FileResult {
+	file := &FileResult{Path: path, Kind: kind}
+	vr.Files = append(vr.Files, file)
+	return file
+}
+
+// filesOfKind returns the validated files of one kind
+func (vr *ValidationResults) filesOfKind(kind string) []*FileResult {
+	var files []*FileResult
+	for _, file := range vr.Files {
+		if file.Kind == kind {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// finishFiles marks every file without errors valid and derives the overall status
+func (vr *ValidationResults) finishFiles() {
+	failed := make(map[string]bool)
+	for _, err := range vr.Errors {
+		failed[err.Context] = true
+	}
+	for _, file := range vr.Files {
+		file.Valid = !failed[file.Path]
+	}
+
+	vr.ConfigValid = vr.ConfigExists
+	for _, file := range vr.filesOfKind("goreleaser") {
+		vr.ConfigValid = vr.ConfigValid && file.Valid
+	}
+	vr.ActionsValid = true
+	for _, file := range vr.filesOfKind("workflow") {
+		vr.ActionsValid = vr.ActionsValid && file.Valid
+	}
 }
 
 // GetExitCode returns appropriate exit code
@@ -118,229 +386,465 @@ func (vr *ValidationResults) GetExitCode() int {
 	return 0
 }
 
-// validateGoReleaserConfig validates GoReleaser configuration
-func validateGoReleaserConfig(results *ValidationResults) error {
-	configPath := ".goreleaser.yaml"
+// newReport converts validation results into a format-independent report
+func newReport(results *ValidationResults, options *ValidationOptions) *report.Report {
+	status := func(exists, valid bool) string {
+		switch {
+		case !exists:
+			return "missing"
+		case valid:
+			return "valid"
+		default:
+			return "invalid"
+		}
+	}
+
+	descriptions := make(map[string]string)
+	for _, rule := range options.Lint.Rules() {
+		descriptions[rule.ID] = rule.Description
+	}
+
+	checks := []report.Check{
+		{Name: "answers", Status: status(results.AnswersExists, results.AnswersValid)},
+		{Name: "goreleaser", Status: status(results.ConfigExists, results.ConfigValid)},
+		{Name: "workflows", Status: status(results.ActionsExists, results.ActionsValid)},
+		{Name: "project", Status: status(true, results.ProjectValid)},
+	}
+	for _, file := range results.Files {
+		checks = append(checks, report.Check{Name: file.Path, Status: status(true, file.Valid)})
+	}
+
+	return &report.Report{
+		Tool:             "goreleaser-wizard",
+		Version:          version,
+		Checks:           checks,
+		Errors:           results.Errors,
+		Warnings:         results.Warnings,
+		Recommendations:  results.Recommendations,
+		ExitCode:         results.GetExitCode(),
+		RuleDescriptions: descriptions,
+	}
+}
 
-	// Check if config exists
-	exists, err := fileSystemRepo.FileExists(context.Background(), configPath)
+// validateAnswers validates the persisted wizard answers file, if present.
+// Every invariant violation is reported, not just the first one.
+func validateAnswers(results *ValidationResults, options *ValidationOptions) error {
+	answersFileName := filepath.Join(options.Root, answersFileName)
+	exists, err := fileSystemRepo.FileExists(context.Background(), answersFileName)
 	if err != nil {
-		results.Errors = append(results.Errors, 
+		results.Warnings = append(results.Warnings,
 			domain.NewSystemError(
 				domain.ErrFileReadFailed,
-				"Failed to check configuration file",
-				fmt.Sprintf("Cannot access %s", configPath),
+				"Failed to check wizard answers file",
+				fmt.Sprintf("Cannot access %s", answersFileName),
 				err,
-			).WithContext(configPath))
+			).WithContext(answersFileName))
 		return nil
 	}
 
-	results.ConfigExists = exists
+	results.AnswersExists = exists
 	if !exists {
+		return nil
+	}
+
+	config, err := decodeAnswersFile(answersFileName)
+	if err != nil {
+		results.Errors = append(results.Errors, asDomainError(err).WithContext(answersFileName))
+		return nil
+	}
+
+	result, err := validationUseCase.ValidateConfiguration(context.Background(), config)
+	if err != nil {
+		return err
+	}
+
+	for _, violation := range result.Errors {
+		results.Errors = append(results.Errors, violation.WithContext(answersFileName))
+	}
+	for _, warning := range result.Warnings {
+		results.Warnings = append(results.Warnings, warning.WithContext(answersFileName))
+	}
+	results.AnswersValid = result.IsValid
+
+	if options.Policy != nil {
+		violations := options.Policy.Check(config)
+		for _, violation := range violations {
+			results.Errors = append(results.Errors, violation.WithContext(answersFileName))
+		}
+		results.AnswersValid = results.AnswersValid && len(violations) == 0
+	}
+	return nil
+}
+
+// validateGoReleaserConfig validates the GoReleaser configurations named with
+// --config, or every configuration found below the project root
+func validateGoReleaserConfig(results *ValidationResults, options *ValidationOptions) error {
+	paths := options.Configs
+	if len(paths) == 0 {
+		found, err := discovery.Configs(options.Root)
+		if err != nil {
+			results.Errors = append(results.Errors, asDomainError(err))
+			return nil
+		}
+		for _, rel := range found {
+			paths = append(paths, filepath.Join(options.Root, filepath.FromSlash(rel)))
+		}
+	}
+
+	results.ConfigExists = len(paths) > 0
+	if !results.ConfigExists {
+		configPath := filepath.Join(options.Root, ".goreleaser.yaml")
 		results.Errors = append(results.Errors,
 			domain.NewSystemError(
 				domain.ErrFileNotFound,
 				"Configuration file not found",
-				fmt.Sprintf("%s does not exist", configPath),
+				fmt.Sprintf("No GoReleaser configuration (%s) below %s", strings.Join(discovery.ConfigNames, ", "), options.Root),
 				nil,
 			).WithContext(configPath))
-		results.Recommendations = append(results.Recommendations, 
+		results.Recommendations = append(results.Recommendations,
 			"Run 'goreleaser-wizard init' to create configuration")
 		return nil
 	}
 
-	// Validate YAML syntax
-	if err := validateYAML(configPath, results); err != nil {
-		return err
+	results.configRoots = make(map[string]*yaml.Node)
+	for _, configPath := range paths {
+		if err := validateGoReleaserFile(configPath, results, options); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	// Try to parse and validate as GoReleaser config
-	if err := parseGoReleaserConfig(configPath, results); err != nil {
-		return err
+// validateGoReleaserFile validates one GoReleaser configuration
+func validateGoReleaserFile(configPath string, results *ValidationResults, options *ValidationOptions) error {
+	file := results.addFile(configPath, "goreleaser")
+
+	// Parse YAML, reporting syntax errors with their position
+	root, content, ok := validateYAML(configPath, yamlcheck.GoReleaserKinds, results)
+	if ok {
+		results.configRoots[configPath] = root
+		parseGoReleaserConfig(configPath, root, results)
+		if err := validateSchema(configPath, root, options.SchemaVersion, file, results); err != nil {
+			return err
+		}
+		addLintFindings(configPath, content, options.Lint.Run(lint.TargetGoReleaser, content, root, &lint.Project{Config: root}), results)
+		if options.Policy != nil {
+			for _, violation := range options.Policy.CheckConfig(root) {
+				results.Errors = append(results.Errors, violation.WithContext(configPath))
+			}
+		}
 	}
 
 	// Run goreleaser check if available
 	if err := runGoReleaserCheck(configPath, results); err != nil {
 		return nil // Not fatal, just record warning
 	}
-
-	results.ConfigValid = len(results.Errors) == 0
 	return nil
 }
 
-// validateGitHubActions validates GitHub Actions workflow
-func validateGitHubActions(results *ValidationResults) error {
-	workflowPath := ".github/workflows/release.yml"
-
-	// Check if workflow exists
-	exists, err := fileSystemRepo.FileExists(context.Background(), workflowPath)
+// validateGitHubActions validates every GitHub Actions workflow, lints them
+// for security risks and cross-checks release workflows against the
+// configuration they release with and its go.mod
+func validateGitHubActions(results *ValidationResults, options *ValidationOptions) error {
+	workflows, err := discovery.Workflows(options.workflowRoot())
 	if err != nil {
-		results.Warnings = append(results.Warnings,
-			domain.NewSystemError(
-				domain.ErrFileReadFailed,
-				"Failed to check GitHub Actions workflow",
-				fmt.Sprintf("Cannot access %s", workflowPath),
-				err,
-			).WithContext(workflowPath))
+		results.Warnings = append(results.Warnings, asDomainError(err))
 		return nil
 	}
 
-	results.ActionsExists = exists
-	if !exists {
+	for _, workflow := range workflows {
+		results.ActionsExists = results.ActionsExists || workflow.Release
+	}
+	if !results.ActionsExists {
 		results.Recommendations = append(results.Recommendations,
 			"Add GitHub Actions workflow for automated releases")
-		return nil
 	}
 
-	// Validate YAML syntax
-	if err := validateYAML(workflowPath, results); err != nil {
-		return err
-	}
+	for _, workflow := range workflows {
+		path := filepath.Join(options.workflowRoot(), filepath.FromSlash(workflow.Path))
+		results.addFile(path, "workflow")
 
-	// Validate workflow content
-	if err := validateWorkflowContent(workflowPath, results); err != nil {
-		return err
+		// Parse YAML, reporting syntax errors with their position
+		root, content, ok := validateYAML(path, yamlcheck.WorkflowKinds, results)
+		if ok {
+			validateWorkflowContent(path, root, results)
+			project := workflowProject(root, results, options)
+			addLintFindings(path, content, options.Lint.Run(lint.TargetWorkflow, content, root, project), results)
+		}
 	}
-
-	results.ActionsValid = len(results.Errors) == 0
 	return nil
 }
 
-// validateProjectStructure validates project structure
-func validateProjectStructure(results *ValidationResults) error {
-	// Get current working directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		results.Errors = append(results.Errors,
-			domain.NewSystemError(
-				domain.ErrPermissionDenied,
-				"Failed to get current directory",
-				"Cannot determine working directory",
-				err,
-			))
-		return nil
+// workflowProject finds the configuration a workflow releases with, from its
+// goreleaser workdir and --config, and the go.mod of that directory
+func workflowProject(workflow *yaml.Node, results *ValidationResults, options *ValidationOptions) *lint.Project {
+	project := &lint.Project{}
+	workdir, config, ok := lint.ReleaseConfig(workflow)
+	if !ok {
+		return project
 	}
 
-	// Use domain validation use case
-	ctx := context.Background()
-	result, err := validationUseCase.ValidateProjectStructure(ctx, cwd)
-	if err != nil {
-		results.Errors = append(results.Errors, err.(*domain.DomainError))
-		return nil
+	// The workdir is relative to the checkout, the root of the repository
+	dir := filepath.Join(options.workflowRoot(), filepath.FromSlash(workdir))
+	candidates := []string{filepath.Join(dir, filepath.FromSlash(config))}
+	if config == "" {
+		candidates = candidates[:0]
+		for _, name := range discovery.ConfigNames {
+			candidates = append(candidates, filepath.Join(dir, name))
+		}
+	}
+	for _, candidate := range candidates {
+		if root, found := results.configRoots[candidate]; found {
+			project.Config = root
+			break
+		}
 	}
 
-	results.ProjectValid = result.IsValid
-	results.Errors = append(results.Errors, result.Issues...)
-	results.Warnings = append(results.Warnings, result.Warnings...)
-	results.Recommendations = append(results.Recommendations, result.Recommendations...)
-
-	return nil
+	if moduleDir := discovery.ModuleDir(options.workflowRoot(), dir); moduleDir != "" {
+		if gomod, err := os.ReadFile(filepath.Join(moduleDir, "go.mod")); err == nil {
+			project.GoVersion = lint.ParseGoVersion(gomod)
+		}
+	}
+	return project
 }
 
-// validateYAML validates YAML syntax
-func validateYAML(filePath string, results *ValidationResults) error {
-	data, err := os.ReadFile(filePath)
-	if err != nil {
-		results.Errors = append(results.Errors,
-			domain.NewSystemError(
-				domain.ErrFileReadFailed,
-				"Failed to read file",
-				fmt.Sprintf("Cannot read %s", filePath),
-				err,
-			).WithContext(filePath))
-		return nil
+// validateProjectStructure validates the structure of every module that has
+// a GoReleaser configuration, or of the project root when there is none
+func validateProjectStructure(results *ValidationResults, options *ValidationOptions) error {
+	configs := options.Configs
+	if len(configs) == 0 {
+		// Discovery problems were reported with the configurations
+		found, _ := discovery.Configs(options.Root)
+		for _, rel := range found {
+			configs = append(configs, filepath.Join(options.Root, filepath.FromSlash(rel)))
+		}
 	}
-
-	// Simple YAML validation - check for balanced brackets and quotes
-	content := string(data)
-	if !isValidYAML(content) {
-		results.Errors = append(results.Errors,
-			domain.NewTemplateError(
-				domain.ErrTemplateSyntaxError,
-				"Invalid YAML syntax",
-				fmt.Sprintf("File %s contains YAML syntax errors", filePath),
-			).WithContext(filePath))
-		return nil
+	modules := discovery.ModuleDirs(options.Root, configs)
+
+	// Group the configurations by the module they build
+	moduleConfigs := make(map[string][]domain.ReleaseConfig)
+	for _, config := range configs {
+		module := discovery.ModuleDir(options.Root, filepath.Dir(config))
+		if module == "" {
+			module = filepath.Dir(config)
+		}
+		moduleConfigs[module] = append(moduleConfigs[module], domain.ReleaseConfig{
+			Path:  config,
+			Mains: buildMains(config, module, results),
+		})
 	}
 
-	return nil
-}
-
-// isValidYAML performs basic YAML validation
-func isValidYAML(content string) bool {
-	// This is a basic check - in real implementation, use yaml parser
-	indent := 0
-	inString := false
-	lines := strings.Split(content, "\n")
+	results.ProjectValid = true
+	seen := make(map[string]bool)
+	for _, module := range modules {
+		// Resolve the module directory
+		dir, err := filepath.Abs(module)
+		if err != nil {
+			results.ProjectValid = false
+			results.Errors = append(results.Errors,
+				domain.NewSystemError(
+					domain.ErrPermissionDenied,
+					"Failed to resolve project directory",
+					fmt.Sprintf("Cannot determine the absolute path of %s", module),
+					err,
+				))
+			continue
+		}
 
-	for _, line := range lines {
-		line = strings.TrimSpace(line)
-		if line == "" || strings.HasPrefix(line, "#") {
+		// Use domain validation use case
+		ctx := context.Background()
+		result, err := validationUseCase.ValidateProjectStructure(ctx, dir, moduleConfigs[module]...)
+		if err != nil {
+			results.ProjectValid = false
+			results.Errors = append(results.Errors, asDomainError(err))
 			continue
 		}
 
-		// Count brackets and braces
-		for _, char := range line {
-			switch char {
-			case '"':
-				inString = !inString
-			case '{':
-				if !inString {
-					indent++
-				}
-			case '}':
-				if !inString {
-					indent--
-				}
+		results.ProjectValid = results.ProjectValid && result.IsValid
+		for _, issue := range result.Issues {
+			if len(modules) > 1 {
+				issue = issue.WithContext(module)
+			}
+			results.Errors = append(results.Errors, issue)
+		}
+		for _, warning := range result.Warnings {
+			if len(modules) > 1 {
+				warning = warning.WithContext(module)
+			}
+			results.Warnings = append(results.Warnings, warning)
+		}
+		for _, recommendation := range result.Recommendations {
+			if !seen[recommendation] {
+				seen[recommendation] = true
+				results.Recommendations = append(results.Recommendations, recommendation)
 			}
 		}
+	}
+	return nil
+}
 
-		// Check indentation
-		if !inString && indent < 0 {
-			return false
+// buildMains returns the main packages or files the builds of a configuration
+// compile, relative to its module. GoReleaser resolves builds[].main in
+// builds[].dir, which defaults to the directory of the configuration; a build
+// without main compiles that directory.
+func buildMains(configPath, module string, results *ValidationResults) []string {
+	root, found := results.configRoots[configPath]
+	if !found {
+		// --project-only skips the configuration checks, so parse it here
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			return nil
+		}
+		if root, _ = yamlcheck.Parse(data, yamlcheck.GoReleaserKinds); root == nil {
+			return nil
 		}
 	}
 
-	return indent == 0
+	builds := yamlcheck.Lookup(root, "builds")
+	if builds == nil || builds.Kind != yaml.SequenceNode || len(builds.Content) == 0 {
+		builds = &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	var mains []string
+	seen := make(map[string]bool)
+	for _, build := range builds.Content {
+		if skip := yamlcheck.Lookup(build, "skip"); skip != nil && skip.Value == "true" {
+			continue
+		}
+		dir, main := ".", "."
+		if node := yamlcheck.Lookup(build, "dir"); node != nil && node.Value != "" {
+			dir = node.Value
+		}
+		if node := yamlcheck.Lookup(build, "main"); node != nil && node.Value != "" {
+			main = node.Value
+		}
+		path := filepath.Join(filepath.Dir(configPath), filepath.FromSlash(dir), filepath.FromSlash(main))
+		rel, err := filepath.Rel(module, path)
+		if err != nil {
+			continue
+		}
+		if rel = filepath.ToSlash(rel); !seen[rel] {
+			seen[rel] = true
+			mains = append(mains, rel)
+		}
+	}
+	return mains
 }
 
-// parseGoReleaserConfig parses and validates GoReleaser configuration
-func parseGoReleaserConfig(configPath string, results *ValidationResults) error {
-	// This would use proper YAML parsing and GoReleaser validation
-	// For now, just check for required fields
-	
-	data, err := os.ReadFile(configPath)
+// validateYAML parses a YAML file and records every problem with its position.
+// It returns the root node, the file content and whether the document could be parsed.
+func validateYAML(filePath string, kinds yamlcheck.Kinds, results *ValidationResults) (*yaml.Node, []byte, bool) {
+	data, err := os.ReadFile(filePath)
 	if err != nil {
-		return domain.NewSystemError(
-			domain.ErrFileReadFailed,
-			"Failed to read configuration",
-			fmt.Sprintf("Cannot read %s", configPath),
-			err,
-		)
+		results.Errors = append(results.Errors,
+			domain.NewSystemError(
+				domain.ErrFileReadFailed,
+				"Failed to read file",
+				fmt.Sprintf("Cannot read %s", filePath),
+				err,
+			).WithContext(filePath))
+		return nil, nil, false
+	}
+
+	root, problems := yamlcheck.Parse(data, kinds)
+	for _, problem := range problems {
+		results.Errors = append(results.Errors, problem.WithContext(filePath))
 	}
 
-	content := string(data)
+	// Structural problems still leave a usable document
+	parsed := root != nil || len(problems) == 0
+	return root, data, parsed
+}
 
-	// Check for required fields
+// parseGoReleaserConfig checks the parsed configuration for required fields
+func parseGoReleaserConfig(configPath string, root *yaml.Node, results *ValidationResults) {
 	requiredFields := []string{"project_name", "builds"}
 	for _, field := range requiredFields {
-		if !strings.Contains(content, field+":") {
+		if yamlcheck.Lookup(root, field) == nil {
 			results.Errors = append(results.Errors,
 				domain.NewValidationError(
 					domain.ErrMissingRequiredField,
 					"Missing required field",
 					fmt.Sprintf("Configuration missing required field: %s", field),
-				).WithContext(field))
+				).WithField(field).WithContext(configPath))
 		}
 	}
+}
+
+// validateSchema checks the configuration against a bundled GoReleaser schema.
+// An empty version is detected from the configuration.
+func validateSchema(configPath string, root *yaml.Node, version schema.Version, file *FileResult, results *ValidationResults) error {
+	if root == nil {
+		return nil
+	}
+	if version == "" {
+		version = schema.Detect(root)
+	}
+
+	bundled, err := schema.Load(version)
+	if err != nil {
+		return err
+	}
 
+	file.SchemaVersion = version
+	result := bundled.Validate(root)
+	for _, problem := range result.Errors {
+		results.Errors = append(results.Errors, problem.WithContext(configPath))
+	}
+	for _, warning := range result.Warnings {
+		results.Warnings = append(results.Warnings, warning.WithContext(configPath))
+	}
 	return nil
 }
 
+// addLintFindings records lint findings by severity; informational findings
+// become recommendations. Fixes are kept with the content they apply to.
+func addLintFindings(filePath string, content []byte, findings []lint.Finding, results *ValidationResults) {
+	var file *fixableFile
+	for _, finding := range findings {
+		if finding.Fix != nil {
+			if file == nil {
+				file = &fixableFile{path: filePath, content: content}
+				results.fixable = append(results.fixable, file)
+			}
+			file.fixes = append(file.fixes, finding.Fix)
+		}
+
+		err := finding.Err.WithContext(filePath)
+		switch finding.Severity {
+		case domain.ErrorSeverityInfo:
+			results.Recommendations = append(results.Recommendations, formatViolation(err))
+		case domain.ErrorSeverityWarning:
+			results.Warnings = append(results.Warnings, err)
+		default:
+			results.Errors = append(results.Errors, err)
+		}
+	}
+}
+
+// displayLintRules lists every lint rule with its severity and state
+func displayLintRules(w io.Writer, engine *lint.Engine) {
+	fmt.Fprintln(w, titleStyle.Render("📏 Lint Rules"))
+	// Columns are as wide as their longest value
+	table := tabwriter.NewWriter(w, 0, 0, 1, ' ', 0)
+	for _, rule := range engine.Rules() {
+		state := "enabled"
+		if !engine.Enabled(rule.ID) {
+			state = "disabled"
+		}
+		fmt.Fprintf(table, "  %s\t%s\t%s\t%s\t%s\n", rule.ID, rule.Severity, rule.Target, state, rule.Description)
+	}
+	table.Flush()
+}
+
 // runGoReleaserCheck runs goreleaser check command
 func runGoReleaserCheck(configPath string, results *ValidationResults) error {
-	// Check if goreleaser is available
+	// Check if goreleaser is available, reporting its absence once
+	if results.goreleaserMissing {
+		return nil
+	}
 	if _, err := exec.LookPath("goreleaser"); err != nil {
+		results.goreleaserMissing = true
 		results.Warnings = append(results.Warnings,
 			domain.NewExternalServiceError(
 				domain.ErrDependencyNotFound,
@@ -365,7 +869,7 @@ func runGoReleaserCheck(configPath string, results *ValidationResults) error {
 				domain.ErrGitOperationFailed,
 				"GoReleaser check failed",
 				string(output),
-			))
+			).WithContext(configPath))
 		return nil
 	}
 
@@ -373,34 +877,19 @@ func runGoReleaserCheck(configPath string, results *ValidationResults) error {
 	return nil
 }
 
-// validateWorkflowContent validates GitHub Actions workflow content
-func validateWorkflowContent(workflowPath string, results *ValidationResults) error {
-	data, err := os.ReadFile(workflowPath)
-	if err != nil {
-		return domain.NewSystemError(
-			domain.ErrFileReadFailed,
-			"Failed to read workflow file",
-			fmt.Sprintf("Cannot read %s", workflowPath),
-			err,
-		)
-	}
-
-	content := string(data)
-
-	// Check for required workflow elements
-	requiredElements := []string{"name:", "on:", "jobs:"}
+// validateWorkflowContent checks the parsed workflow for its required top-level keys
+func validateWorkflowContent(workflowPath string, root *yaml.Node, results *ValidationResults) {
+	requiredElements := []string{"name", "on", "jobs"}
 	for _, element := range requiredElements {
-		if !strings.Contains(content, element) {
+		if yamlcheck.Lookup(root, element) == nil {
 			results.Warnings = append(results.Warnings,
 				domain.NewTemplateError(
 					domain.ErrTemplateExecutionFailed,
 					"Missing workflow element",
-					fmt.Sprintf("Workflow missing required element: %s", element),
+					fmt.Sprintf("Workflow missing required element: %s:", element),
 				).WithContext(workflowPath))
 		}
 	}
-
-	return nil
 }
 
 // displayValidationResults displays validation results
@@ -408,26 +897,47 @@ func displayValidationResults(results *ValidationResults, verbose bool) {
 	fmt.Println("📋 Validation Summary:")
 	fmt.Println()
 
-	// Configuration status
-	if results.ConfigExists {
-		if results.ConfigValid {
-			fmt.Println(successStyle.Render("✅ GoReleaser configuration: Valid"))
+	// Wizard answers status
+	if results.AnswersExists {
+		if results.AnswersValid {
+			fmt.Println(successStyle.Render("✅ Wizard answers: Valid"))
 		} else {
-			fmt.Println(errorStyle.Render("❌ GoReleaser configuration: Invalid"))
+			fmt.Println(errorStyle.Render("❌ Wizard answers: Invalid"))
+		}
+	}
+
+	// Configuration status, per file
+	if results.ConfigExists {
+		for _, file := range results.filesOfKind("goreleaser") {
+			schemaNote := ""
+			if file.SchemaVersion != "" {
+				schemaNote = fmt.Sprintf(" (schema %s)", file.SchemaVersion)
+			}
+			if file.Valid {
+				fmt.Println(successStyle.Render(fmt.Sprintf("✅ GoReleaser configuration %s: Valid%s", file.Path, schemaNote)))
+			} else {
+				fmt.Println(errorStyle.Render(fmt.Sprintf("❌ GoReleaser configuration %s: Invalid%s", file.Path, schemaNote)))
+			}
 		}
 	} else {
 		fmt.Println(errorStyle.Render("❌ GoReleaser configuration: Not found"))
 	}
 
-	// GitHub Actions status
-	if results.ActionsExists {
-		if results.ActionsValid {
-			fmt.Println(successStyle.Render("✅ GitHub Actions workflow: Valid"))
+	// GitHub Actions status, per workflow
+	for _, file := range results.filesOfKind("workflow") {
+		if file.Valid {
+			fmt.Println(successStyle.Render(fmt.Sprintf("✅ Workflow %s: Valid", file.Path)))
 		} else {
-			fmt.Println(errorStyle.Render("❌ GitHub Actions workflow: Invalid"))
+			fmt.Println(errorStyle.Render(fmt.Sprintf("❌ Workflow %s: Invalid", file.Path)))
 		}
-	} else {
-		fmt.Println(infoStyle.Render("ℹ️  GitHub Actions workflow: Not found"))
+	}
+	if !results.ActionsExists {
+		fmt.Println(infoStyle.Render("ℹ️  GitHub Actions release workflow: Not found"))
+	}
+
+	// Organization policy
+	if results.Policy != "" {
+		fmt.Println(infoStyle.Render("🛡️  Policy: " + results.Policy))
 	}
 
 	// Project structure status
@@ -446,31 +956,14 @@ func displayValidationResults(results *ValidationResults, verbose bool) {
 
 	fmt.Println()
 
-	// Display errors
+	// Display errors and warnings, grouped by file
 	if len(results.Errors) > 0 {
 		fmt.Println(errorStyle.Render("❌ Errors:"))
-		for _, err := range results.Errors {
-			fmt.Printf("  • %s\n", err.Message)
-			if verbose {
-				fmt.Printf("    Details: %s\n", err.Details)
-				if err.Context != "" {
-					fmt.Printf("    Context: %s\n", err.Context)
-				}
-			}
-		}
-		fmt.Println()
+		displayProblems(results.Errors, verbose)
 	}
-
-	// Display warnings
 	if len(results.Warnings) > 0 {
 		fmt.Println(infoStyle.Render("⚠️  Warnings:"))
-		for _, warning := range results.Warnings {
-			fmt.Printf("  • %s\n", warning.Message)
-			if verbose {
-				fmt.Printf("    Details: %s\n", warning.Details)
-			}
-		}
-		fmt.Println()
+		displayProblems(results.Warnings, verbose)
 	}
 
 	// Display recommendations
@@ -483,72 +976,36 @@ func displayValidationResults(results *ValidationResults, verbose bool) {
 	}
 }
 
-// attemptFixes attempts to fix common issues
-func attemptFixes(results *ValidationResults) error {
-	fmt.Println("🔧 Attempting to fix common issues...")
-	fmt.Println()
-
-	fixed := 0
-
-	// Fix missing configuration directory
-	if !results.ConfigExists {
-		if err := os.MkdirAll(".github/workflows", 0755); err == nil {
-			fmt.Println(successStyle.Render("✅ Created .github/workflows directory"))
-			fixed++
+// displayProblems prints problems in one section per file, in the order the
+// files were first reported, followed by those not tied to a file
+func displayProblems(problems []*domain.DomainError, verbose bool) {
+	var order []string
+	byFile := make(map[string][]*domain.DomainError)
+	for _, problem := range problems {
+		if _, seen := byFile[problem.Context]; !seen && problem.Context != "" {
+			order = append(order, problem.Context)
 		}
+		byFile[problem.Context] = append(byFile[problem.Context], problem)
 	}
-
-	// Try to create basic configuration if missing
-	if !results.ConfigExists {
-		configContent := generateBasicConfig()
-		if err := os.WriteFile(".goreleaser.yaml", []byte(configContent), 0644); err == nil {
-			fmt.Println(successStyle.Render("✅ Created basic .goreleaser.yaml"))
-			fixed++
-		}
+	if len(byFile[""]) > 0 {
+		order = append(order, "")
 	}
 
-	if fixed > 0 {
-		fmt.Println()
-		fmt.Println(successStyle.Render(fmt.Sprintf("✅ Fixed %d issues", fixed)))
-		fmt.Println(infoStyle.Render("💡 Run validation again to check remaining issues"))
-	} else {
-		fmt.Println(infoStyle.Render("ℹ️  No auto-fixable issues found"))
+	for _, path := range order {
+		if path == "" {
+			fmt.Println("  📁 Project")
+		} else {
+			fmt.Printf("  📄 %s\n", path)
+		}
+		for _, problem := range byFile[path] {
+			fmt.Printf("    • %s\n", formatViolation(problem.WithContext("")))
+			// Positioned problems need their details to be actionable
+			if verbose || problem.Line > 0 {
+				fmt.Printf("      Details: %s\n", problem.Details)
+			}
+		}
 	}
-
-	return nil
-}
-
-// generateBasicConfig generates a basic GoReleaser configuration
-func generateBasicConfig() string {
-	return `# Basic GoReleaser configuration
-# Generated by GoReleaser Wizard
-
-# The project name
-project_name: my-project
-
-# The build configuration
-builds:
-  - env:
-      - CGO_ENABLED=0
-    goos:
-      - linux
-      - darwin
-      - windows
-    goarch:
-      - amd64
-      - arm64
-
-# Archive configuration
-archives:
-  - format: tar.gz
-    name_template: '{{ .ProjectName }}_{{ .Version }}_{{ .Os }}_{{ .Arch }}'
-
-# Release configuration
-release:
-  github:
-    owner: your-username
-    name: my-project
-`
+	fmt.Println()
 }
 
 // SimpleFileSystemRepository is a basic implementation for demonstration
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -9,17 +10,79 @@ import (
 
 	"slices"
 
+	"github.com/LarsArtmann/template-GoReleaser/internal/discovery"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/LarsArtmann/template-GoReleaser/internal/lint"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+// newTestValidation sets up the validation dependencies runValidate injects
+// and returns options validating dir
+func newTestValidation(t *testing.T, dir string) *ValidationOptions {
+	t.Helper()
+
+	fileSystemRepo = &SimpleFileSystemRepository{}
+	validationUseCase = domain.NewValidationUseCase(appLogger, fileSystemRepo)
+
+	options, err := newValidationOptions("auto")
+	if err != nil {
+		t.Fatalf("newValidationOptions() error = %v", err)
+	}
+	options.Root = dir
+	return options
+}
+
+// runValidationSteps runs the validation steps of runValidate, which exits
+// the process, in the current directory
+func runValidationSteps(t *testing.T, verbose bool) *ValidationResults {
+	t.Helper()
+
+	results, err := collectValidationResults(newTestValidation(t, "."), false)
+	if err != nil {
+		t.Fatalf("collectValidationResults() error = %v", err)
+	}
+	displayValidationResults(results, verbose)
+	return results
+}
+
+// writeTestFiles writes files below dir, creating parent directories
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const testGoMod = `module github.com/user/test
+go 1.21
+`
+
+const testGoReleaserConfig = `# GoReleaser configuration
+version: 2
+project_name: test
+builds:
+  - main: .
+    binary: test
+    goos:
+      - linux
+    goarch:
+      - amd64
+`
+
 func TestRunValidate(t *testing.T) {
 	tests := []struct {
 		name        string
 		setupFunc   func() string
-		args        []string
 		flags       map[string]bool
 		expectPass  bool
 		expectError bool
@@ -37,13 +100,13 @@ go 1.21
 				goreleaser := `# GoReleaser configuration
 version: 2
 project_name: test
-build:
-  main: .
-  binary: test
-  goos:
-    - linux
-  goarch:
-    - amd64
+builds:
+  - main: .
+    binary: test
+    goos:
+      - linux
+    goarch:
+      - amd64
 `
 				os.WriteFile(filepath.Join(dir, ".goreleaser.yaml"), []byte(goreleaser), 0644)
 				// Create main.go
@@ -59,7 +122,6 @@ build:
 				exec.Command("git", "commit", "-m", "init").Run()
 				return dir
 			},
-			args:       []string{},
 			flags:      map[string]bool{"verbose": false, "fix": false},
 			expectPass: true,
 		},
@@ -73,7 +135,6 @@ go 1.21
 				os.WriteFile(filepath.Join(dir, "go.mod"), []byte(goMod), 0644)
 				return dir
 			},
-			args:        []string{},
 			flags:       map[string]bool{"verbose": false, "fix": false},
 			expectPass:  false,
 			expectError: true,
@@ -93,7 +154,6 @@ project_name: test
 				os.WriteFile(filepath.Join(dir, ".goreleaser.yaml"), []byte(goreleaser), 0644)
 				return dir
 			},
-			args:  []string{},
 			flags: map[string]bool{"verbose": true, "fix": false},
 		},
 	}
@@ -112,41 +172,20 @@ project_name: test
 			viper.Reset()
 			viper.Set("debug", false)
 
-			// Create test command
-			cmd := &cobra.Command{}
-			cmd.Flags().Bool("verbose", false, "show detailed validation output")
-			cmd.Flags().Bool("fix", false, "attempt to fix common issues")
-
-			// Set flags
-			for flag, value := range tt.flags {
-				if value {
-					cmd.Flags().Set(flag, "true")
-				} else {
-					cmd.Flags().Set(flag, "false")
-				}
-			}
-
-			// Capture exit status
-			defer func() {
-				if r := recover(); r != nil {
-					if tt.expectError {
-						// Expected to fail
-						return
-					}
-					t.Errorf("runValidate() panicked: %v", r)
-				}
-			}()
-
 			// Run validation
-			func() {
-				defer HandlePanic("validate test", log.New(os.Stderr))
-				runValidate(cmd, tt.args)
-			}()
+			results := runValidationSteps(t, tt.flags["verbose"])
+
+			if tt.expectPass && len(results.Errors) > 0 {
+				t.Errorf("validation errors = %v, want none", results.Errors)
+			}
+			if tt.expectError && len(results.Errors) == 0 {
+				t.Error("validation reported no errors, want errors")
+			}
 		})
 	}
 }
 
-func TestCheckFileExists(t *testing.T) {
+func TestValidateFileExists(t *testing.T) {
 	tests := []struct {
 		name        string
 		path        string
@@ -205,20 +244,20 @@ func TestCheckFileExists(t *testing.T) {
 				}()
 			}
 
-			err := CheckFileExists(tt.path, tt.requireDir)
+			err := validateFileExists(tt.path, tt.requireDir)
 
 			if (err != nil) != tt.wantErr {
-				t.Errorf("CheckFileExists() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("validateFileExists() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 
 			if tt.wantErr && tt.errContains != "" {
 				if err == nil {
-					t.Errorf("CheckFileExists() expected error containing %q, got nil", tt.errContains)
+					t.Errorf("validateFileExists() expected error containing %q, got nil", tt.errContains)
 					return
 				}
 				if !strings.Contains(err.Error(), tt.errContains) {
-					t.Errorf("CheckFileExists() error = %v, want to contain %q", err, tt.errContains)
+					t.Errorf("validateFileExists() error = %v, want to contain %q", err, tt.errContains)
 				}
 			}
 		})
@@ -353,6 +392,180 @@ build:
 	}
 }
 
+func TestValidateProjectStructureModules(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"svc/a/go.mod":           testGoMod,
+		"svc/a/main.go":          "package main\n\nfunc main() {}",
+		"svc/a/.goreleaser.yaml": testGoReleaserConfig,
+		"svc/b/go.mod":           testGoMod,
+		"svc/b/.goreleaser.yaml": testGoReleaserConfig,
+	})
+
+	options := newTestValidation(t, dir)
+	results, err := collectValidationResults(options, false)
+	if err != nil {
+		t.Fatalf("collectValidationResults() error = %v", err)
+	}
+
+	// Only the module without a main package is reported
+	if len(results.Errors) != 1 || !strings.Contains(results.Errors[0].Message, "Main file not found") {
+		t.Fatalf("errors = %v, want one missing main file", results.Errors)
+	}
+	if !strings.Contains(results.Errors[0].Context, filepath.Join("svc", "b")) {
+		t.Errorf("error context = %q, want svc/b", results.Errors[0].Context)
+	}
+}
+
+func TestValidateProjectStructureBuildMains(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       map[string]string
+		expectIssue string
+	}{
+		{
+			name: "main_package_named_after_the_binary",
+			files: map[string]string{
+				"go.mod":           "module github.com/user/project\ngo 1.21\n",
+				"cmd/tool/main.go": "package main\n\nfunc main() {}",
+				".goreleaser.yaml": "version: 2\nbuilds:\n  - main: ./cmd/tool\n    binary: tool\n",
+			},
+		},
+		{
+			name: "main_in_build_dir",
+			files: map[string]string{
+				"go.mod":              testGoMod,
+				"app/server/serve.go": "package main\n\nfunc main() {}",
+				".goreleaser.yaml":    "version: 2\nbuilds:\n  - dir: app\n    main: ./server\n",
+			},
+		},
+		{
+			name: "missing_build_main",
+			files: map[string]string{
+				"go.mod":           testGoMod,
+				"main.go":          "package main\n\nfunc main() {}",
+				".goreleaser.yaml": "version: 2\nbuilds:\n  - main: .\n  - id: worker\n    main: ./cmd/worker\n",
+			},
+			expectIssue: "cmd/worker",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFiles(t, dir, tt.files)
+
+			results, err := collectValidationResults(newTestValidation(t, dir), true)
+			if err != nil {
+				t.Fatalf("collectValidationResults() error = %v", err)
+			}
+
+			if tt.expectIssue == "" {
+				if len(results.Errors) > 0 {
+					t.Errorf("errors = %v, want none", results.Errors)
+				}
+				return
+			}
+			if len(results.Errors) != 1 || !strings.Contains(results.Errors[0].Details, tt.expectIssue) {
+				t.Errorf("errors = %v, want a missing %s", results.Errors, tt.expectIssue)
+			}
+		})
+	}
+}
+
+// TestValidateGeneratedProject expects the wizard's own output to validate
+// without warnings
+func TestValidateGeneratedProject(t *testing.T) {
+	config := domain.NewSafeProjectConfig()
+	config.ProjectName = "tool"
+	config.BinaryName = "tool"
+	config.MainPath = "."
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryGitHub
+	config.SigningLevel = domain.SigningLevelAdvanced
+	config.SBOM = true
+
+	g := generator.New()
+	goreleaser, err := g.GenerateGoReleaserConfig(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workflow, err := g.GenerateGitHubActions(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"go.mod":                        testGoMod,
+		"main.go":                       "package main\n\nfunc main() {}",
+		".goreleaser.yaml":              goreleaser,
+		".github/workflows/release.yml": workflow,
+	})
+
+	results, err := collectValidationResults(newTestValidation(t, dir), false)
+	if err != nil {
+		t.Fatalf("collectValidationResults() error = %v", err)
+	}
+	for _, problem := range append(results.Errors, results.Warnings...) {
+		if problem.Rule != "" {
+			t.Errorf("generated project violates %s: %s", problem.Rule, formatViolation(problem))
+		}
+	}
+}
+
+func TestDisplayLintRulesAlignsColumns(t *testing.T) {
+	engine := lint.NewEngine(
+		lint.Rule{ID: "short", Description: "first rule", Target: lint.TargetGoReleaser, Severity: domain.ErrorSeverityError},
+		lint.Rule{ID: "a-rule-id-longer-than-the-old-column", Description: "second rule", Target: lint.TargetWorkflow, Severity: domain.ErrorSeverityWarning},
+	)
+
+	var out strings.Builder
+	displayLintRules(&out, engine)
+
+	lines := strings.Split(strings.TrimRight(out.String(), "\n"), "\n")
+	first, second := lines[len(lines)-2], lines[len(lines)-1]
+	if strings.Index(first, "first rule") != strings.Index(second, "second rule") {
+		t.Errorf("descriptions not aligned:\n%s\n%s", first, second)
+	}
+	if !strings.Contains(second, "a-rule-id-longer-than-the-old-column ") {
+		t.Errorf("long rule ID not separated from its severity: %q", second)
+	}
+}
+
+func TestValidateGitHubActionsFromGitRoot(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		".git/HEAD":                     "ref: refs/heads/main\n",
+		".github/workflows/release.yml": "name: release\non: push\npermissions: {}\njobs:\n  release:\n    steps:\n      - uses: goreleaser/goreleaser-action@v6\n        with:\n          workdir: svc/a\n",
+		"svc/a/go.mod":                  testGoMod,
+		"svc/a/.goreleaser.yaml":        testGoReleaserConfig,
+	})
+
+	options := newTestValidation(t, filepath.Join(dir, "svc", "a"))
+	options.GitRoot = discovery.GitRoot(options.Root)
+
+	results := &ValidationResults{}
+	if err := validateGitHubActions(results, options); err != nil {
+		t.Fatalf("validateGitHubActions() error = %v", err)
+	}
+	if !results.ActionsExists {
+		t.Errorf("release workflow at the repository root not found: %+v", results.Files)
+	}
+}
+
+func TestValidateGoReleaserConfigFlag(t *testing.T) {
+	flags := validateCmd.Flags()
+
+	// The global --config names the wizard's user config
+	if flags.Lookup("config") != nil {
+		t.Error("validate must not shadow the global 'config' flag")
+	}
+	if flags.Lookup("goreleaser-config") == nil {
+		t.Error("Expected 'goreleaser-config' flag to be present")
+	}
+}
+
 func TestValidateDependencies(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -475,16 +688,8 @@ build:
 	viper.Reset()
 	viper.Set("debug", false)
 
-	// Create test command
-	cmd := &cobra.Command{}
-	cmd.Flags().Bool("verbose", false, "show detailed validation output")
-	cmd.Flags().Bool("fix", false, "attempt to fix common issues")
-
 	// Run validation and check that it doesn't panic
-	func() {
-		defer HandlePanic("validate output test", log.New(os.Stderr))
-		runValidate(cmd, []string{})
-	}()
+	runValidationSteps(t, false)
 
 	// If we get here without panic, the output formatting is working
 	// More detailed output testing would require capturing stdout, which is complex
@@ -7,7 +7,14 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/LarsArtmann/template-GoReleaser/internal/discovery"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/LarsArtmann/template-GoReleaser/internal/merge"
+	"github.com/LarsArtmann/template-GoReleaser/internal/migrate"
+	"github.com/LarsArtmann/template-GoReleaser/internal/state"
 	"github.com/charmbracelet/log"
+	"go.yaml.in/yaml/v3"
 )
 
 // Workflow represents a sequence of jobs
@@ -91,6 +98,7 @@ const (
 	WorkflowTypeFullWizard     WorkflowType = "full-wizard"
 	WorkflowTypeConfigOnly     WorkflowType = "config-only"
 	WorkflowTypeValidationOnly WorkflowType = "validation-only"
+	WorkflowTypeGenerate       WorkflowType = "generate"
 	WorkflowTypeMigrate        WorkflowType = "migrate"
 	WorkflowTypeUpdate         WorkflowType = "update"
 	WorkflowTypeRollback       WorkflowType = "rollback"
@@ -98,8 +106,9 @@ const (
 
 // WorkflowBuilder builds workflows for different scenarios
 type WorkflowBuilder struct {
-	logger  *log.Logger
-	factory *JobFactory
+	logger   *log.Logger
+	factory  *JobFactory
+	resolver ConflictResolver
 }
 
 // NewWorkflowBuilder creates a new workflow builder
@@ -110,6 +119,17 @@ func NewWorkflowBuilder(logger *log.Logger) *WorkflowBuilder {
 	}
 }
 
+// SetRootDir sets the directory workflows read and write configuration in
+func (wb *WorkflowBuilder) SetRootDir(rootDir string) {
+	wb.factory.SetRootDir(rootDir)
+}
+
+// SetConflictResolver sets how update workflows resolve merge conflicts.
+// Without a resolver, conflict markers are written.
+func (wb *WorkflowBuilder) SetConflictResolver(resolver ConflictResolver) {
+	wb.resolver = resolver
+}
+
 // BuildWorkflow builds a workflow based on type and configuration
 func (wb *WorkflowBuilder) BuildWorkflow(wfType WorkflowType, config *ProjectConfig, force bool) (*Workflow, error) {
 	var workflow *Workflow
@@ -131,6 +151,14 @@ func (wb *WorkflowBuilder) BuildWorkflow(wfType WorkflowType, config *ProjectCon
 		jobs = []Job{wb.factory.CreateValidationOnlyJob(".")}
 		workflow.SetParallel(false, 1)
 
+	case WorkflowTypeGenerate:
+		workflow = NewWorkflow("Generate", "Generate release artifacts from saved answers", wb.logger)
+		jobs = wb.factory.CreateGenerateJobs(config, force)
+		workflow.SetParallel(false, 1)
+
+	case WorkflowTypeRollback:
+		return wb.BuildRollbackWorkflow("")
+
 	default:
 		return nil, fmt.Errorf("unsupported workflow type: %s", wfType)
 	}
@@ -144,7 +172,7 @@ func (wb *WorkflowBuilder) BuildWorkflow(wfType WorkflowType, config *ProjectCon
 	switch wfType {
 	case WorkflowTypeFullWizard:
 		workflow.SetTimeout(10 * time.Minute)
-	case WorkflowTypeConfigOnly:
+	case WorkflowTypeConfigOnly, WorkflowTypeGenerate:
 		workflow.SetTimeout(5 * time.Minute)
 	case WorkflowTypeValidationOnly:
 		workflow.SetTimeout(2 * time.Minute)
@@ -153,8 +181,31 @@ func (wb *WorkflowBuilder) BuildWorkflow(wfType WorkflowType, config *ProjectCon
 	return workflow, nil
 }
 
+// BuildRollbackWorkflow builds a workflow restoring every file of a recorded run.
+// An empty runID selects the latest run that was not rolled back yet.
+func (wb *WorkflowBuilder) BuildRollbackWorkflow(runID string) (*Workflow, error) {
+	description := "Restore the files changed by the latest run"
+	if runID != "" {
+		description = fmt.Sprintf("Restore the files changed by run %s", runID)
+	}
+	workflow := NewWorkflow("Rollback", description, wb.logger)
+
+	workflow.JobManager.AddJob(&RollbackJob{
+		id:      "rollback-run",
+		runID:   runID,
+		rootDir: wb.factory.RootDir(),
+		backups: wb.factory.NewBackupRecorder(WorkflowTypeRollback),
+		logger:  wb.logger,
+	})
+
+	workflow.SetTimeout(2 * time.Minute)
+	workflow.SetParallel(false, 1)
+
+	return workflow, nil
+}
+
 // BuildMigrateWorkflow builds a migration workflow
-func (wb *WorkflowBuilder) BuildMigrateWorkflow(fromVersion, toVersion string, config *ProjectConfig) (*Workflow, error) {
+func (wb *WorkflowBuilder) BuildMigrateWorkflow(fromVersion, toVersion string) (*Workflow, error) {
 	workflow := NewWorkflow(
 		fmt.Sprintf("Migration %s -> %s", fromVersion, toVersion),
 		fmt.Sprintf("Migrate configuration from version %s to %s", fromVersion, toVersion),
@@ -162,7 +213,7 @@ func (wb *WorkflowBuilder) BuildMigrateWorkflow(fromVersion, toVersion string, c
 	)
 
 	// Create migration jobs
-	jobs := wb.createMigrationJobs(fromVersion, toVersion, config)
+	jobs := wb.createMigrationJobs(fromVersion, toVersion)
 
 	for _, job := range jobs {
 		workflow.JobManager.AddJob(job)
@@ -196,31 +247,36 @@ func (wb *WorkflowBuilder) BuildUpdateWorkflow(config *ProjectConfig, dryRun boo
 }
 
 // createMigrationJobs creates jobs for migration workflow
-func (wb *WorkflowBuilder) createMigrationJobs(fromVersion, toVersion string, config *ProjectConfig) []Job {
+func (wb *WorkflowBuilder) createMigrationJobs(fromVersion, toVersion string) []Job {
 	var jobs []Job
+	backups := wb.factory.NewBackupRecorder(WorkflowTypeMigrate)
 
-	// Backup current configuration
-	backupJob := &ConfigBackupJob{
-		id:     "backup-config",
-		logger: wb.logger,
-	}
-	jobs = append(jobs, backupJob)
-
-	// Validate migration compatibility
+	// Validate migration compatibility before anything is recorded
 	validationJob := &MigrationValidationJob{
 		id:          "validate-migration",
 		fromVersion: fromVersion,
 		toVersion:   toVersion,
+		rootDir:     wb.factory.RootDir(),
 		logger:      wb.logger,
 	}
 	jobs = append(jobs, validationJob)
 
+	// Backup current configuration
+	backupJob := &ConfigBackupJob{
+		id:      "backup-config",
+		rootDir: wb.factory.RootDir(),
+		backups: backups,
+		logger:  wb.logger,
+	}
+	jobs = append(jobs, backupJob)
+
 	// Migrate configuration
 	migrateJob := &ConfigMigrationJob{
 		id:          "migrate-config",
 		fromVersion: fromVersion,
 		toVersion:   toVersion,
-		config:      config,
+		rootDir:     wb.factory.RootDir(),
+		backups:     backups,
 		logger:      wb.logger,
 	}
 	jobs = append(jobs, migrateJob)
@@ -233,25 +289,30 @@ func (wb *WorkflowBuilder) createUpdateJobs(config *ProjectConfig, dryRun bool)
 	var jobs []Job
 
 	// Validate project structure
-	validationJob := NewProjectValidationJob(".", wb.logger)
+	validationJob := NewProjectValidationJob(wb.factory.RootDir(), wb.logger)
 	jobs = append(jobs, validationJob)
 
 	// Update configuration
 	updateJob := &ConfigUpdateJob{
-		id:     "update-config",
-		config: config,
-		dryRun: dryRun,
-		logger: wb.logger,
+		id:       "update-config",
+		config:   config,
+		dryRun:   dryRun,
+		rootDir:  wb.factory.RootDir(),
+		resolver: wb.resolver,
+		backups:  wb.factory.NewBackupRecorder(WorkflowTypeUpdate),
+		logger:   wb.logger,
 	}
 	jobs = append(jobs, updateJob)
 
 	return jobs
 }
 
-// ConfigBackupJob backs up existing configuration
+// ConfigBackupJob records the configuration and workflows in the backup catalog
 type ConfigBackupJob struct {
-	id     string
-	logger *log.Logger
+	id      string
+	rootDir string
+	backups *BackupRecorder
+	logger  *log.Logger
 }
 
 func (j *ConfigBackupJob) ID() string {
@@ -265,22 +326,35 @@ func (j *ConfigBackupJob) Name() string {
 func (j *ConfigBackupJob) Execute(ctx context.Context) error {
 	j.logger.Info("Backing up existing configuration")
 
-	// Check if .goreleaser.yaml exists
-	if _, err := os.Stat(".goreleaser.yaml"); os.IsNotExist(err) {
-		j.logger.Info("No existing configuration to backup")
-		return nil
-	}
-
-	// Create backup with timestamp
-	timestamp := time.Now().Format("20060102-150405")
-	backupFile := fmt.Sprintf(".goreleaser.yaml.backup.%s", timestamp)
-
-	err := os.Rename(".goreleaser.yaml", backupFile)
+	files, err := migrationFiles(j.rootDir)
 	if err != nil {
 		return fmt.Errorf("failed to create backup: %w", err)
 	}
 
-	j.logger.Infof("Configuration backed up to: %s", backupFile)
+	// Every file is read before the run is opened, so an unreadable file
+	// leaves no partial run in the catalog
+	var existing []string
+	for _, file := range files {
+		_, exists, err := readArtifact(file.Path)
+		if err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+		// Missing files are not recorded, restoring would remove them anyway
+		if exists {
+			existing = append(existing, file.Path)
+		}
+	}
+	for _, path := range existing {
+		if err := j.backups.Record(path); err != nil {
+			return fmt.Errorf("failed to create backup: %w", err)
+		}
+	}
+
+	if runID := j.backups.RunID(); runID != "" {
+		j.logger.Infof("Configuration backed up in run: %s", runID)
+	} else {
+		j.logger.Info("No existing configuration to backup")
+	}
 	return nil
 }
 
@@ -294,6 +368,7 @@ type MigrationValidationJob struct {
 	id          string
 	fromVersion string
 	toVersion   string
+	rootDir     string
 	logger      *log.Logger
 }
 
@@ -308,10 +383,25 @@ func (j *MigrationValidationJob) Name() string {
 func (j *MigrationValidationJob) Execute(ctx context.Context) error {
 	j.logger.Infof("Validating migration from %s to %s", j.fromVersion, j.toVersion)
 
-	// This is a simplified validation - in real implementation,
-	// this would check compatibility between versions
-	if j.fromVersion == j.toVersion {
-		return fmt.Errorf("source and target versions are the same")
+	if err := migrate.ValidateVersions(j.fromVersion, j.toVersion); err != nil {
+		return err
+	}
+
+	configPath := migrationConfigPath(j.rootDir)
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.FileNotFoundError(configPath, err)
+		}
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+
+	version, err := migrate.DetectVersion(data)
+	if err != nil {
+		return err
+	}
+	if version == migrate.TargetVersion {
+		j.logger.Info("Configuration already declares version 2, only deprecations will be fixed")
 	}
 
 	j.logger.Info("Migration compatibility validated")
@@ -323,12 +413,70 @@ func (j *MigrationValidationJob) Rollback(ctx context.Context) error {
 	return nil
 }
 
-// ConfigMigrationJob migrates configuration
+// migrationFile is a file the migration engine rewrites
+type migrationFile struct {
+	Path   string
+	Target migrate.Target
+}
+
+// migrationConfigPath returns the GoReleaser configuration in rootDir under
+// the first name GoReleaser looks for, or the generated name when none exists
+func migrationConfigPath(rootDir string) string {
+	for _, name := range discovery.ConfigNames {
+		if path := artifactPath(rootDir, name); fileExists(path) {
+			return path
+		}
+	}
+	return artifactPath(rootDir, generator.GoReleaserConfigPath)
+}
+
+// migrationFiles returns the GoReleaser configuration and every workflow below rootDir
+func migrationFiles(rootDir string) ([]migrationFile, error) {
+	files := []migrationFile{{
+		Path:   migrationConfigPath(rootDir),
+		Target: migrate.TargetGoReleaser,
+	}}
+
+	workflowDir := artifactPath(rootDir, filepath.Dir(generator.WorkflowPath))
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(workflowDir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			files = append(files, migrationFile{Path: match, Target: migrate.TargetWorkflow})
+		}
+	}
+	return files, nil
+}
+
+// migrateFile runs the migration rules for a file; a missing file yields no result
+func migrateFile(file migrationFile) (*migrate.Result, error) {
+	data, exists, err := readArtifact(file.Path)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	var result *migrate.Result
+	if file.Target == migrate.TargetWorkflow {
+		result, err = migrate.MigrateWorkflow(data)
+	} else {
+		result, err = migrate.MigrateConfig(data)
+	}
+	if err != nil {
+		return nil, asDomainError(err).WithContext(file.Path)
+	}
+	return result, nil
+}
+
+// ConfigMigrationJob migrates configuration and release workflows
 type ConfigMigrationJob struct {
 	id          string
 	fromVersion string
 	toVersion   string
-	config      *ProjectConfig
+	rootDir     string
+	backups     *BackupRecorder
+	updated     []string
 	logger      *log.Logger
 }
 
@@ -343,13 +491,45 @@ func (j *ConfigMigrationJob) Name() string {
 func (j *ConfigMigrationJob) Execute(ctx context.Context) error {
 	j.logger.Infof("Migrating configuration from %s to %s", j.fromVersion, j.toVersion)
 
-	// This is a simplified migration - in real implementation,
-	// this would transform configuration based on version differences
-	err := generateGoReleaserConfig(j.config)
+	files, err := migrationFiles(j.rootDir)
 	if err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
 
+	// Every file is read and migrated before the first one is recorded, so a
+	// file that cannot be migrated leaves no partial run in the catalog
+	type migrated struct {
+		path   string
+		result *migrate.Result
+	}
+	var changed []migrated
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		result, err := migrateFile(file)
+		if err != nil {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+		if result == nil || !result.Changed() {
+			continue
+		}
+		changed = append(changed, migrated{path: file.Path, result: result})
+	}
+
+	for _, file := range changed {
+		if err := j.backups.Record(file.path); err != nil {
+			return err
+		}
+		j.updated = append(j.updated, file.path)
+
+		if err := os.WriteFile(file.path, file.result.Content, 0644); err != nil {
+			return domain.FileWriteFailedError(file.path, err)
+		}
+		j.logger.Info("Migrated file", "path", file.path, "changes", len(file.result.Changes))
+	}
+
 	j.logger.Info("Configuration migrated successfully")
 	return nil
 }
@@ -357,30 +537,27 @@ func (j *ConfigMigrationJob) Execute(ctx context.Context) error {
 func (j *ConfigMigrationJob) Rollback(ctx context.Context) error {
 	j.logger.Info("Rolling back configuration migration")
 
-	// Restore from backup (simplified)
-	backupFiles, err := filepath.Glob(".goreleaser.yaml.backup.*")
-	if err != nil || len(backupFiles) == 0 {
-		return fmt.Errorf("no backup found for rollback")
-	}
-
-	// Get the most recent backup
-	latestBackup := backupFiles[len(backupFiles)-1]
-
-	err = os.Rename(latestBackup, ".goreleaser.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to restore backup: %w", err)
+	if err := j.backups.Restore(j.updated...); err != nil {
+		return err
 	}
-
-	j.logger.Infof("Configuration rolled back from: %s", latestBackup)
+	j.updated = nil
 	return nil
 }
 
-// ConfigUpdateJob updates configuration
+// ConflictResolver decides how a merge conflict in an artifact is resolved
+type ConflictResolver func(path string, index int, conflict merge.Conflict) merge.Resolution
+
+// ConfigUpdateJob updates generated files with a three-way merge of the
+// recorded merge base, the file on disk and the newly generated output
 type ConfigUpdateJob struct {
-	id     string
-	config *ProjectConfig
-	dryRun bool
-	logger *log.Logger
+	id       string
+	config   *ProjectConfig
+	dryRun   bool
+	rootDir  string
+	resolver ConflictResolver
+	backups  *BackupRecorder
+	updated  []string
+	logger   *log.Logger
 }
 
 func (j *ConfigUpdateJob) ID() string {
@@ -392,24 +569,190 @@ func (j *ConfigUpdateJob) Name() string {
 }
 
 func (j *ConfigUpdateJob) Execute(ctx context.Context) error {
+	j.logger.Info("Updating configuration")
+
+	artifacts, err := artifactGenerator.Artifacts(ctx, j.config)
+	if err != nil {
+		return fmt.Errorf("configuration update failed: %w", err)
+	}
+
+	store := state.NewStore(j.rootDir)
+	for _, artifact := range artifacts {
+		if err := j.updateArtifact(store, artifact); err != nil {
+			return fmt.Errorf("configuration update failed: %w", err)
+		}
+	}
+
 	if j.dryRun {
-		j.logger.Info("Dry-run: Skipping configuration update")
+		j.logger.Info("Dry-run: no files were changed")
 		return nil
 	}
 
-	j.logger.Info("Updating configuration")
+	// Record the new merge bases only once every file was merged,
+	// so a failed update leaves the previous bases in place
+	for _, artifact := range artifacts {
+		basePath := artifactPath(j.rootDir, state.BaseFile(artifact.Path))
+		if err := j.backups.Record(basePath); err != nil {
+			return err
+		}
+		j.updated = append(j.updated, basePath)
 
-	err := generateGoReleaserConfig(j.config)
-	if err != nil {
-		return fmt.Errorf("configuration update failed: %w", err)
+		if err := store.SaveBase(artifact.Path, artifact.Content); err != nil {
+			return err
+		}
 	}
 
 	j.logger.Info("Configuration updated successfully")
 	return nil
 }
 
+// updateArtifact merges one generated artifact into the file on disk
+func (j *ConfigUpdateJob) updateArtifact(store *state.Store, artifact generator.Artifact) error {
+	path := artifactPath(j.rootDir, artifact.Path)
+
+	current, exists, err := readArtifact(path)
+	if err != nil {
+		return err
+	}
+
+	merged := artifact.Content
+	unresolved := 0
+	if exists {
+		base, hasBase, err := store.Base(artifact.Path)
+		if err != nil {
+			return err
+		}
+		if !hasBase {
+			j.logger.Warn("No merge base recorded, local differences will conflict", "path", path)
+		}
+
+		// YAML is merged per key and item; other files, e.g. the Dockerfile, per line
+		mergeFiles := merge.Merge
+		if isYAMLArtifact(artifact.Path) {
+			mergeFiles = merge.MergeYAML
+		}
+		result := mergeFiles(base, string(current), artifact.Content)
+		merged = result.Render(merge.DefaultLabels, func(index int, conflict merge.Conflict) merge.Resolution {
+			resolution := merge.ResolveMarkers
+			if j.resolver != nil {
+				resolution = j.resolver(artifact.Path, index, conflict)
+			}
+			if resolution == merge.ResolveMarkers {
+				unresolved++
+			}
+			return resolution
+		})
+
+		if merged == string(current) {
+			j.logger.Info("Already up to date", "path", path)
+			return nil
+		}
+	}
+
+	// A clean merge must still be a valid YAML document
+	if unresolved == 0 && isYAMLArtifact(artifact.Path) {
+		var document any
+		if err := yaml.Unmarshal([]byte(merged), &document); err != nil {
+			return domain.NewTemplateError(
+				domain.ErrTemplateSyntaxError,
+				"Merged file is not valid YAML",
+				fmt.Sprintf("merging %s produced invalid YAML: %v", path, err),
+			).WithContext(path).WithCause(err)
+		}
+	}
+
+	if j.dryRun {
+		j.logger.Info("Dry-run: would update file", "path", path, "conflicts", unresolved)
+		return nil
+	}
+
+	if err := j.backups.Record(path); err != nil {
+		return err
+	}
+	j.updated = append(j.updated, path)
+
+	if err := generator.WriteArtifact(j.rootDir, generator.Artifact{Kind: artifact.Kind, Path: artifact.Path, Content: merged}); err != nil {
+		return err
+	}
+
+	if unresolved > 0 {
+		j.logger.Warn("Wrote conflict markers", "path", path, "conflicts", unresolved)
+	} else {
+		j.logger.Info("Merged generated changes", "path", path)
+	}
+	return nil
+}
+
 func (j *ConfigUpdateJob) Rollback(ctx context.Context) error {
-	// In real implementation, this would restore previous configuration
-	j.logger.Info("Configuration update rollback not implemented")
+	j.logger.Info("Rolling back configuration update")
+
+	if err := j.backups.Restore(j.updated...); err != nil {
+		return err
+	}
+	j.updated = nil
+	return nil
+}
+
+// RollbackJob restores every file of a recorded run from the backup catalog.
+// The files it overwrites are recorded as a run of their own.
+type RollbackJob struct {
+	id       string
+	runID    string
+	rootDir  string
+	backups  *BackupRecorder
+	restored []string
+	logger   *log.Logger
+}
+
+func (j *RollbackJob) ID() string {
+	return j.id
+}
+
+func (j *RollbackJob) Name() string {
+	return "Rollback Run"
+}
+
+func (j *RollbackJob) Execute(ctx context.Context) error {
+	store := state.NewStore(j.rootDir)
+
+	var run *state.Manifest
+	var err error
+	if j.runID == "" {
+		run, err = store.LatestRun(string(WorkflowTypeRollback))
+	} else {
+		run, err = store.LoadRun(j.runID)
+	}
+	if err != nil {
+		return err
+	}
+
+	j.logger.Info("Rolling back run", "run", run.ID, "command", run.Command, "files", len(run.Files))
+
+	// Keep the current files so this rollback can itself be undone
+	for _, file := range run.Files {
+		path := artifactPath(j.rootDir, file.Path)
+		if err := j.backups.Record(path); err != nil {
+			return err
+		}
+		j.restored = append(j.restored, path)
+	}
+
+	if err := store.RestoreRun(run); err != nil {
+		return err
+	}
+	if err := store.MarkRolledBack(run, time.Now()); err != nil {
+		return err
+	}
+
+	j.logger.Info("Run rolled back", "run", run.ID, "undo", j.backups.RunID())
+	return nil
+}
+
+func (j *RollbackJob) Rollback(ctx context.Context) error {
+	// RestoreRun is all-or-nothing; only a failure after it needs undoing
+	if err := j.backups.Restore(j.restored...); err != nil {
+		return err
+	}
+	j.restored = nil
 	return nil
 }
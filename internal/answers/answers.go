@@ -0,0 +1,265 @@
+// Package answers persists the wizard answers of a project in a versioned
+// .goreleaser-wizard.yaml, the single input of generate, update and diff
+package answers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// FileName is the answers file, relative to the project root
+const FileName = ".goreleaser-wizard.yaml"
+
+// SchemaVersion is the answers schema this wizard writes. Older files are
+// upgraded by Migrations when read; files without schema_version predate
+// versioning and use schema 0.
+//
+//	0: booleans for CGO, Docker, signing, actions and Pro features
+//	1: typed levels and statuses (cgo_status, docker_support, ...)
+const SchemaVersion = 1
+
+// document is the on-disk form: the schema version followed by the answers
+type document struct {
+	SchemaVersion            int `yaml:"schema_version"`
+	domain.SafeProjectConfig `yaml:",inline"`
+}
+
+// Store keeps wizard answers in answers files.
+// It implements domain.ConfigUseCase.
+type Store struct {
+	layers []domain.Layer
+}
+
+var _ domain.ConfigUseCase = (*Store)(nil)
+
+// New creates an answers store. New answers start from the given layers,
+// weakest first, such as an organization baseline and user defaults.
+func New(layers ...domain.Layer) *Store {
+	return &Store{layers: layers}
+}
+
+// CreateConfig returns draft answers for a project type, merged from the
+// store's layers and the defaults of the project type
+func (s *Store) CreateConfig(ctx context.Context, projectType domain.ProjectType) (*domain.SafeProjectConfig, error) {
+	if !projectType.IsValid() {
+		return nil, domain.NewValidationError(
+			domain.ErrInvalidProjectType,
+			"Invalid project type",
+			fmt.Sprintf("'%s' is not a valid project type", projectType),
+		).WithField("project_type")
+	}
+
+	requested := domain.Layer{
+		Kind:   domain.LayerFlags,
+		Source: "project type",
+		Values: map[string]any{"project_type": string(projectType)},
+	}
+	resolved, err := domain.ResolveLayers(append(s.layers[:len(s.layers):len(s.layers)], requested)...)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.Config, nil
+}
+
+// LoadConfig reads the answers at path and validates them. Invalid answers
+// are returned together with every violation.
+func (s *Store) LoadConfig(ctx context.Context, path string) (*domain.SafeProjectConfig, error) {
+	config, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.ValidateConfig(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	return config, result.Err()
+}
+
+// SaveConfig writes the answers to path with the current schema version
+func (s *Store) SaveConfig(ctx context.Context, config *domain.SafeProjectConfig, path string) error {
+	data, err := Encode(config)
+	if err != nil {
+		return domain.NewSystemError(
+			domain.ErrFileWriteFailed,
+			"Failed to encode wizard answers",
+			err.Error(),
+			err,
+		).WithContext(path)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return domain.FileWriteFailedError(path, err)
+	}
+	return nil
+}
+
+// ValidateConfig checks the invariants and moves the answers to the valid or
+// invalid state. Answers that were generated, found invalid before, or left
+// processing by an interrupted run, start a new lifecycle as a draft first.
+func (s *Store) ValidateConfig(ctx context.Context, config *domain.SafeProjectConfig) (*domain.ValidationResult, error) {
+	switch config.State {
+	case domain.ConfigStateGenerated, domain.ConfigStateInvalid, domain.ConfigStateProcessing:
+		config.State = domain.GetInitialConfigState()
+	}
+
+	result := domain.NewValidationResult(config.Violations())
+	next := domain.ConfigStateValid
+	if !result.IsValid {
+		next = domain.ConfigStateInvalid
+	}
+	// A state that is not part of the lifecycle is one of the violations
+	if config.State.IsValid() {
+		if err := transition(config, next); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// UpdateConfig applies a partial update to a copy of the answers and
+// validates the result, unless the update sets the state itself
+func (s *Store) UpdateConfig(ctx context.Context, config *domain.SafeProjectConfig, updates *domain.ConfigUpdate) (*domain.SafeProjectConfig, error) {
+	updated := config.Clone()
+	if updates == nil {
+		return updated, nil
+	}
+	apply(updated, updates)
+	return s.revise(ctx, config, updated, updates.State)
+}
+
+// PatchConfig applies an RFC 7396 JSON merge patch to a copy of the answers
+// and validates the result, unless the patch sets the state itself
+func (s *Store) PatchConfig(ctx context.Context, config *domain.SafeProjectConfig, patch []byte) (*domain.SafeProjectConfig, error) {
+	updated, err := config.ApplyMergePatch(patch)
+	if err != nil {
+		return nil, err
+	}
+	return s.reviseEdited(ctx, config, updated)
+}
+
+// reviseEdited finishes an edit that may have changed the state field
+func (s *Store) reviseEdited(ctx context.Context, config, updated *domain.SafeProjectConfig) (*domain.SafeProjectConfig, error) {
+	var state *domain.ConfigState
+	if updated.State != config.State {
+		requested := updated.State
+		state, updated.State = &requested, config.State
+	}
+	return s.revise(ctx, config, updated, state)
+}
+
+// revise finishes an update. A requested state is a transition from the
+// current one; otherwise changed answers are a new draft and are validated
+// again, and unchanged answers keep their state.
+func (s *Store) revise(ctx context.Context, config, updated *domain.SafeProjectConfig, state *domain.ConfigState) (*domain.SafeProjectConfig, error) {
+	if state != nil {
+		if err := transition(updated, *state); err != nil {
+			return nil, err
+		}
+		return updated, nil
+	}
+	if updated.Equals(config) {
+		return updated, nil
+	}
+
+	updated.State = domain.GetInitialConfigState()
+	result, err := s.ValidateConfig(ctx, updated)
+	if err != nil {
+		return nil, err
+	}
+	return updated, result.Err()
+}
+
+// BeginGeneration moves valid answers to the processing state
+func (s *Store) BeginGeneration(config *domain.SafeProjectConfig) error {
+	if !config.State.AllowsGeneration() {
+		return domain.NewBusinessRuleError(
+			domain.ErrInvalidStateTransition,
+			"Answers are not ready for generation",
+			fmt.Sprintf("Answers in state %s cannot be generated; validate them first", config.State),
+		).WithField("state")
+	}
+	return transition(config, domain.ConfigStateProcessing)
+}
+
+// CompleteGeneration records that artifacts were generated from the answers
+func (s *Store) CompleteGeneration(config *domain.SafeProjectConfig) error {
+	return transition(config, domain.ConfigStateGenerated)
+}
+
+// Read parses the answers file at path without validating invariants
+func Read(path string) (*domain.SafeProjectConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.FileNotFoundError(path, err)
+		}
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read wizard answers",
+			fmt.Sprintf("Cannot read %s", path),
+			err,
+		).WithContext(path)
+	}
+
+	config, err := Decode(data)
+	if err != nil {
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, domainErr.WithContext(path)
+		}
+		return nil, err
+	}
+	return config, nil
+}
+
+// Decode parses answers of any supported schema version, upgrading them
+// first, and rejects unknown keys
+func Decode(data []byte) (*domain.SafeProjectConfig, error) {
+	migrated, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	return decodeCurrent(migrated.Content)
+}
+
+// decodeCurrent parses answers of the current schema version
+func decodeCurrent(data []byte) (*domain.SafeProjectConfig, error) {
+	var doc document
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		return nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid wizard answers file",
+			err.Error(),
+		).WithCause(err)
+	}
+	return &doc.SafeProjectConfig, nil
+}
+
+// Encode renders answers with the current schema version
+func Encode(config *domain.SafeProjectConfig) ([]byte, error) {
+	return yaml.Marshal(document{SchemaVersion: SchemaVersion, SafeProjectConfig: *config})
+}
+
+// transition changes the lifecycle state, reporting forbidden transitions
+func transition(config *domain.SafeProjectConfig, state domain.ConfigState) error {
+	if config.State == state {
+		return nil
+	}
+	if err := config.TransitionToState(state); err != nil {
+		return domain.NewBusinessRuleError(
+			domain.ErrInvalidStateTransition,
+			"Invalid answers state transition",
+			err.Error(),
+		).WithField("state").WithCause(err)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package answers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func newConfig(t *testing.T) *domain.SafeProjectConfig {
+	t.Helper()
+	config, err := New().CreateConfig(context.Background(), domain.ProjectTypeCLI)
+	if err != nil {
+		t.Fatalf("CreateConfig() error = %v", err)
+	}
+	config.ProjectName = "tool"
+	config.BinaryName = "tool"
+	config.MainPath = "./cmd/tool"
+	return config
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	path := filepath.Join(t.TempDir(), FileName)
+
+	config := newConfig(t)
+	if config.State != domain.ConfigStateDraft {
+		t.Errorf("created state = %s, want draft", config.State)
+	}
+	if err := store.SaveConfig(ctx, config, path); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "schema_version: 1\n") {
+		t.Errorf("answers file does not start with the schema version:\n%s", data)
+	}
+
+	loaded, err := store.LoadConfig(ctx, path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if loaded.State != domain.ConfigStateValid {
+		t.Errorf("loaded state = %s, want valid", loaded.State)
+	}
+	loaded.State = config.State
+	if !reflect.DeepEqual(loaded, config) {
+		t.Errorf("LoadConfig() =\n%+v\nwant\n%+v", loaded, config)
+	}
+}
+
+func TestSaveConfigWritesResolvedLayers(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), FileName)
+	user := domain.Layer{Kind: domain.LayerUser, Source: "home.yaml", Values: map[string]any{"signing_level": "advanced"}}
+
+	store := New(user)
+	config, err := store.CreateConfig(ctx, domain.ProjectTypeCLI)
+	if err != nil {
+		t.Fatalf("CreateConfig() error = %v", err)
+	}
+	if err := store.SaveConfig(ctx, config, path); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+
+	// A reader without the user defaults gets the same answers
+	loaded, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if loaded.SigningLevel != domain.SigningLevelAdvanced {
+		t.Errorf("signing_level = %s, want the user default written by init", loaded.SigningLevel)
+	}
+	if !reflect.DeepEqual(loaded, config) {
+		t.Errorf("Read() =\n%+v\nwant\n%+v", loaded, config)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		code    domain.ErrorCode
+	}{
+		{name: "unversioned", content: "project_name: tool\n"},
+		{name: "current", content: "schema_version: 1\nproject_name: tool\n"},
+		{name: "empty", content: ""},
+		{name: "newer", content: "schema_version: 2\nproject_name: tool\n", code: domain.ErrUnsupportedAnswersVersion},
+		{name: "unknown key", content: "schema_version: 1\nprojectname: tool\n", code: domain.ErrTemplateSyntaxError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Decode([]byte(tt.content))
+			if tt.code == "" {
+				if err != nil {
+					t.Errorf("Decode() error = %v", err)
+				}
+				return
+			}
+			if !domain.IsErrorCode(err, tt.code) {
+				t.Errorf("Decode() error = %v, want %s", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	config := newConfig(t)
+
+	if err := store.BeginGeneration(config); !domain.IsErrorCode(err, domain.ErrInvalidStateTransition) {
+		t.Errorf("BeginGeneration() of a draft error = %v", err)
+	}
+
+	result, err := store.ValidateConfig(ctx, config)
+	if err != nil || !result.IsValid || config.State != domain.ConfigStateValid {
+		t.Fatalf("ValidateConfig() = %+v, %v; state %s", result, err, config.State)
+	}
+	if err := store.BeginGeneration(config); err != nil {
+		t.Fatalf("BeginGeneration() error = %v", err)
+	}
+	if err := store.CompleteGeneration(config); err != nil || config.State != domain.ConfigStateGenerated {
+		t.Fatalf("CompleteGeneration() error = %v; state %s", err, config.State)
+	}
+
+	// Generated answers start over when they are validated again
+	config.ProjectName = ""
+	result, err = store.ValidateConfig(ctx, config)
+	if err != nil || result.IsValid || config.State != domain.ConfigStateInvalid {
+		t.Errorf("ValidateConfig() = %+v, %v; state %s", result, err, config.State)
+	}
+}
+
+func TestUpdateConfig(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	config := newConfig(t)
+	config.SigningLevel = domain.SigningLevelAdvanced
+	config.DockerSupport = domain.DockerSupportNone
+
+	name := "renamed"
+	enabled := true
+	platforms := []domain.Platform{domain.PlatformLinux}
+	updated, err := store.UpdateConfig(ctx, config, &domain.ConfigUpdate{
+		ProjectName:   &name,
+		Platforms:     platforms,
+		Signing:       &enabled,
+		DockerEnabled: &enabled,
+	})
+	if err != nil {
+		t.Fatalf("UpdateConfig() error = %v", err)
+	}
+	if updated.ProjectName != name || !reflect.DeepEqual(updated.Platforms, platforms) {
+		t.Errorf("updated = %s %v", updated.ProjectName, updated.Platforms)
+	}
+	if updated.SigningLevel != domain.SigningLevelAdvanced || updated.DockerSupport != domain.DockerSupportBoth {
+		t.Errorf("legacy booleans gave signing %s, docker %s", updated.SigningLevel, updated.DockerSupport)
+	}
+	if updated.State != domain.ConfigStateValid {
+		t.Errorf("updated state = %s, want valid", updated.State)
+	}
+	if config.ProjectName != "tool" {
+		t.Errorf("UpdateConfig() modified its input")
+	}
+
+	empty := ""
+	invalid, err := store.UpdateConfig(ctx, config, &domain.ConfigUpdate{BinaryName: &empty})
+	if err == nil || invalid.State != domain.ConfigStateInvalid {
+		t.Errorf("UpdateConfig() with an empty binary = %v; state %s", err, invalid.State)
+	}
+}
+
+func TestPatchConfig(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	config := newConfig(t)
+	config.State = domain.ConfigStateGenerated
+
+	unchanged, err := store.PatchConfig(ctx, config, []byte(`{"sbom": false}`))
+	if err != nil || unchanged.State != domain.ConfigStateGenerated {
+		t.Errorf("PatchConfig() without changes = %v; state %s", err, unchanged.State)
+	}
+
+	patched, err := store.PatchConfig(ctx, config, []byte(`{"sbom": true, "signing_level": "basic"}`))
+	if err != nil {
+		t.Fatalf("PatchConfig() error = %v", err)
+	}
+	if !patched.SBOM || patched.SigningLevel != domain.SigningLevelBasic || patched.State != domain.ConfigStateValid {
+		t.Errorf("patched = sbom %t, signing %s, state %s", patched.SBOM, patched.SigningLevel, patched.State)
+	}
+
+	if _, err := store.PatchConfig(ctx, config, []byte(`{"state": "draft"}`)); !domain.IsErrorCode(err, domain.ErrInvalidStateTransition) {
+		t.Errorf("PatchConfig() leaving the final state error = %v", err)
+	}
+}
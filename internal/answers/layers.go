@@ -0,0 +1,205 @@
+package answers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// ReadLayer reads partial answers from a file, or from every .yaml and .yml
+// file of a directory in name order, one layer per file
+func ReadLayer(kind domain.LayerKind, path string) ([]domain.Layer, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.FileNotFoundError(path, err)
+		}
+		return nil, readFailed(path, err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, readFailed(path, err)
+		}
+		files = nil
+		for _, entry := range entries {
+			if ext := filepath.Ext(entry.Name()); !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+		sort.Strings(files)
+	}
+
+	var layers []domain.Layer
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, readFailed(file, err)
+		}
+		layer, err := DecodeLayer(kind, file, data)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+	}
+	return layers, nil
+}
+
+// DecodeLayer parses partial answers of any supported schema version. Every
+// key must be an answers field and enum values must be valid; state is
+// managed by the wizard and cannot be layered.
+func DecodeLayer(kind domain.LayerKind, source string, data []byte) (domain.Layer, error) {
+	layer := domain.Layer{Kind: kind, Source: source, Values: map[string]any{}}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return layer, nil
+	}
+
+	root, err := layerNode(data)
+	if err != nil {
+		return layer, asLayerError(err).WithContext(source)
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i].Value, root.Content[i+1]
+		if key == "schema_version" {
+			continue
+		}
+		if key == "state" {
+			return layer, layerError(fmt.Sprintf("line %d: state is managed by the wizard", root.Content[i].Line)).WithField(key).WithContext(source)
+		}
+		if err := addLayerValue(layer, key, value); err != nil {
+			return layer, asLayerError(err).WithContext(source)
+		}
+	}
+	return layer, checkLayer(layer)
+}
+
+// ProjectLayer returns the persisted answers of a project as a layer
+func ProjectLayer(path string) (domain.Layer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return domain.Layer{}, domain.FileNotFoundError(path, err)
+		}
+		return domain.Layer{}, readFailed(path, err)
+	}
+
+	root, err := layerNode(data)
+	if err != nil {
+		return domain.Layer{}, asLayerError(err).WithContext(path)
+	}
+	remove(root, "state")
+	content, err := encode(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}})
+	if err != nil {
+		return domain.Layer{}, err
+	}
+	return DecodeLayer(domain.LayerProject, path, content)
+}
+
+// FlagLayer builds the command line layer from field=value assignments such
+// as "signing_level=advanced" or "platforms=[linux, darwin]"
+func FlagLayer(assignments []string) (domain.Layer, error) {
+	layer := domain.Layer{Kind: domain.LayerFlags, Source: "--set", Values: map[string]any{}}
+	for _, assignment := range assignments {
+		key, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return layer, layerError(fmt.Sprintf("%q is not field=value", assignment)).WithContext(layer.Source)
+		}
+		_, typ, err := resolvePath(key)
+		if err != nil {
+			return layer, err
+		}
+		node, err := parseValue(key, typ, value)
+		if err != nil {
+			return layer, err
+		}
+		if err := addLayerValue(layer, key, node); err != nil {
+			return layer, asLayerError(err).WithContext(layer.Source)
+		}
+	}
+	return layer, checkLayer(layer)
+}
+
+// layerNode upgrades partial answers to the current schema and returns their
+// top-level mapping
+func layerNode(data []byte) (*yaml.Node, error) {
+	migrated, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(migrated.Content, &doc); err != nil {
+		return nil, layerError(err.Error()).WithCause(err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, layerError("expected a mapping of answers fields")
+	}
+	return doc.Content[0], nil
+}
+
+// addLayerValue checks a top-level field and its value and adds it to a layer
+func addLayerValue(layer domain.Layer, key string, node *yaml.Node) error {
+	segments, typ, err := resolvePath(key)
+	if err != nil {
+		return err
+	}
+	if len(segments) != 1 {
+		return layerError(fmt.Sprintf("%q is not a top-level answers field", key)).WithField(key)
+	}
+	if err := checkEnum(key, typ, node); err != nil {
+		return err
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return layerError(err.Error()).WithField(key).WithCause(err)
+	}
+	layer.Values[key] = value
+	return nil
+}
+
+// checkLayer rejects values of the wrong type, e.g. "sbom: yes please"
+func checkLayer(layer domain.Layer) error {
+	patch, err := json.Marshal(layer.Values)
+	if err != nil {
+		return layerError(err.Error()).WithContext(layer.Source).WithCause(err)
+	}
+	if _, err := domain.NewSafeProjectConfig().ApplyMergePatch(patch); err != nil {
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			return layerError(domainErr.Details).WithContext(layer.Source).WithCause(err)
+		}
+		return err
+	}
+	return nil
+}
+
+func layerError(details string) *domain.DomainError {
+	return domain.NewValidationError(domain.ErrInvalidConfigLayer, "Invalid configuration layer", details)
+}
+
+func asLayerError(err error) *domain.DomainError {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return layerError(err.Error()).WithCause(err)
+}
+
+func readFailed(path string, err error) *domain.DomainError {
+	return domain.NewSystemError(
+		domain.ErrFileReadFailed,
+		"Failed to read configuration layer",
+		fmt.Sprintf("Cannot read %s", path),
+		err,
+	).WithContext(path)
+}
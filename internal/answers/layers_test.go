@@ -0,0 +1,118 @@
+package answers
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func TestReadLayer(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"10-signing.yaml": "signing_level: advanced\n",
+		"20-sbom.yml":     "sbom: true\nplatforms: [linux]\n",
+		"README.md":       "not a layer",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	layers, err := ReadLayer(domain.LayerOrg, dir)
+	if err != nil {
+		t.Fatalf("ReadLayer() error = %v", err)
+	}
+	if len(layers) != 2 || filepath.Base(layers[0].Source) != "10-signing.yaml" {
+		t.Fatalf("ReadLayer() = %+v, want both YAML files in name order", layers)
+	}
+	if layers[0].Values["signing_level"] != "advanced" || layers[1].Values["sbom"] != true {
+		t.Errorf("ReadLayer() values = %v, %v", layers[0].Values, layers[1].Values)
+	}
+
+	if _, err := ReadLayer(domain.LayerOrg, filepath.Join(dir, "missing.yaml")); !domain.IsErrorCode(err, domain.ErrFileNotFound) {
+		t.Errorf("ReadLayer(missing) error = %v", err)
+	}
+}
+
+func TestDecodeLayer(t *testing.T) {
+	// Schema 0 keys are upgraded like full answers
+	layer, err := DecodeLayer(domain.LayerUser, "home.yaml", []byte("docker_enabled: true\n"))
+	if err != nil {
+		t.Fatalf("DecodeLayer() error = %v", err)
+	}
+	if !reflect.DeepEqual(layer.Values, map[string]any{"docker_support": "both"}) {
+		t.Errorf("DecodeLayer() values = %v", layer.Values)
+	}
+
+	tests := map[string]domain.ErrorCode{
+		"signing: basic\n":                domain.ErrInvalidAnswersPath,
+		"signing_level: maximum\n":        domain.ErrInvalidAnswersValue,
+		"sbom: [true]\n":                  domain.ErrInvalidConfigLayer,
+		"state: valid\n":                  domain.ErrInvalidConfigLayer,
+		"- signing_level\n":               domain.ErrTemplateSyntaxError,
+		"schema_version: 9\nsbom: true\n": domain.ErrUnsupportedAnswersVersion,
+	}
+	for content, code := range tests {
+		if _, err := DecodeLayer(domain.LayerUser, "home.yaml", []byte(content)); !domain.IsErrorCode(err, code) {
+			t.Errorf("DecodeLayer(%q) error = %v, want %s", content, err, code)
+		}
+	}
+}
+
+func TestProjectLayer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), FileName)
+	if err := New().SaveConfig(context.Background(), newConfig(t), path); err != nil {
+		t.Fatal(err)
+	}
+
+	layer, err := ProjectLayer(path)
+	if err != nil {
+		t.Fatalf("ProjectLayer() error = %v", err)
+	}
+	if layer.Kind != domain.LayerProject || layer.Values["binary_name"] != "tool" {
+		t.Errorf("ProjectLayer() = %+v", layer)
+	}
+	if _, ok := layer.Values["state"]; ok {
+		t.Error("ProjectLayer() layers the lifecycle state")
+	}
+}
+
+func TestFlagLayer(t *testing.T) {
+	layer, err := FlagLayer([]string{"signing_level=advanced", "platforms=[linux, darwin]", "project_description=A tool: fast"})
+	if err != nil {
+		t.Fatalf("FlagLayer() error = %v", err)
+	}
+	expected := map[string]any{
+		"signing_level":       "advanced",
+		"platforms":           []any{"linux", "darwin"},
+		"project_description": "A tool: fast",
+	}
+	if !reflect.DeepEqual(layer.Values, expected) {
+		t.Errorf("FlagLayer() values = %v", layer.Values)
+	}
+
+	for _, assignment := range []string{"sbom", "platforms[0]=linux", "docker_registry=example.com"} {
+		if _, err := FlagLayer([]string{assignment}); err == nil {
+			t.Errorf("FlagLayer(%s) accepted an invalid assignment", assignment)
+		}
+	}
+}
+
+func TestCreateConfigUsesLayers(t *testing.T) {
+	org := domain.Layer{Kind: domain.LayerOrg, Source: "org.yaml", Values: map[string]any{
+		"signing_level": "enterprise",
+		"project_type":  "library",
+	}}
+	config, err := New(org).CreateConfig(context.Background(), domain.ProjectTypeWeb)
+	if err != nil {
+		t.Fatalf("CreateConfig() error = %v", err)
+	}
+	if config.SigningLevel != domain.SigningLevelEnterprise || config.ProjectType != domain.ProjectTypeWeb {
+		t.Errorf("CreateConfig() signing = %s, type = %s", config.SigningLevel, config.ProjectType)
+	}
+}
@@ -0,0 +1,253 @@
+package answers
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// Change is a single rewrite made by a migration
+type Change struct {
+	Migration string
+	Path      string
+	Line      int
+	Before    string
+	After     string
+}
+
+// Migration upgrades answers from schema version From to From+1
+type Migration struct {
+	ID          string
+	From        int
+	Description string
+	apply       func(root *yaml.Node) []Change
+}
+
+// MigrationResult is the outcome of upgrading an answers file
+type MigrationResult struct {
+	Content []byte
+	From    int
+	To      int
+	Changes []Change
+}
+
+// Changed reports whether the file needs to be rewritten
+func (r *MigrationResult) Changed() bool {
+	return r.From != r.To
+}
+
+// Migrations returns every registered migration in application order
+func Migrations() []Migration {
+	return []Migration{
+		{
+			ID:          "typed-enums",
+			From:        0,
+			Description: "Replace the cgo_enabled, docker_enabled, signing, generate_actions and pro_version booleans and project type names with typed values",
+			apply:       typedEnums,
+		},
+	}
+}
+
+// Migrate upgrades answers to SchemaVersion, keeping comments and key order.
+// Answers already at SchemaVersion are returned unchanged.
+func Migrate(data []byte) (*MigrationResult, error) {
+	result := &MigrationResult{Content: data, From: SchemaVersion, To: SchemaVersion, Changes: []Change{}}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid wizard answers file",
+			err.Error(),
+		).WithCause(err)
+	}
+	if len(doc.Content) == 0 {
+		return result, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid wizard answers file",
+			"the top level of the answers must be a mapping",
+		)
+	}
+
+	version, err := schemaVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	result.From = version
+	if version == SchemaVersion {
+		return result, nil
+	}
+
+	for _, migration := range Migrations() {
+		if migration.From < version {
+			continue
+		}
+		for _, change := range migration.apply(root) {
+			change.Migration = migration.ID
+			result.Changes = append(result.Changes, change)
+		}
+	}
+	result.Changes = append(result.Changes, setSchemaVersion(root))
+	content, err := encode(&doc)
+	if err != nil {
+		return nil, domain.NewTemplateError(
+			domain.ErrTemplateExecutionFailed,
+			"Failed to encode migrated answers",
+			err.Error(),
+		).WithCause(err)
+	}
+	result.Content = content
+	return result, nil
+}
+
+// schemaVersion reads schema_version; files without it are schema 0
+func schemaVersion(root *yaml.Node) (int, error) {
+	_, value := lookup(root, "schema_version")
+	if value == nil {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value.Value)
+	if err != nil || value.Kind != yaml.ScalarNode || version < 0 || version > SchemaVersion {
+		return 0, domain.NewValidationError(
+			domain.ErrUnsupportedAnswersVersion,
+			"Unsupported answers schema version",
+			fmt.Sprintf("schema_version %s is not supported; this wizard reads 0 to %d", value.Value, SchemaVersion),
+		).WithField("schema_version").WithPosition(value.Line, value.Column)
+	}
+	return version, nil
+}
+
+// setSchemaVersion records the current schema version at the top of the file
+func setSchemaVersion(root *yaml.Node) Change {
+	change := Change{Migration: "schema-version", Path: "schema_version", Line: 1, Before: "(none)"}
+	current := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(SchemaVersion)}
+
+	if key, value := lookup(root, "schema_version"); value != nil {
+		change.Line = key.Line
+		change.Before = "schema_version: " + value.Value
+		value.Value = current.Value
+	} else {
+		key := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "schema_version"}
+		// Keep the file's leading comment above the version
+		if len(root.Content) > 0 {
+			key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+		}
+		root.Content = append([]*yaml.Node{key, current}, root.Content...)
+	}
+	change.After = "schema_version: " + current.Value
+	return change
+}
+
+// legacyBooleans maps the schema 0 booleans to their typed schema 1 fields
+var legacyBooleans = []struct {
+	key     string
+	typed   string
+	convert func(enabled bool) string
+}{
+	{"cgo_enabled", "cgo_status", func(enabled bool) string {
+		if enabled {
+			return string(domain.CGOStatusEnabled)
+		}
+		return string(domain.CGOStatusDisabled)
+	}},
+	{"docker_enabled", "docker_support", func(enabled bool) string { return string(domain.DockerSupportFromBool(enabled)) }},
+	{"signing", "signing_level", func(enabled bool) string { return string(domain.SigningLevelFromBool(enabled)) }},
+	{"generate_actions", "action_level", func(enabled bool) string { return string(domain.ActionLevelFromBool(enabled)) }},
+	{"pro_version", "feature_level", func(enabled bool) string { return string(domain.FeatureLevelFromBool(enabled)) }},
+}
+
+// typedEnums rewrites the schema 0 booleans and project type display names.
+// A typed field that is already set wins over its legacy boolean.
+func typedEnums(root *yaml.Node) []Change {
+	var changes []Change
+	for _, legacy := range legacyBooleans {
+		key, value := lookup(root, legacy.key)
+		if value == nil {
+			continue
+		}
+		enabled, err := strconv.ParseBool(value.Value)
+		if err != nil || value.Kind != yaml.ScalarNode {
+			// Left in place for the decoder to report
+			continue
+		}
+
+		before := legacy.key + ": " + value.Value
+		if _, typed := lookup(root, legacy.typed); typed != nil {
+			remove(root, legacy.key)
+			changes = append(changes, Change{
+				Path:   legacy.key,
+				Line:   key.Line,
+				Before: before,
+				After:  fmt.Sprintf("(removed, %s is set)", legacy.typed),
+			})
+			continue
+		}
+
+		key.Value = legacy.typed
+		value.Value, value.Tag, value.Style = legacy.convert(enabled), "!!str", 0
+		changes = append(changes, Change{
+			Path:   legacy.key,
+			Line:   key.Line,
+			Before: before,
+			After:  legacy.typed + ": " + value.Value,
+		})
+	}
+
+	if key, value := lookup(root, "project_type"); value != nil && !domain.ProjectType(value.Value).IsValid() {
+		for _, projectType := range domain.GetAllProjectTypes() {
+			if strings.EqualFold(projectType.String(), value.Value) {
+				changes = append(changes, Change{
+					Path:   "project_type",
+					Line:   key.Line,
+					Before: "project_type: " + value.Value,
+					After:  "project_type: " + string(projectType),
+				})
+				value.Value, value.Style = string(projectType), 0
+				break
+			}
+		}
+	}
+	return changes
+}
+
+// lookup returns the key and value nodes of a mapping entry
+func lookup(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// remove deletes a mapping entry
+func remove(mapping *yaml.Node, key string) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return
+		}
+	}
+}
+
+// encode renders a document with two-space indentation
+func encode(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,151 @@
+package answers
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// TestMigrationsChain checks that every schema version up to the current one
+// has exactly one migration, in order
+func TestMigrationsChain(t *testing.T) {
+	migrations := Migrations()
+	if len(migrations) != SchemaVersion {
+		t.Fatalf("%d migrations for schema version %d", len(migrations), SchemaVersion)
+	}
+	for i, migration := range migrations {
+		if migration.From != i {
+			t.Errorf("migration %s upgrades from %d, want %d", migration.ID, migration.From, i)
+		}
+		if migration.ID == "" || migration.Description == "" || migration.apply == nil {
+			t.Errorf("migration %d is incomplete: %+v", i, migration)
+		}
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+		changes  []string
+	}{
+		{
+			name: "legacy booleans",
+			content: `# wizard answers
+project_name: tool
+project_type: CLI Application
+cgo_enabled: false
+docker_enabled: true
+signing: true
+generate_actions: true
+pro_version: false
+`,
+			expected: `# wizard answers
+schema_version: 1
+project_name: tool
+project_type: cli
+cgo_status: disabled
+docker_support: both
+signing_level: basic
+action_level: basic
+feature_level: basic
+`,
+			changes: []string{"cgo_enabled", "docker_enabled", "signing", "generate_actions", "pro_version", "project_type", "schema_version"},
+		},
+		{
+			name: "typed field wins",
+			content: `schema_version: 0
+docker_support: build
+docker_enabled: false
+`,
+			expected: `schema_version: 1
+docker_support: build
+`,
+			changes: []string{"docker_enabled", "schema_version"},
+		},
+		{
+			name:     "current",
+			content:  "schema_version: 1\ncgo_status: enabled\n",
+			expected: "schema_version: 1\ncgo_status: enabled\n",
+		},
+		{
+			// Written by the first versioned wizard, already typed
+			name: "versioned typed answers",
+			content: `schema_version: 1
+project_name: tool
+project_type: cli
+cgo_status: disabled
+docker_support: none
+signing_level: basic
+state: generated
+`,
+			expected: `schema_version: 1
+project_name: tool
+project_type: cli
+cgo_status: disabled
+docker_support: none
+signing_level: basic
+state: generated
+`,
+		},
+		{
+			name:     "empty",
+			content:  "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Migrate([]byte(tt.content))
+			if err != nil {
+				t.Fatalf("Migrate() error = %v", err)
+			}
+			if string(result.Content) != tt.expected {
+				t.Errorf("Migrate() content =\n%s\nwant\n%s", result.Content, tt.expected)
+			}
+			var paths []string
+			for _, change := range result.Changes {
+				paths = append(paths, change.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.changes) {
+				t.Errorf("changed paths = %v, want %v", paths, tt.changes)
+			}
+			if result.Changed() != (len(tt.changes) > 0) {
+				t.Errorf("Changed() = %t with changes %v", result.Changed(), paths)
+			}
+		})
+	}
+}
+
+func TestMigrateRejectsUnknownVersions(t *testing.T) {
+	for _, content := range []string{"schema_version: -1\n", "schema_version: 2\n", "schema_version: two\n"} {
+		if _, err := Migrate([]byte(content)); !domain.IsErrorCode(err, domain.ErrUnsupportedAnswersVersion) {
+			t.Errorf("Migrate(%q) error = %v", content, err)
+		}
+	}
+}
+
+// TestDecodeLegacyAnswers loads a schema 0 file as typed answers
+func TestDecodeLegacyAnswers(t *testing.T) {
+	config, err := Decode([]byte("project_name: tool\ndocker_enabled: true\ncgo_enabled: true\n"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if config.DockerSupport != domain.DockerSupportBoth || config.CGOStatus != domain.CGOStatusEnabled {
+		t.Errorf("Decode() = docker %s, cgo %s", config.DockerSupport, config.CGOStatus)
+	}
+}
+
+// TestDecodeVersionedAnswers loads a typed schema 1 file without migrating it
+func TestDecodeVersionedAnswers(t *testing.T) {
+	config, err := Decode([]byte("schema_version: 1\nproject_name: tool\ncgo_status: enabled\ndocker_support: build\n"))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if config.DockerSupport != domain.DockerSupportBuild || config.CGOStatus != domain.CGOStatusEnabled {
+		t.Errorf("Decode() = docker %s, cgo %s", config.DockerSupport, config.CGOStatus)
+	}
+}
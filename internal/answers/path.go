@@ -0,0 +1,323 @@
+package answers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// segment is one step of a field path: a yaml key, or a list index when key is empty
+type segment struct {
+	key   string
+	index int
+}
+
+// enumValues lists the valid values of every enum type in the answers
+var enumValues = map[reflect.Type][]string{
+	reflect.TypeOf(domain.ProjectType("")):    stringsOf(domain.GetAllProjectTypes()),
+	reflect.TypeOf(domain.Platform("")):       stringsOf(domain.GetAllPlatforms()),
+	reflect.TypeOf(domain.Architecture("")):   stringsOf(domain.GetAllArchitectures()),
+	reflect.TypeOf(domain.CGOStatus("")):      stringsOf(domain.GetAllCGOStatuses()),
+	reflect.TypeOf(domain.GitProvider("")):    stringsOf(domain.GetAllGitProviders()),
+	reflect.TypeOf(domain.DockerSupport("")):  stringsOf(domain.GetAllDockerSupports()),
+	reflect.TypeOf(domain.DockerRegistry("")): stringsOf(domain.GetAllDockerRegistries()),
+	reflect.TypeOf(domain.SigningLevel("")):   stringsOf(domain.GetAllSigningLevels()),
+	reflect.TypeOf(domain.ActionLevel("")):    stringsOf(domain.GetAllActionLevels()),
+	reflect.TypeOf(domain.ActionTrigger("")):  stringsOf(domain.GetAllActionTriggers()),
+	reflect.TypeOf(domain.FeatureLevel("")):   stringsOf(domain.GetAllFeatureLevels()),
+	reflect.TypeOf(domain.ConfigState("")):    stringsOf(domain.GetAllConfigStates()),
+}
+
+func stringsOf[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
+
+// Get returns the value at a field path such as "platforms" or
+// "build_tags[0].name". Fields left out of the file return a null node.
+func Get(config *domain.SafeProjectConfig, path string) (*yaml.Node, error) {
+	segments, _, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	root, err := configNode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	node := root
+	for i, seg := range segments {
+		node = child(node, seg)
+		if node != nil {
+			continue
+		}
+		// A list left out of the file is empty, so any index into it is out of range
+		for _, rest := range segments[i:] {
+			if rest.key == "" {
+				return nil, invalidPath(path, fmt.Sprintf("index %d is out of range", rest.index))
+			}
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+	return node, nil
+}
+
+// SetField sets the field at path to a YAML value, e.g. "true", "linux" or
+// "[linux, darwin]", and validates the result like UpdateConfig. A list index
+// one past the end appends.
+func (s *Store) SetField(ctx context.Context, config *domain.SafeProjectConfig, path, value string) (*domain.SafeProjectConfig, error) {
+	segments, leaf, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	node, err := parseValue(path, leaf, value)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEnum(path, leaf, node); err != nil {
+		return nil, err
+	}
+
+	return s.edit(ctx, config, path, func(root *yaml.Node) error {
+		parent, last, err := walk(root, path, segments)
+		if err != nil {
+			return err
+		}
+		if last.key != "" {
+			if _, existing := lookup(parent, last.key); existing != nil {
+				*existing = *node
+			} else {
+				parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: last.key}, node)
+			}
+			return nil
+		}
+		switch {
+		case last.index < len(parent.Content):
+			parent.Content[last.index] = node
+		case last.index == len(parent.Content):
+			parent.Content = append(parent.Content, node)
+		default:
+			return invalidPath(path, fmt.Sprintf("index %d is out of range; the list has %d elements", last.index, len(parent.Content)))
+		}
+		return nil
+	})
+}
+
+// parseValue turns a command line value into a node. Text fields and enums
+// take the value literally; everything else is parsed as YAML.
+func parseValue(path string, typ reflect.Type, value string) (*yaml.Node, error) {
+	if typ.Kind() == reflect.String {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}, nil
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err != nil || len(doc.Content) == 0 {
+		return nil, invalidPath(path, fmt.Sprintf("%q is not a YAML value", value))
+	}
+	return doc.Content[0], nil
+}
+
+// UnsetField removes the field at path, resetting it to its zero value, or
+// removes a list element. It validates the result like UpdateConfig.
+func (s *Store) UnsetField(ctx context.Context, config *domain.SafeProjectConfig, path string) (*domain.SafeProjectConfig, error) {
+	segments, _, err := resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.edit(ctx, config, path, func(root *yaml.Node) error {
+		parent, last, err := walk(root, path, segments)
+		if err != nil {
+			return err
+		}
+		if last.key != "" {
+			remove(parent, last.key)
+			return nil
+		}
+		if last.index >= len(parent.Content) {
+			return invalidPath(path, fmt.Sprintf("index %d is out of range", last.index))
+		}
+		parent.Content = append(parent.Content[:last.index], parent.Content[last.index+1:]...)
+		return nil
+	})
+}
+
+// edit applies a change to the YAML form of the answers and decodes the result
+func (s *Store) edit(ctx context.Context, config *domain.SafeProjectConfig, path string, change func(root *yaml.Node) error) (*domain.SafeProjectConfig, error) {
+	root, err := configNode(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := change(root); err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, invalidPath(path, err.Error())
+	}
+	updated, err := decodeCurrent(data)
+	if err != nil {
+		var domainErr *domain.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, invalidPath(path, domainErr.Details)
+		}
+		return nil, err
+	}
+	return s.reviseEdited(ctx, config, updated)
+}
+
+// walk returns the node holding the last segment of a path, creating
+// struct fields left out of the file and appending a list element for an
+// index one past the end
+func walk(root *yaml.Node, path string, segments []segment) (*yaml.Node, segment, error) {
+	node := root
+	for i, seg := range segments[:len(segments)-1] {
+		if seg.key == "" && node.Kind != yaml.SequenceNode {
+			// An empty list is stored as null or left out
+			*node = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		next := child(node, seg)
+		if next == nil {
+			if seg.key == "" && seg.index > len(node.Content) {
+				return nil, seg, invalidPath(path, fmt.Sprintf("index %d is out of range; the list has %d elements", seg.index, len(node.Content)))
+			}
+			next = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			if segments[i+1].key == "" {
+				next = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+			}
+			if seg.key == "" {
+				node.Content = append(node.Content, next)
+			} else {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: seg.key}, next)
+			}
+		}
+		node = next
+	}
+
+	last := segments[len(segments)-1]
+	if last.key == "" && node.Kind != yaml.SequenceNode {
+		*node = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	}
+	return node, last, nil
+}
+
+func child(node *yaml.Node, seg segment) *yaml.Node {
+	if seg.key != "" {
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		_, value := lookup(node, seg.key)
+		return value
+	}
+	if node.Kind != yaml.SequenceNode || seg.index >= len(node.Content) {
+		return nil
+	}
+	return node.Content[seg.index]
+}
+
+// resolvePath parses a path and checks it against the answers fields,
+// returning the segments and the type of the addressed value
+func resolvePath(path string) ([]segment, reflect.Type, error) {
+	var segments []segment
+	typ := reflect.TypeOf(domain.SafeProjectConfig{})
+
+	for i, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" {
+			return nil, nil, invalidPath(path, "every path element needs a field name")
+		}
+		if typ.Kind() != reflect.Struct {
+			return nil, nil, invalidPath(path, fmt.Sprintf("%s has no fields", strings.Join(strings.Split(path, ".")[:i], ".")))
+		}
+		field, ok := fieldByName(typ, key)
+		if !ok {
+			return nil, nil, invalidPath(path, fmt.Sprintf("unknown field %q", key))
+		}
+		segments = append(segments, segment{key: key})
+		typ = field.Type
+
+		for rest != "" {
+			digits, after, found := strings.Cut(rest, "]")
+			index, err := strconv.Atoi(digits)
+			if !found || err != nil || index < 0 || (after != "" && !strings.HasPrefix(after, "[")) {
+				return nil, nil, invalidPath(path, fmt.Sprintf("invalid list index in %q", part))
+			}
+			if typ.Kind() != reflect.Slice {
+				return nil, nil, invalidPath(path, fmt.Sprintf("%s is not a list", key))
+			}
+			segments = append(segments, segment{index: index})
+			typ = typ.Elem()
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return segments, typ, nil
+}
+
+// fieldByName finds a struct field by its yaml name
+func fieldByName(typ reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if tag == name && field.IsExported() {
+			return field, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// checkEnum rejects values outside an enum, listing the valid ones
+func checkEnum(path string, typ reflect.Type, value *yaml.Node) error {
+	values := []*yaml.Node{value}
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+		if value.Kind == yaml.SequenceNode {
+			values = value.Content
+		}
+	}
+	valid, ok := enumValues[typ]
+	if !ok {
+		return nil
+	}
+	for _, v := range values {
+		if v.Kind != yaml.ScalarNode || !contains(valid, v.Value) {
+			return domain.NewValidationError(
+				domain.ErrInvalidAnswersValue,
+				"Invalid value",
+				fmt.Sprintf("%q is not a valid %s; valid values: %s", v.Value, typ.Name(), strings.Join(valid, ", ")),
+			).WithField(path)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// configNode returns the answers as a YAML mapping
+func configNode(config *domain.SafeProjectConfig) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := doc.Encode(config); err != nil {
+		return nil, domain.NewSystemError(domain.ErrFileWriteFailed, "Failed to encode wizard answers", err.Error(), err)
+	}
+	return &doc, nil
+}
+
+func invalidPath(path, details string) *domain.DomainError {
+	return domain.NewValidationError(domain.ErrInvalidAnswersPath, "Invalid answers path", details).WithField(path)
+}
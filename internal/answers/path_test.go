@@ -0,0 +1,141 @@
+package answers
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func TestGet(t *testing.T) {
+	config := newConfig(t)
+	config.BuildTags = []domain.BuildTag{{Name: "netgo"}}
+
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{"sbom", "false"},
+		{"platforms[1]", "darwin"},
+		{"build_tags[0].name", "netgo"},
+		{"project_description", "null"},
+	}
+	for _, tt := range tests {
+		node, err := Get(config, tt.path)
+		if err != nil {
+			t.Errorf("Get(%s) error = %v", tt.path, err)
+			continue
+		}
+		if node.Value != tt.expected {
+			t.Errorf("Get(%s) = %q, want %q", tt.path, node.Value, tt.expected)
+		}
+	}
+
+	for _, path := range []string{"platform", "platforms[9]", "sbom[0]", "sbom.enabled", "build_tags[x]", ".sbom"} {
+		if _, err := Get(config, path); !domain.IsErrorCode(err, domain.ErrInvalidAnswersPath) {
+			t.Errorf("Get(%s) error = %v", path, err)
+		}
+	}
+
+	// An empty list is left out of the file but still has no elements
+	config.BuildTags = nil
+	if _, err := Get(config, "build_tags[0].name"); !domain.IsErrorCode(err, domain.ErrInvalidAnswersPath) {
+		t.Errorf("Get(build_tags[0].name) on an empty list error = %v", err)
+	}
+}
+
+func TestSetField(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+
+	tests := []struct {
+		path    string
+		value   string
+		changed []string
+	}{
+		{"sbom", "true", []string{"sbom"}},
+		{"platforms", "[linux, darwin]", []string{"platforms[2]"}},
+		{"platforms[0]", "freebsd", []string{"platforms[0]"}},
+		{"docker_registry", "quay.io", []string{"docker_registry"}},
+		{"build_tags[0]", "{name: netgo}", []string{"build_tags[0]"}},
+		{"build_tags[0].name", "netgo", []string{"build_tags[0]"}},
+		{"platforms[3]", "freebsd", []string{"platforms[3]"}},
+		{"project_description", "A tool: with a colon", []string{"project_description"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			config := newConfig(t)
+			updated, err := store.SetField(ctx, config, tt.path, tt.value)
+			if err != nil {
+				t.Fatalf("SetField() error = %v", err)
+			}
+			var paths []string
+			for _, change := range config.Diff(updated) {
+				if change.Path != "state" {
+					paths = append(paths, change.Path)
+				}
+			}
+			if !reflect.DeepEqual(paths, tt.changed) {
+				t.Errorf("changed = %v, want %v", paths, tt.changed)
+			}
+			if updated.State != domain.ConfigStateValid {
+				t.Errorf("state = %s, want valid", updated.State)
+			}
+		})
+	}
+}
+
+func TestSetFieldRejects(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	config := newConfig(t)
+
+	tests := []struct {
+		path  string
+		value string
+		code  domain.ErrorCode
+	}{
+		{"platforms", "[linux, haiku]", domain.ErrInvalidAnswersValue},
+		{"docker_registry", "example.com", domain.ErrInvalidAnswersValue},
+		{"sbom", "maybe", domain.ErrInvalidAnswersPath},
+		{"platforms[5]", "linux", domain.ErrInvalidAnswersPath},
+		{"build_tags[1].name", "netgo", domain.ErrInvalidAnswersPath},
+		{"unknown", "1", domain.ErrInvalidAnswersPath},
+		{"binary_name", "my;tool", domain.ErrInvalidBinaryName},
+	}
+	for _, tt := range tests {
+		if _, err := store.SetField(ctx, config, tt.path, tt.value); !domain.IsErrorCode(err, tt.code) {
+			t.Errorf("SetField(%s, %s) error = %v, want %s", tt.path, tt.value, err, tt.code)
+		}
+	}
+
+	_, err := store.SetField(ctx, config, "signing_level", "maximum")
+	var domainErr *domain.DomainError
+	if !errors.As(err, &domainErr) || !strings.Contains(domainErr.Details, "valid values: none, basic") {
+		t.Errorf("SetField(signing_level, maximum) error = %v, want the valid values", err)
+	}
+}
+
+func TestUnsetField(t *testing.T) {
+	ctx := context.Background()
+	store := New()
+	config := newConfig(t)
+	config.ProjectDescription = "A tool"
+
+	updated, err := store.UnsetField(ctx, config, "project_description")
+	if err != nil || updated.ProjectDescription != "" {
+		t.Errorf("UnsetField(project_description) = %q, %v", updated.ProjectDescription, err)
+	}
+
+	updated, err = store.UnsetField(ctx, config, "platforms[1]")
+	if err != nil || !reflect.DeepEqual(updated.Platforms, []domain.Platform{domain.PlatformLinux, domain.PlatformWindows}) {
+		t.Errorf("UnsetField(platforms[1]) = %v, %v", updated.Platforms, err)
+	}
+
+	if _, err := store.UnsetField(ctx, config, "binary_name"); err == nil {
+		t.Error("UnsetField(binary_name) accepted answers without a binary")
+	}
+}
@@ -0,0 +1,75 @@
+package answers
+
+import "github.com/LarsArtmann/template-GoReleaser/internal/domain"
+
+// apply copies the fields set in updates onto config. The legacy booleans
+// only change their typed field when they flip it on or off, so enabling
+// signing keeps an advanced signing level.
+func apply(config *domain.SafeProjectConfig, updates *domain.ConfigUpdate) {
+	if updates.ProjectName != nil {
+		config.ProjectName = *updates.ProjectName
+	}
+	if updates.ProjectDescription != nil {
+		config.ProjectDescription = *updates.ProjectDescription
+	}
+	if updates.ProjectType != nil {
+		config.ProjectType = *updates.ProjectType
+	}
+	if updates.BinaryName != nil {
+		config.BinaryName = *updates.BinaryName
+	}
+	if updates.MainPath != nil {
+		config.MainPath = *updates.MainPath
+	}
+	if updates.Platforms != nil {
+		config.Platforms = append([]domain.Platform(nil), updates.Platforms...)
+	}
+	if updates.Architectures != nil {
+		config.Architectures = append([]domain.Architecture(nil), updates.Architectures...)
+	}
+	if updates.ArchitectureVariants != nil {
+		config.ArchitectureVariants = append([]domain.ArchitectureVariant(nil), updates.ArchitectureVariants...)
+	}
+	if updates.CGOEnabled != nil && *updates.CGOEnabled != config.GetCGOEnabled() {
+		config.SetCGOEnabled(*updates.CGOEnabled)
+	}
+	if updates.BuildTags != nil {
+		config.BuildTags = append([]domain.BuildTag(nil), updates.BuildTags...)
+	}
+	if updates.LDFlags != nil {
+		config.LDFlags = *updates.LDFlags
+	}
+	if updates.GitProvider != nil {
+		config.GitProvider = *updates.GitProvider
+	}
+	if updates.DockerEnabled != nil && *updates.DockerEnabled != config.GetDockerEnabled() {
+		config.SetDockerEnabled(*updates.DockerEnabled)
+	}
+	if updates.DockerRegistry != nil {
+		config.DockerRegistry = *updates.DockerRegistry
+	}
+	if updates.DockerImage != nil {
+		config.DockerImage = *updates.DockerImage
+	}
+	if updates.Signing != nil && *updates.Signing != config.GetSigning() {
+		config.SetSigning(*updates.Signing)
+	}
+	if updates.Homebrew != nil {
+		config.Homebrew = *updates.Homebrew
+	}
+	if updates.Snap != nil {
+		config.Snap = *updates.Snap
+	}
+	if updates.SBOM != nil {
+		config.SBOM = *updates.SBOM
+	}
+	if updates.GenerateActions != nil && *updates.GenerateActions != config.GetGenerateActions() {
+		config.SetGenerateActions(*updates.GenerateActions)
+	}
+	if updates.ActionsOn != nil {
+		config.ActionsOn = append([]domain.ActionTrigger(nil), updates.ActionsOn...)
+	}
+	if updates.ProVersion != nil && *updates.ProVersion != config.GetProVersion() {
+		config.SetProVersion(*updates.ProVersion)
+	}
+}
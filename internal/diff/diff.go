@@ -0,0 +1,194 @@
+// Package diff computes line-based and semantic differences between text files
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OpKind is the kind of a single line edit
+type OpKind int
+
+const (
+	OpEqual OpKind = iota
+	OpDelete
+	OpInsert
+)
+
+// Edit is one line of an edit script. Line keeps its trailing newline, if any.
+type Edit struct {
+	Kind OpKind
+	Line string
+}
+
+// SplitLines splits text into lines that keep their trailing newline.
+// A final line without newline is kept as-is so it compares unequal to a terminated one.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Lines returns the shortest edit script turning a into b
+func Lines(a, b []string) []Edit {
+	// Strip the common prefix and suffix; generated files rarely differ much
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]Edit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, Edit{Kind: OpEqual, Line: line})
+	}
+	edits = append(edits, lcs(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, Edit{Kind: OpEqual, Line: line})
+	}
+	return edits
+}
+
+// lcs builds an edit script from a longest common subsequence table
+func lcs(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]Edit, 0, n+m)
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, Edit{Kind: OpEqual, Line: a[i]})
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			edits = append(edits, Edit{Kind: OpDelete, Line: a[i]})
+			i++
+		default:
+			edits = append(edits, Edit{Kind: OpInsert, Line: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		edits = append(edits, Edit{Kind: OpDelete, Line: a[i]})
+	}
+	for ; j < m; j++ {
+		edits = append(edits, Edit{Kind: OpInsert, Line: b[j]})
+	}
+	return edits
+}
+
+// Unified renders a unified diff of oldText and newText with the given number of context lines.
+// It returns an empty string when the texts are equal.
+func Unified(oldName, newName, oldText, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+
+	edits := Lines(SplitLines(oldText), SplitLines(newText))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, h := range hunks(edits, context) {
+		writeHunk(&b, edits, h)
+	}
+	return b.String()
+}
+
+// hunk is a half-open range of edit indexes
+type hunk struct {
+	start, end int
+}
+
+// hunks groups changed edits that are at most 2*context equal lines apart
+func hunks(edits []Edit, context int) []hunk {
+	var result []hunk
+	for i := 0; i < len(edits); i++ {
+		if edits[i].Kind == OpEqual {
+			continue
+		}
+
+		start := max(0, i-context)
+		last := i
+		for j := i + 1; j < len(edits); j++ {
+			if edits[j].Kind != OpEqual {
+				if j-last-1 > 2*context {
+					break
+				}
+				last = j
+			}
+		}
+		end := min(len(edits), last+context+1)
+
+		result = append(result, hunk{start: start, end: end})
+		i = last
+	}
+	return result
+}
+
+func writeHunk(b *strings.Builder, edits []Edit, h hunk) {
+	oldStart, newStart := 1, 1
+	for _, e := range edits[:h.start] {
+		if e.Kind != OpInsert {
+			oldStart++
+		}
+		if e.Kind != OpDelete {
+			newStart++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, e := range edits[h.start:h.end] {
+		if e.Kind != OpInsert {
+			oldCount++
+		}
+		if e.Kind != OpDelete {
+			newCount++
+		}
+	}
+
+	// An empty range is addressed by the line before it
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+
+	fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, e := range edits[h.start:h.end] {
+		prefix := " "
+		switch e.Kind {
+		case OpDelete:
+			prefix = "-"
+		case OpInsert:
+			prefix = "+"
+		}
+		b.WriteString(prefix)
+		b.WriteString(e.Line)
+		if !strings.HasSuffix(e.Line, "\n") {
+			b.WriteString("\n\\ No newline at end of file\n")
+		}
+	}
+}
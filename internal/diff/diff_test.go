@@ -0,0 +1,142 @@
+package diff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldText  string
+		newText  string
+		context  int
+		expected string
+	}{
+		{
+			name:     "equal",
+			oldText:  "a\nb\n",
+			newText:  "a\nb\n",
+			context:  3,
+			expected: "",
+		},
+		{
+			name:    "single_change",
+			oldText: "a\nb\nc\n",
+			newText: "a\nB\nc\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name:    "insert_into_empty",
+			oldText: "",
+			newText: "a\n",
+			context: 3,
+			expected: "--- old\n+++ new\n" +
+				"@@ -0,0 +1,1 @@\n+a\n",
+		},
+		{
+			name:    "separate_hunks",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n",
+			newText: "x\n2\n3\n4\n5\n6\n7\ny\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,2 @@\n-1\n+x\n 2\n" +
+				"@@ -7,2 +7,2 @@\n 7\n-8\n+y\n",
+		},
+		{
+			name:    "missing_trailing_newline",
+			oldText: "a",
+			newText: "a\n",
+			context: 3,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,1 +1,1 @@\n-a\n\\ No newline at end of file\n+a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("old", "new", tt.oldText, tt.newText, tt.context)
+			if got != tt.expected {
+				t.Errorf("Unified() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLinesRoundTrip(t *testing.T) {
+	a := SplitLines("one\ntwo\nthree\nfour\n")
+	b := SplitLines("zero\none\nthree\nfive\n")
+
+	var oldLines, newLines []string
+	for _, e := range Lines(a, b) {
+		if e.Kind != OpInsert {
+			oldLines = append(oldLines, e.Line)
+		}
+		if e.Kind != OpDelete {
+			newLines = append(newLines, e.Line)
+		}
+	}
+
+	if strings.Join(oldLines, "") != strings.Join(a, "") {
+		t.Errorf("edit script does not reproduce old text: %q", oldLines)
+	}
+	if strings.Join(newLines, "") != strings.Join(b, "") {
+		t.Errorf("edit script does not reproduce new text: %q", newLines)
+	}
+}
+
+func TestYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldYAML  string
+		newYAML  string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "formatting_and_order_ignored",
+			oldYAML:  "a: 1\nb: [x, y] # comment\n",
+			newYAML:  "b:\n  - x\n  - y\na: 1\n",
+			expected: nil,
+		},
+		{
+			name:    "nested_changes",
+			oldYAML: "builds:\n  - goos: [linux]\n    main: .\nrelease: {draft: true}\n",
+			newYAML: "builds:\n  - goos: [linux, darwin]\n    main: ./cmd/app\nchangelog: {sort: asc}\n",
+			expected: []string{
+				"+ builds[0].goos[1]: \"darwin\"",
+				"~ builds[0].main: \".\" -> \"./cmd/app\"",
+				"+ changelog: {\"sort\":\"asc\"}",
+				"- release: {\"draft\":true}",
+			},
+		},
+		{
+			name:    "invalid_yaml",
+			oldYAML: "a: [",
+			newYAML: "a: 1",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes, err := YAML([]byte(tt.oldYAML), []byte(tt.newYAML))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("YAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var got []string
+			for _, change := range changes {
+				got = append(got, change.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("YAML() =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// ChangeKind classifies a semantic change
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change is a single semantic difference between two YAML documents
+type Change struct {
+	Kind ChangeKind
+	Path string // dotted path, e.g. builds[0].goos
+	Old  any
+	New  any
+}
+
+// String renders the change as a single line
+func (c Change) String() string {
+	switch c.Kind {
+	case ChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatValue(c.New))
+	case ChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path, formatValue(c.Old))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatValue(c.Old), formatValue(c.New))
+	}
+}
+
+// YAML compares two YAML documents by value, ignoring formatting, comments and key order
+func YAML(oldData, newData []byte) ([]Change, error) {
+	var oldValue, newValue any
+	if err := yaml.Unmarshal(oldData, &oldValue); err != nil {
+		return nil, invalidYAMLError("old", err)
+	}
+	if err := yaml.Unmarshal(newData, &newValue); err != nil {
+		return nil, invalidYAMLError("new", err)
+	}
+
+	changes := []Change{}
+	compareValues("", oldValue, newValue, &changes)
+	return changes, nil
+}
+
+func compareValues(path string, oldValue, newValue any, changes *[]Change) {
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if oldIsMap && newIsMap {
+		for _, key := range unionKeys(oldMap, newMap) {
+			childPath := joinKey(path, key)
+			oldChild, inOld := oldMap[key]
+			newChild, inNew := newMap[key]
+			switch {
+			case !inNew:
+				*changes = append(*changes, Change{Kind: ChangeRemoved, Path: childPath, Old: oldChild})
+			case !inOld:
+				*changes = append(*changes, Change{Kind: ChangeAdded, Path: childPath, New: newChild})
+			default:
+				compareValues(childPath, oldChild, newChild, changes)
+			}
+		}
+		return
+	}
+
+	oldList, oldIsList := oldValue.([]any)
+	newList, newIsList := newValue.([]any)
+	if oldIsList && newIsList {
+		for i := 0; i < max(len(oldList), len(newList)); i++ {
+			childPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(newList):
+				*changes = append(*changes, Change{Kind: ChangeRemoved, Path: childPath, Old: oldList[i]})
+			case i >= len(oldList):
+				*changes = append(*changes, Change{Kind: ChangeAdded, Path: childPath, New: newList[i]})
+			default:
+				compareValues(childPath, oldList[i], newList[i], changes)
+			}
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(oldValue, newValue) {
+		if path == "" {
+			path = "."
+		}
+		*changes = append(*changes, Change{Kind: ChangeModified, Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// formatValue renders scalars verbatim and collections as compact JSON
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	case nil:
+		return "null"
+	case string:
+		return strconv.Quote(v)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func invalidYAMLError(side string, err error) *domain.DomainError {
+	return domain.NewTemplateError(
+		domain.ErrTemplateSyntaxError,
+		"Invalid YAML",
+		fmt.Sprintf("cannot parse %s document: %v", side, err),
+	).WithCause(err)
+}
@@ -0,0 +1,174 @@
+// Package discovery finds the GoReleaser configurations and GitHub Actions
+// workflows of a project, including per-module configurations in monorepos
+package discovery
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// ConfigNames are the file names GoReleaser looks for, in its order of preference
+var ConfigNames = []string{".goreleaser.yml", ".goreleaser.yaml", "goreleaser.yml", "goreleaser.yaml"}
+
+// WorkflowDir is the GitHub Actions workflow directory, relative to the root
+const WorkflowDir = ".github/workflows"
+
+// skipDirs are never searched for configurations
+var skipDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+	"dist":         true,
+}
+
+// Workflow is a GitHub Actions workflow file
+type Workflow struct {
+	Path    string // slash-separated, relative to the root
+	Release bool   // the workflow runs GoReleaser
+}
+
+// Configs returns every GoReleaser configuration below root as sorted,
+// slash-separated paths relative to root. Hidden directories, vendor,
+// node_modules, testdata and dist are skipped.
+func Configs(root string) ([]string, error) {
+	names := make(map[string]bool, len(ConfigNames))
+	for _, name := range ConfigNames {
+		names[name] = true
+	}
+
+	var configs []string
+	err := filepath.WalkDir(root, func(current string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if current != root && (strings.HasPrefix(entry.Name(), ".") || skipDirs[entry.Name()]) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if names[entry.Name()] {
+			rel, err := filepath.Rel(root, current)
+			if err != nil {
+				return err
+			}
+			configs = append(configs, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, discoveryError(root, err)
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		// Shallow configs first, so the root configuration leads
+		di, dj := strings.Count(configs[i], "/"), strings.Count(configs[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return configs[i] < configs[j]
+	})
+	return configs, nil
+}
+
+// Workflows returns every workflow in .github/workflows below root
+func Workflows(root string) ([]Workflow, error) {
+	var workflows []Workflow
+	for _, pattern := range []string{"*.yml", "*.yaml"} {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(WorkflowDir), pattern))
+		if err != nil {
+			return nil, discoveryError(root, err)
+		}
+		for _, match := range matches {
+			data, err := os.ReadFile(match)
+			if err != nil {
+				return nil, discoveryError(match, err)
+			}
+			workflows = append(workflows, Workflow{
+				Path:    path.Join(WorkflowDir, filepath.Base(match)),
+				Release: bytes.Contains(bytes.ToLower(data), []byte("goreleaser")),
+			})
+		}
+	}
+
+	sort.Slice(workflows, func(i, j int) bool {
+		return workflows[i].Path < workflows[j].Path
+	})
+	return workflows, nil
+}
+
+// ModuleDir returns the nearest directory at or above dir, but not above
+// root, that contains a go.mod file, or "" when there is none
+func ModuleDir(root, dir string) string {
+	root, dir = filepath.Clean(root), filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir
+		}
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+			return ""
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// GitRoot returns the nearest directory at or above dir that holds a .git
+// directory or file, in the same relative or absolute form as dir, or dir
+// itself outside a git repository. Workflows live there even when a monorepo
+// module is validated on its own.
+func GitRoot(dir string) string {
+	candidate := filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(filepath.Join(candidate, ".git")); err == nil {
+			return candidate
+		}
+		parent := filepath.Join(candidate, "..")
+		current, errCurrent := filepath.Abs(candidate)
+		above, errAbove := filepath.Abs(parent)
+		if errCurrent != nil || errAbove != nil || current == above {
+			return filepath.Clean(dir)
+		}
+		candidate = parent
+	}
+}
+
+// ModuleDirs returns the module directory of every configuration, given as
+// paths below root, falling back to the configuration's own directory when
+// no go.mod governs it. Root is the only module when there are none.
+func ModuleDirs(root string, configs []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, config := range configs {
+		dir := filepath.Dir(config)
+		if module := ModuleDir(root, dir); module != "" {
+			dir = module
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return []string{filepath.Clean(root)}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+func discoveryError(path string, err error) *domain.DomainError {
+	return domain.NewSystemError(
+		domain.ErrFileReadFailed,
+		"Failed to search the project",
+		fmt.Sprintf("Cannot read %s", path),
+		err,
+	).WithContext(path)
+}
@@ -0,0 +1,135 @@
+package discovery
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigs(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{
+		".goreleaser.yaml",
+		"services/api/.goreleaser.yml",
+		"tools/goreleaser.yaml",
+		"vendor/example.com/lib/.goreleaser.yaml",
+		".github/goreleaser.yaml",
+		"services/api/testdata/.goreleaser.yaml",
+		"services/api/config.yaml",
+	} {
+		writeFile(t, root, path, "version: 2\n")
+	}
+
+	configs, err := Configs(root)
+	if err != nil {
+		t.Fatalf("Configs() error = %v", err)
+	}
+	expected := []string{".goreleaser.yaml", "tools/goreleaser.yaml", "services/api/.goreleaser.yml"}
+	if strings.Join(configs, ",") != strings.Join(expected, ",") {
+		t.Errorf("Configs() = %v, expected %v", configs, expected)
+	}
+}
+
+func TestWorkflows(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".github/workflows/release.yaml", "jobs:\n  r:\n    steps:\n      - uses: goreleaser/goreleaser-action@v6\n")
+	writeFile(t, root, ".github/workflows/ci.yml", "jobs:\n  test:\n    steps:\n      - run: go test ./...\n")
+	writeFile(t, root, ".github/workflows/README.md", "goreleaser\n")
+
+	workflows, err := Workflows(root)
+	if err != nil {
+		t.Fatalf("Workflows() error = %v", err)
+	}
+	if len(workflows) != 2 {
+		t.Fatalf("Workflows() = %v", workflows)
+	}
+	if workflows[0].Path != ".github/workflows/ci.yml" || workflows[0].Release {
+		t.Errorf("workflows[0] = %+v", workflows[0])
+	}
+	if workflows[1].Path != ".github/workflows/release.yaml" || !workflows[1].Release {
+		t.Errorf("workflows[1] = %+v", workflows[1])
+	}
+
+	if workflows, err := Workflows(t.TempDir()); err != nil || len(workflows) != 0 {
+		t.Errorf("Workflows() without workflows = %v, %v", workflows, err)
+	}
+}
+
+func TestModuleDir(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/root\n")
+	writeFile(t, root, "services/api/go.mod", "module example.com/api\n")
+
+	tests := map[string]string{
+		"services/api/cmd": "services/api",
+		"services/api":     "services/api",
+		"tools":            ".",
+	}
+	for dir, expected := range tests {
+		got := ModuleDir(root, filepath.Join(root, dir))
+		if got != filepath.Join(root, expected) {
+			t.Errorf("ModuleDir(%s) = %s, expected %s", dir, got, expected)
+		}
+	}
+
+	if got := ModuleDir(filepath.Join(root, "tools"), filepath.Join(root, "tools")); got != "" {
+		t.Errorf("ModuleDir() above root = %s", got)
+	}
+}
+
+func TestGitRoot(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, ".git/HEAD", "ref: refs/heads/main\n")
+	writeFile(t, root, "services/api/go.mod", "module example.com/api\n")
+
+	if got := GitRoot(filepath.Join(root, "services", "api")); got != root {
+		t.Errorf("GitRoot() = %s, expected %s", got, root)
+	}
+
+	// Relative paths stay relative
+	original, _ := os.Getwd()
+	if err := os.Chdir(filepath.Join(root, "services")); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(original)
+	if got := GitRoot("api"); got != ".." {
+		t.Errorf("GitRoot(api) = %s, expected ..", got)
+	}
+
+	outside := t.TempDir()
+	if got := GitRoot(outside); got != outside {
+		t.Errorf("GitRoot() outside a repository = %s, expected %s", got, outside)
+	}
+}
+
+func TestModuleDirs(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "go.mod", "module example.com/root\n")
+	writeFile(t, root, "services/api/go.mod", "module example.com/api\n")
+
+	configs := []string{
+		filepath.Join(root, ".goreleaser.yaml"),
+		filepath.Join(root, "services", "api", ".goreleaser.yaml"),
+		filepath.Join(root, "services", "api", "cmd", "tool", ".goreleaser.yaml"),
+	}
+	expected := []string{root, filepath.Join(root, "services", "api")}
+	if got := ModuleDirs(root, configs); strings.Join(got, ",") != strings.Join(expected, ",") {
+		t.Errorf("ModuleDirs() = %v, expected %v", got, expected)
+	}
+
+	if got := ModuleDirs(root, nil); len(got) != 1 || got[0] != root {
+		t.Errorf("ModuleDirs() without configurations = %v", got)
+	}
+}
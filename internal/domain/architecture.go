@@ -4,131 +4,103 @@ import (
 	"fmt"
 )
 
-// Architecture represents supported CPU architectures
-// Generated from TypeSpec specification - DO NOT MODIFY MANUALLY
+// Architecture represents supported CPU architectures. The values follow the
+// Architecture enum of typespec/goreleaser-wizard.tsp; platform support comes
+// from the toolchain matrix in platforms.json.
 type Architecture string
 
 const (
-	ArchitectureAMD64   Architecture = "amd64"   // 64-bit x86
-	ArchitectureARM64   Architecture = "arm64"   // 64-bit ARM
-	Architecture386     Architecture = "386"     // 32-bit x86
-	ArchitectureARM     Architecture = "arm"     // 32-bit ARM
-	ArchitecturePPC64   Architecture = "ppc64"   // 64-bit PowerPC (big endian)
-	ArchitecturePPC64LE Architecture = "ppc64le" // 64-bit PowerPC (little endian)
-	ArchitectureS390X   Architecture = "s390x"   // IBM System z
-	ArchitectureMIPS    Architecture = "mips"    // 32-bit MIPS (big endian)
-	ArchitectureMIPSLE  Architecture = "mipsle"  // 32-bit MIPS (little endian)
+	ArchitectureAMD64    Architecture = "amd64"    // 64-bit x86
+	ArchitectureARM64    Architecture = "arm64"    // 64-bit ARM
+	Architecture386      Architecture = "386"      // 32-bit x86
+	ArchitectureARM      Architecture = "arm"      // 32-bit ARM
+	ArchitecturePPC64    Architecture = "ppc64"    // 64-bit PowerPC (big endian)
+	ArchitecturePPC64LE  Architecture = "ppc64le"  // 64-bit PowerPC (little endian)
+	ArchitectureS390X    Architecture = "s390x"    // IBM System z
+	ArchitectureMIPS     Architecture = "mips"     // 32-bit MIPS (big endian)
+	ArchitectureMIPSLE   Architecture = "mipsle"   // 32-bit MIPS (little endian)
+	ArchitectureMIPS64   Architecture = "mips64"   // 64-bit MIPS (big endian)
+	ArchitectureMIPS64LE Architecture = "mips64le" // 64-bit MIPS (little endian)
+	ArchitectureRISCV64  Architecture = "riscv64"  // 64-bit RISC-V
+	ArchitectureLoong64  Architecture = "loong64"  // 64-bit LoongArch
+	ArchitectureWASM     Architecture = "wasm"     // WebAssembly
 )
 
-// Architecture metadata - generated from TypeSpec invariants
-type architectureMeta struct {
-	supportedByAllPlatforms bool
-	is64Bit               bool
-	goSupport             string
+// architectureNames are the display names of architectures
+var architectureNames = map[Architecture]string{
+	ArchitectureAMD64:    "64-bit x86",
+	ArchitectureARM64:    "64-bit ARM",
+	Architecture386:      "32-bit x86",
+	ArchitectureARM:      "32-bit ARM",
+	ArchitecturePPC64:    "64-bit PowerPC (big endian)",
+	ArchitecturePPC64LE:  "64-bit PowerPC (little endian)",
+	ArchitectureS390X:    "IBM System z",
+	ArchitectureMIPS:     "32-bit MIPS (big endian)",
+	ArchitectureMIPSLE:   "32-bit MIPS (little endian)",
+	ArchitectureMIPS64:   "64-bit MIPS (big endian)",
+	ArchitectureMIPS64LE: "64-bit MIPS (little endian)",
+	ArchitectureRISCV64:  "64-bit RISC-V",
+	ArchitectureLoong64:  "64-bit LoongArch",
+	ArchitectureWASM:     "WebAssembly",
 }
 
-var architectureMetaMap = map[Architecture]architectureMeta{
-	ArchitectureAMD64: {
-		supportedByAllPlatforms: true,
-		is64Bit:               true,
-		goSupport:             "stable",
-	},
-	ArchitectureARM64: {
-		supportedByAllPlatforms: true,
-		is64Bit:               true,
-		goSupport:             "stable",
-	},
-	Architecture386: {
-		supportedByAllPlatforms: true,
-		is64Bit:               false,
-		goSupport:             "stable",
-	},
-	ArchitectureARM: {
-		supportedByAllPlatforms: true,
-		is64Bit:               false,
-		goSupport:             "stable",
-	},
-	ArchitecturePPC64: {
-		supportedByAllPlatforms: false,
-		is64Bit:               true,
-		goSupport:             "stable",
-	},
-	ArchitecturePPC64LE: {
-		supportedByAllPlatforms: false,
-		is64Bit:               true,
-		goSupport:             "stable",
-	},
-	ArchitectureS390X: {
-		supportedByAllPlatforms: false,
-		is64Bit:               true,
-		goSupport:             "stable",
-	},
-	ArchitectureMIPS: {
-		supportedByAllPlatforms: false,
-		is64Bit:               false,
-		goSupport:             "stable",
-	},
-	ArchitectureMIPSLE: {
-		supportedByAllPlatforms: false,
-		is64Bit:               false,
-		goSupport:             "stable",
-	},
+// architectures32Bit have 32-bit pointers; wasm counts as 64-bit, like GOARCH
+var architectures32Bit = map[Architecture]bool{
+	Architecture386:    true,
+	ArchitectureARM:    true,
+	ArchitectureMIPS:   true,
+	ArchitectureMIPSLE: true,
 }
 
-// IsValid returns true if Architecture is valid
+// IsValid returns true if the Go toolchain can build for the architecture
 func (a Architecture) IsValid() bool {
-	_, exists := architectureMetaMap[a]
-	return exists
+	for _, arch := range knownArchitectures {
+		if arch == a {
+			return true
+		}
+	}
+	return false
 }
 
 // String returns human-readable display name
 func (a Architecture) String() string {
-	switch a {
-	case ArchitectureAMD64:
-		return "64-bit x86"
-	case ArchitectureARM64:
-		return "64-bit ARM"
-	case Architecture386:
-		return "32-bit x86"
-	case ArchitectureARM:
-		return "32-bit ARM"
-	case ArchitecturePPC64:
-		return "64-bit PowerPC (big endian)"
-	case ArchitecturePPC64LE:
-		return "64-bit PowerPC (little endian)"
-	case ArchitectureS390X:
-		return "IBM System z"
-	case ArchitectureMIPS:
-		return "32-bit MIPS (big endian)"
-	case ArchitectureMIPSLE:
-		return "32-bit MIPS (little endian)"
-	default:
-		return string(a)
+	if name, exists := architectureNames[a]; exists {
+		return name
 	}
+	return string(a)
 }
 
-// SupportedByAllPlatforms returns true if architecture is supported by all platforms
+// SupportedByAllPlatforms returns true if every first-class platform
+// (Linux, macOS and Windows) can be built for the architecture
 func (a Architecture) SupportedByAllPlatforms() bool {
-	if meta, exists := architectureMetaMap[a]; exists {
-		return meta.supportedByAllPlatforms
+	if !a.IsValid() {
+		return false
 	}
-	return false
+	for _, platform := range knownPlatforms {
+		if platform.IsFirstClass() && !platform.SupportsArchitecture(a) {
+			return false
+		}
+	}
+	return true
 }
 
 // Is64Bit returns true if architecture is 64-bit
 func (a Architecture) Is64Bit() bool {
-	if meta, exists := architectureMetaMap[a]; exists {
-		return meta.is64Bit
-	}
-	return false
+	return a.IsValid() && !architectures32Bit[a]
 }
 
-// GoSupport returns Go support level for this architecture
+// GoSupport returns Go support level for this architecture: "first-class"
+// when a first-class target uses it, otherwise "secondary"
 func (a Architecture) GoSupport() string {
-	if meta, exists := architectureMetaMap[a]; exists {
-		return meta.goSupport
+	if !a.IsValid() {
+		return "unknown"
+	}
+	for _, target := range knownTargets {
+		if target.Architecture == a && target.FirstClass {
+			return "first-class"
+		}
 	}
-	return "unknown"
+	return "secondary"
 }
 
 // ValidateArchitectures validates a slice of architectures
@@ -151,11 +123,7 @@ func GetRecommendedArchitectures() []Architecture {
 	return []Architecture{ArchitectureAMD64, ArchitectureARM64}
 }
 
-// GetAllArchitectures returns all supported architectures
+// GetAllArchitectures returns every architecture of the Go toolchain, first-class ones first
 func GetAllArchitectures() []Architecture {
-	return []Architecture{
-		ArchitectureAMD64, ArchitectureARM64, Architecture386, ArchitectureARM,
-		ArchitecturePPC64, ArchitecturePPC64LE, ArchitectureS390X,
-		ArchitectureMIPS, ArchitectureMIPSLE,
-	}
-}
\ No newline at end of file
+	return append([]Architecture(nil), knownArchitectures...)
+}
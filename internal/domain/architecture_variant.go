@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// VariantOption describes how the Go toolchain selects the micro-architecture
+// of an architecture, e.g. GOARM for 32-bit ARM
+type VariantOption struct {
+	// EnvVar is the Go environment variable, e.g. GOARM
+	EnvVar string
+	// BuildKey is the GoReleaser build key, e.g. goarm
+	BuildKey string
+	// Values are the accepted values, oldest hardware first
+	Values []string
+	// Default is the value the toolchain uses when EnvVar is unset
+	Default string
+}
+
+// variantOptions lists the architectures that have micro-architecture variants
+var variantOptions = map[Architecture]VariantOption{
+	ArchitectureAMD64:    {EnvVar: "GOAMD64", BuildKey: "goamd64", Values: []string{"v1", "v2", "v3", "v4"}, Default: "v1"},
+	ArchitectureARM:      {EnvVar: "GOARM", BuildKey: "goarm", Values: []string{"5", "6", "7"}, Default: "7"},
+	ArchitectureARM64:    {EnvVar: "GOARM64", BuildKey: "goarm64", Values: arm64Versions(), Default: "v8.0"},
+	Architecture386:      {EnvVar: "GO386", BuildKey: "go386", Values: []string{"sse2", "softfloat"}, Default: "sse2"},
+	ArchitectureMIPS:     mipsOption("GOMIPS", "gomips"),
+	ArchitectureMIPSLE:   mipsOption("GOMIPS", "gomips"),
+	ArchitectureMIPS64:   mipsOption("GOMIPS64", "gomips64"),
+	ArchitectureMIPS64LE: mipsOption("GOMIPS64", "gomips64"),
+}
+
+func mipsOption(envVar, buildKey string) VariantOption {
+	return VariantOption{EnvVar: envVar, BuildKey: buildKey, Values: []string{"hardfloat", "softfloat"}, Default: "hardfloat"}
+}
+
+// arm64Versions returns the ARM architecture versions GOARM64 accepts,
+// v8.0 to v8.9 and v9.0 to v9.5
+func arm64Versions() []string {
+	var versions []string
+	for minor := 0; minor <= 9; minor++ {
+		versions = append(versions, fmt.Sprintf("v8.%d", minor))
+	}
+	for minor := 0; minor <= 5; minor++ {
+		versions = append(versions, fmt.Sprintf("v9.%d", minor))
+	}
+	return versions
+}
+
+// VariantOption returns how the micro-architecture of the architecture is
+// selected, and whether it has variants at all
+func (a Architecture) VariantOption() (VariantOption, bool) {
+	option, exists := variantOptions[a]
+	return option, exists
+}
+
+// IsValid returns true if the option accepts the value
+func (vo VariantOption) IsValid(value string) bool {
+	for _, v := range vo.Values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchitectureVariant selects the micro-architecture variants built for an
+// architecture, e.g. GOARM 6 and 7 for the Raspberry Pi
+type ArchitectureVariant struct {
+	Architecture Architecture `json:"architecture" yaml:"architecture"`
+	Variants     []string     `json:"variants" yaml:"variants"`
+}
+
+// UnmarshalJSON accepts numeric variants such as 'variants: [6, 7]' as
+// well as strings, since answers written in YAML rarely quote GOARM values
+func (av *ArchitectureVariant) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Architecture Architecture      `json:"architecture"`
+		Variants     []json.RawMessage `json:"variants"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&raw); err != nil {
+		return err
+	}
+
+	av.Architecture = raw.Architecture
+	av.Variants = nil
+	for _, value := range raw.Variants {
+		var variant string
+		if err := json.Unmarshal(value, &variant); err != nil {
+			var number json.Number
+			if err := json.Unmarshal(value, &number); err != nil {
+				return fmt.Errorf("variant %s is neither a string nor a number", value)
+			}
+			variant = number.String()
+		}
+		av.Variants = append(av.Variants, variant)
+	}
+	return nil
+}
+
+// String returns the variants as "arm: 6, 7"
+func (av ArchitectureVariant) String() string {
+	return string(av.Architecture) + ": " + strings.Join(av.Variants, ", ")
+}
+
+// GetVariantArchitectures returns the architectures that have micro-architecture variants
+func GetVariantArchitectures() []Architecture {
+	var architectures []Architecture
+	for _, arch := range knownArchitectures {
+		if _, exists := variantOptions[arch]; exists {
+			architectures = append(architectures, arch)
+		}
+	}
+	return architectures
+}
+
+// variantViolations checks that every variant belongs to a selected
+// architecture that has variants, and that architectures sharing a build key
+// select the same variants
+func (spc *SafeProjectConfig) variantViolations() ValidationErrors {
+	var violations ValidationErrors
+	selected := make(map[Architecture]bool)
+	for _, arch := range spc.Architectures {
+		selected[arch] = true
+	}
+
+	seen := make(map[Architecture]bool)
+	byKey := make(map[string]ArchitectureVariant)
+	for i, variant := range spc.ArchitectureVariants {
+		field := fmt.Sprintf("architecture_variants[%d]", i)
+		option, exists := variant.Architecture.VariantOption()
+		switch {
+		case !exists:
+			violations = append(violations, InvalidArchitectureVariantError(fmt.Sprintf("Architecture '%s' has no micro-architecture variants; variants exist for %s", variant.Architecture, joinArchitectures(GetVariantArchitectures()))).WithField(field+".architecture"))
+			continue
+		case !selected[variant.Architecture]:
+			violations = append(violations, InvalidArchitectureVariantError(fmt.Sprintf("Variants are set for %s, but %s is not among the selected architectures", variant.Architecture, variant.Architecture)).WithField(field+".architecture"))
+		case seen[variant.Architecture]:
+			violations = append(violations, InvalidArchitectureVariantError(fmt.Sprintf("Variants for %s are listed more than once", variant.Architecture)).WithField(field+".architecture"))
+		}
+		seen[variant.Architecture] = true
+
+		if len(variant.Variants) == 0 {
+			violations = append(violations, InvalidArchitectureVariantError(fmt.Sprintf("No %s variants selected for %s", option.EnvVar, variant.Architecture)).WithField(field+".variants"))
+		}
+		for j, value := range variant.Variants {
+			if !option.IsValid(value) {
+				violations = append(violations, InvalidArchitectureVariantError(fmt.Sprintf("'%s' is not a valid %s value for %s; valid values: %s", value, option.EnvVar, variant.Architecture, strings.Join(option.Values, ", "))).WithField(fmt.Sprintf("%s.variants[%d]", field, j)))
+			}
+		}
+
+		// GoReleaser applies a build key to every architecture that reads it
+		if other, exists := byKey[option.BuildKey]; exists && other.Architecture != variant.Architecture && strings.Join(other.Variants, ",") != strings.Join(variant.Variants, ",") {
+			violations = append(violations, NewBusinessRuleError(ErrInvalidArchitectureVariant, "Conflicting architecture variants", fmt.Sprintf("%s and %s share %s and must select the same variants", other.Architecture, variant.Architecture, option.EnvVar)).WithField(field+".variants"))
+		}
+		byKey[option.BuildKey] = variant
+	}
+	return violations
+}
+
+func joinArchitectures(architectures []Architecture) string {
+	values := make([]string, len(architectures))
+	for i, arch := range architectures {
+		values[i] = string(arch)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestVariantOptions(t *testing.T) {
+	option, exists := ArchitectureARM.VariantOption()
+	if !exists || option.EnvVar != "GOARM" || option.BuildKey != "goarm" || !option.IsValid("6") || option.IsValid("8") {
+		t.Errorf("arm VariantOption() = %+v, %t", option, exists)
+	}
+	option, _ = ArchitectureARM64.VariantOption()
+	if !option.IsValid("v8.0") || !option.IsValid("v9.5") || option.IsValid("v9.6") {
+		t.Errorf("arm64 variants = %v", option.Values)
+	}
+	if _, exists := ArchitectureRISCV64.VariantOption(); exists {
+		t.Error("riscv64 has no variants")
+	}
+
+	for _, arch := range GetVariantArchitectures() {
+		if option, _ := arch.VariantOption(); !option.IsValid(option.Default) {
+			t.Errorf("%s: default %q is not a valid variant", string(arch), option.Default)
+		}
+	}
+}
+
+func TestArchitectureVariantViolations(t *testing.T) {
+	tests := []struct {
+		name     string
+		variants []ArchitectureVariant
+		fields   []string
+	}{
+		{
+			name: "valid",
+			variants: []ArchitectureVariant{
+				{Architecture: ArchitectureAMD64, Variants: []string{"v1", "v3"}},
+				{Architecture: ArchitectureARM, Variants: []string{"6", "7"}},
+			},
+		},
+		{
+			name:     "invalid_value",
+			variants: []ArchitectureVariant{{Architecture: ArchitectureAMD64, Variants: []string{"v3", "v5"}}},
+			fields:   []string{"architecture_variants[0].variants[1]"},
+		},
+		{
+			name:     "architecture_not_selected",
+			variants: []ArchitectureVariant{{Architecture: ArchitectureARM64, Variants: []string{"v8.2"}}},
+			fields:   []string{"architecture_variants[0].architecture"},
+		},
+		{
+			name:     "architecture_without_variants",
+			variants: []ArchitectureVariant{{Architecture: ArchitectureRISCV64, Variants: []string{"rva22u64"}}},
+			fields:   []string{"architecture_variants[0].architecture"},
+		},
+		{
+			name: "duplicate_and_empty",
+			variants: []ArchitectureVariant{
+				{Architecture: ArchitectureARM, Variants: []string{"7"}},
+				{Architecture: ArchitectureARM},
+			},
+			fields: []string{"architecture_variants[1].architecture", "architecture_variants[1].variants"},
+		},
+		{
+			name: "shared_key_conflict",
+			variants: []ArchitectureVariant{
+				{Architecture: ArchitectureMIPS, Variants: []string{"softfloat"}},
+				{Architecture: ArchitectureMIPSLE, Variants: []string{"hardfloat"}},
+			},
+			fields: []string{"architecture_variants[1].variants"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validTestConfig()
+			config.Platforms = []Platform{PlatformLinux}
+			config.Architectures = []Architecture{ArchitectureAMD64, ArchitectureARM, ArchitectureMIPS, ArchitectureMIPSLE}
+			config.ArchitectureVariants = tt.variants
+
+			var fields []string
+			for _, violation := range config.Violations() {
+				if violation.Code != ErrInvalidArchitectureVariant {
+					t.Errorf("unexpected violation %v", violation)
+				}
+				fields = append(fields, violation.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.fields) {
+				t.Errorf("violations = %v, want %v", fields, tt.fields)
+			}
+		})
+	}
+}
+
+func TestCloneCopiesVariants(t *testing.T) {
+	config := validTestConfig()
+	config.ArchitectureVariants = []ArchitectureVariant{{Architecture: ArchitectureAMD64, Variants: []string{"v3"}}}
+
+	clone := config.Clone()
+	clone.ArchitectureVariants[0].Variants[0] = "v2"
+	if config.ArchitectureVariants[0].Variants[0] != "v3" {
+		t.Error("Clone() shares the variant list")
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldChange is one difference between two configurations. Path follows the
+// yaml tags, e.g. "platforms[1]" or "build_tags[0].name"; a missing list
+// element is nil.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+// String renders the change as "path: old → new"
+func (fc FieldChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", fc.Path, formatFieldValue(fc.Old), formatFieldValue(fc.New))
+}
+
+func formatFieldValue(value any) string {
+	if value == nil {
+		return "(none)"
+	}
+	// Enum values print as stored, not as their display names
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return fmt.Sprintf("%q", v.String())
+	}
+	return fmt.Sprintf("%+v", value)
+}
+
+// Diff returns every field that differs from other, in field order. Lists are
+// compared element by element; a nil list equals an empty one.
+func (spc *SafeProjectConfig) Diff(other *SafeProjectConfig) []FieldChange {
+	var changes []FieldChange
+	diffValues("", reflect.ValueOf(*spc), reflect.ValueOf(*other), &changes)
+	return changes
+}
+
+func diffValues(path string, old, new reflect.Value, changes *[]FieldChange) {
+	switch old.Kind() {
+	case reflect.Struct:
+		for i := 0; i < old.NumField(); i++ {
+			name := fieldName(old.Type().Field(i))
+			if name == "" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			diffValues(name, old.Field(i), new.Field(i), changes)
+		}
+	case reflect.Slice:
+		for i := 0; i < old.Len() || i < new.Len(); i++ {
+			element := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= old.Len():
+				*changes = append(*changes, FieldChange{Path: element, New: new.Index(i).Interface()})
+			case i >= new.Len():
+				*changes = append(*changes, FieldChange{Path: element, Old: old.Index(i).Interface()})
+			default:
+				diffValues(element, old.Index(i), new.Index(i), changes)
+			}
+		}
+	default:
+		if old.Interface() != new.Interface() {
+			*changes = append(*changes, FieldChange{Path: path, Old: old.Interface(), New: new.Interface()})
+		}
+	}
+}
+
+// fieldName returns the yaml name of a struct field, or "" if it is not serialized
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+	if name == "-" || !field.IsExported() {
+		return ""
+	}
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
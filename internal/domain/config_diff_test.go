@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := validTestConfig()
+	old.BuildTags = []BuildTag{{Name: "netgo"}}
+
+	new := old.Clone()
+	new.Platforms = []Platform{PlatformLinux, PlatformWindows}
+	new.CGOStatus = CGOStatusEnabled
+	new.DockerSupport = DockerSupportBoth
+	new.SigningLevel = SigningLevelBasic
+	new.BuildTags = []BuildTag{{Name: "osusergo"}, {Name: "netgo"}}
+
+	var paths []string
+	for _, change := range old.Diff(new) {
+		paths = append(paths, change.Path)
+	}
+	expected := []string{
+		"platforms[1]",
+		"platforms[2]",
+		"cgo_status",
+		"build_tags[0].name",
+		"build_tags[1]",
+		"docker_support",
+		"signing_level",
+	}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Diff() paths = %v, want %v", paths, expected)
+	}
+
+	changes := old.Diff(new)
+	if changes[1].Old != PlatformWindows || changes[1].New != nil {
+		t.Errorf("removed element = %+v", changes[1])
+	}
+	if got := changes[2].String(); got != `cgo_status: "disabled" → "enabled"` {
+		t.Errorf("String() = %s", got)
+	}
+}
+
+func TestEquals(t *testing.T) {
+	config := validTestConfig()
+	if !config.Equals(config.Clone()) {
+		t.Error("Equals() = false for a clone")
+	}
+
+	withEmptyTags := config.Clone()
+	withEmptyTags.BuildTags = []BuildTag{}
+	if !config.Equals(withEmptyTags) {
+		t.Error("Equals() distinguishes nil and empty lists")
+	}
+
+	// Fields the previous comparison ignored
+	for name, modify := range map[string]func(c *SafeProjectConfig){
+		"platforms":      func(c *SafeProjectConfig) { c.Platforms = []Platform{PlatformLinux} },
+		"cgo_status":     func(c *SafeProjectConfig) { c.CGOStatus = CGOStatusRequired },
+		"docker_support": func(c *SafeProjectConfig) { c.DockerSupport = DockerSupportPublish },
+		"signing_level":  func(c *SafeProjectConfig) { c.SigningLevel = SigningLevelEnterprise },
+	} {
+		changed := config.Clone()
+		modify(changed)
+		if config.Equals(changed) || !config.HasChanged(changed) {
+			t.Errorf("Equals() ignores %s", name)
+		}
+	}
+
+	var missing *SafeProjectConfig
+	if config.Equals(missing) || !missing.Equals(nil) {
+		t.Error("Equals() mishandles nil configurations")
+	}
+}
@@ -60,7 +60,7 @@ func (cs CGOStatus) ToBool() bool {
 func ValidateCGOStatus(status CGOStatus) error {
 	if !status.IsValid() {
 		return NewValidationError(
-			ErrInvalidCharacters,
+			ErrInvalidCGOStatus,
 			"Invalid CGO status",
 			fmt.Sprintf("'%s' is not a valid CGO status", status),
 		)
@@ -68,6 +68,11 @@ func ValidateCGOStatus(status CGOStatus) error {
 	return nil
 }
 
+// GetAllCGOStatuses returns all available CGO statuses
+func GetAllCGOStatuses() []CGOStatus {
+	return []CGOStatus{CGOStatusDisabled, CGOStatusEnabled, CGOStatusRequired}
+}
+
 // DockerSupport represents Docker support level with compile-time safety
 // Replaces bool DockerEnabled for better type safety and semantic clarity
 type DockerSupport string
@@ -133,7 +138,7 @@ func (ds DockerSupport) ToBool() bool {
 func ValidateDockerSupport(support DockerSupport) error {
 	if !support.IsValid() {
 		return NewValidationError(
-			ErrInvalidCharacters,
+			ErrInvalidDockerSupport,
 			"Invalid Docker support level",
 			fmt.Sprintf("'%s' is not a valid Docker support level", support),
 		)
@@ -141,6 +146,11 @@ func ValidateDockerSupport(support DockerSupport) error {
 	return nil
 }
 
+// GetAllDockerSupports returns all available Docker support options
+func GetAllDockerSupports() []DockerSupport {
+	return []DockerSupport{DockerSupportNone, DockerSupportBuild, DockerSupportPublish, DockerSupportBoth}
+}
+
 // SigningLevel represents code signing level with compile-time safety
 // Replaces bool Signing for better type safety and semantic clarity
 type SigningLevel string
@@ -196,7 +206,7 @@ func (sl SigningLevel) ToBool() bool {
 func ValidateSigningLevel(level SigningLevel) error {
 	if !level.IsValid() {
 		return NewValidationError(
-			ErrInvalidCharacters,
+			ErrInvalidSigningLevel,
 			"Invalid signing level",
 			fmt.Sprintf("'%s' is not a valid signing level", level),
 		)
@@ -204,6 +214,11 @@ func ValidateSigningLevel(level SigningLevel) error {
 	return nil
 }
 
+// GetAllSigningLevels returns all available signing levels
+func GetAllSigningLevels() []SigningLevel {
+	return []SigningLevel{SigningLevelNone, SigningLevelBasic, SigningLevelAdvanced, SigningLevelEnterprise}
+}
+
 // ActionLevel represents GitHub Actions generation level with compile-time safety
 // Replaces bool GenerateActions for better type safety and semantic clarity
 type ActionLevel string
@@ -255,7 +270,7 @@ func (al ActionLevel) ToBool() bool {
 func ValidateActionLevel(level ActionLevel) error {
 	if !level.IsValid() {
 		return NewValidationError(
-			ErrInvalidCharacters,
+			ErrInvalidActionLevel,
 			"Invalid action level",
 			fmt.Sprintf("'%s' is not a valid action level", level),
 		)
@@ -263,6 +278,11 @@ func ValidateActionLevel(level ActionLevel) error {
 	return nil
 }
 
+// GetAllActionLevels returns all available action levels
+func GetAllActionLevels() []ActionLevel {
+	return []ActionLevel{ActionLevelNone, ActionLevelBasic, ActionLevelAdvanced}
+}
+
 // FeatureLevel represents feature tier with compile-time safety
 // Replaces bool ProVersion for better type safety and semantic clarity
 type FeatureLevel string
@@ -314,7 +334,7 @@ func (fl FeatureLevel) ToBool() bool {
 func ValidateFeatureLevel(level FeatureLevel) error {
 	if !level.IsValid() {
 		return NewValidationError(
-			ErrInvalidCharacters,
+			ErrInvalidFeatureLevel,
 			"Invalid feature level",
 			fmt.Sprintf("'%s' is not a valid feature level", level),
 		)
@@ -322,6 +342,11 @@ func ValidateFeatureLevel(level FeatureLevel) error {
 	return nil
 }
 
+// GetAllFeatureLevels returns all available feature levels
+func GetAllFeatureLevels() []FeatureLevel {
+	return []FeatureLevel{FeatureLevelBasic, FeatureLevelProfessional, FeatureLevelEnterprise}
+}
+
 // Enum migration utilities for backward compatibility
 
 // CGOStatusFromBool converts legacy boolean to CGOStatus
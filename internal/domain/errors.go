@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // DomainError represents all domain-specific errors
@@ -12,6 +13,10 @@ type DomainError struct {
 	Message string     `json:"message"`
 	Details string     `json:"details,omitempty"`
 	Context string     `json:"context,omitempty"`
+	Field   string     `json:"field,omitempty"`
+	Line    int        `json:"line,omitempty"`
+	Column  int        `json:"column,omitempty"`
+	Rule    string     `json:"rule,omitempty"`
 	Cause   error     `json:"cause,omitempty"`
 }
 
@@ -26,11 +31,19 @@ const (
 	ErrInvalidProjectDescription ErrorCode = "INVALID_PROJECT_DESCRIPTION"
 	ErrInvalidPlatform           ErrorCode = "INVALID_PLATFORM"
 	ErrInvalidArchitecture       ErrorCode = "INVALID_ARCHITECTURE"
+	ErrInvalidArchitectureVariant ErrorCode = "INVALID_ARCHITECTURE_VARIANT"
 	ErrInvalidGitProvider       ErrorCode = "INVALID_GIT_PROVIDER"
 	ErrInvalidDockerRegistry    ErrorCode = "INVALID_DOCKER_REGISTRY"
 	ErrInvalidActionTrigger     ErrorCode = "INVALID_ACTION_TRIGGER"
 	ErrInvalidBuildTag          ErrorCode = "INVALID_BUILD_TAG"
 	ErrInvalidConfigState       ErrorCode = "INVALID_CONFIG_STATE"
+	ErrInvalidProjectType       ErrorCode = "INVALID_PROJECT_TYPE"
+	ErrInvalidCGOStatus         ErrorCode = "INVALID_CGO_STATUS"
+	ErrInvalidDockerSupport     ErrorCode = "INVALID_DOCKER_SUPPORT"
+	ErrInvalidDockerImage       ErrorCode = "INVALID_DOCKER_IMAGE"
+	ErrInvalidSigningLevel      ErrorCode = "INVALID_SIGNING_LEVEL"
+	ErrInvalidActionLevel       ErrorCode = "INVALID_ACTION_LEVEL"
+	ErrInvalidFeatureLevel      ErrorCode = "INVALID_FEATURE_LEVEL"
 
 	// Configuration Errors
 	ErrDockerNotSupported      ErrorCode = "DOCKER_NOT_SUPPORTED"
@@ -38,6 +51,7 @@ const (
 	ErrMainPathRequired        ErrorCode = "MAIN_PATH_REQUIRED"
 	ErrInvalidStateTransition   ErrorCode = "INVALID_STATE_TRANSITION"
 	ErrMissingRequiredField    ErrorCode = "MISSING_REQUIRED_FIELD"
+	ErrCGONotSupported         ErrorCode = "CGO_NOT_SUPPORTED"
 	ErrFieldTooLong           ErrorCode = "FIELD_TOO_LONG"
 	ErrFieldTooShort          ErrorCode = "FIELD_TOO_SHORT"
 
@@ -60,6 +74,50 @@ const (
 	ErrTemplateNotFound       ErrorCode = "TEMPLATE_NOT_FOUND"
 	ErrTemplateExecutionFailed ErrorCode = "TEMPLATE_EXECUTION_FAILED"
 	ErrTemplateSyntaxError    ErrorCode = "TEMPLATE_SYNTAX_ERROR"
+	ErrArtifactNotPlanned     ErrorCode = "ARTIFACT_NOT_PLANNED"
+
+	// Migration Errors
+	ErrUnsupportedMigration ErrorCode = "UNSUPPORTED_MIGRATION"
+
+	// Backup Errors
+	ErrBackupNotFound ErrorCode = "BACKUP_NOT_FOUND"
+
+	// YAML Errors
+	ErrYAMLSyntax            ErrorCode = "YAML_SYNTAX_ERROR"
+	ErrYAMLDuplicateKey      ErrorCode = "YAML_DUPLICATE_KEY"
+	ErrYAMLTabIndentation    ErrorCode = "YAML_TAB_INDENTATION"
+	ErrYAMLAliasTypeMismatch ErrorCode = "YAML_ALIAS_TYPE_MISMATCH"
+
+	// Schema Errors
+	ErrUnknownSchemaVersion ErrorCode = "UNKNOWN_SCHEMA_VERSION"
+	ErrSchemaUnknownKey     ErrorCode = "SCHEMA_UNKNOWN_KEY"
+	ErrSchemaTypeMismatch   ErrorCode = "SCHEMA_TYPE_MISMATCH"
+	ErrSchemaEnumViolation  ErrorCode = "SCHEMA_ENUM_VIOLATION"
+
+	// Lint Errors
+	ErrLintViolation   ErrorCode = "LINT_VIOLATION"
+	ErrUnknownLintRule ErrorCode = "UNKNOWN_LINT_RULE"
+
+	// Report Errors
+	ErrUnknownOutputFormat ErrorCode = "UNKNOWN_OUTPUT_FORMAT"
+
+	// Policy Errors
+	ErrPolicyViolation ErrorCode = "POLICY_VIOLATION"
+	ErrInvalidPolicy   ErrorCode = "INVALID_POLICY"
+
+	// Import Errors
+	ErrImportUnsupported ErrorCode = "IMPORT_UNSUPPORTED"
+
+	// Answers Errors
+	ErrUnsupportedAnswersVersion ErrorCode = "UNSUPPORTED_ANSWERS_VERSION"
+	ErrInvalidMergePatch         ErrorCode = "INVALID_MERGE_PATCH"
+	ErrInvalidAnswersPath        ErrorCode = "INVALID_ANSWERS_PATH"
+	ErrInvalidAnswersValue       ErrorCode = "INVALID_ANSWERS_VALUE"
+	ErrInvalidConfigLayer        ErrorCode = "INVALID_CONFIG_LAYER"
+
+	// Preset Errors
+	ErrUnknownPreset ErrorCode = "UNKNOWN_PRESET"
+	ErrInvalidPreset ErrorCode = "INVALID_PRESET"
 
 	// External Service Errors
 	ErrGitOperationFailed    ErrorCode = "GIT_OPERATION_FAILED"
@@ -69,10 +127,30 @@ const (
 
 // Error implements the error interface
 func (de *DomainError) Error() string {
+	msg := fmt.Sprintf("[%s] %s", de.Code, de.Message)
+	if de.Field != "" {
+		msg += fmt.Sprintf(" (field: %s)", de.Field)
+	}
 	if de.Context != "" {
-		return fmt.Sprintf("[%s] %s (context: %s)", de.Code, de.Message, de.Context)
+		msg += fmt.Sprintf(" (context: %s)", de.Context)
+	}
+	if de.Line > 0 {
+		msg += fmt.Sprintf(" (line %d, column %d)", de.Line, de.Column)
+	}
+	return msg
+}
+
+// Location returns the error position as context:line:column, leaving out
+// the parts that are not known
+func (de *DomainError) Location() string {
+	location := de.Context
+	if de.Line > 0 {
+		if location != "" {
+			location += ":"
+		}
+		location += fmt.Sprintf("%d:%d", de.Line, de.Column)
 	}
-	return fmt.Sprintf("[%s] %s", de.Code, de.Message)
+	return location
 }
 
 // Unwrap returns the underlying cause
@@ -82,24 +160,103 @@ func (de *DomainError) Unwrap() error {
 
 // WithContext adds context to the error
 func (de *DomainError) WithContext(context string) *DomainError {
-	return &DomainError{
-		Code:    de.Code,
-		Message: de.Message,
-		Details: de.Details,
-		Context: context,
-		Cause:   de.Cause,
-	}
+	clone := *de
+	clone.Context = context
+	return &clone
+}
+
+// WithField records the configuration field path the error refers to
+func (de *DomainError) WithField(field string) *DomainError {
+	clone := *de
+	clone.Field = field
+	return &clone
+}
+
+// WithPosition records the 1-based line and column the error refers to
+func (de *DomainError) WithPosition(line, column int) *DomainError {
+	clone := *de
+	clone.Line = line
+	clone.Column = column
+	return &clone
+}
+
+// WithRule records the id of the lint rule that reported the error
+func (de *DomainError) WithRule(rule string) *DomainError {
+	clone := *de
+	clone.Rule = rule
+	return &clone
 }
 
 // WithCause adds an underlying cause to the error
 func (de *DomainError) WithCause(cause error) *DomainError {
-	return &DomainError{
-		Code:    de.Code,
-		Message: de.Message,
-		Details: de.Details,
-		Context: de.Context,
-		Cause:   cause,
+	clone := *de
+	clone.Cause = cause
+	return &clone
+}
+
+// ValidationErrors aggregates every violation found during a validation pass
+// so callers can report all problems at once instead of failing fast
+type ValidationErrors []*DomainError
+
+// Error implements the error interface
+func (ve ValidationErrors) Error() string {
+	switch len(ve) {
+	case 0:
+		return "no validation errors"
+	case 1:
+		return ve[0].Error()
+	}
+
+	messages := make([]string, 0, len(ve))
+	for _, err := range ve {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(ve), strings.Join(messages, "; "))
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As
+func (ve ValidationErrors) Unwrap() []error {
+	errs := make([]error, 0, len(ve))
+	for _, err := range ve {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Err returns nil when there are no violations, otherwise the aggregate itself
+func (ve ValidationErrors) Err() error {
+	if len(ve) == 0 {
+		return nil
 	}
+	return ve
+}
+
+// Fields returns the distinct field paths that have violations, in order of appearance
+func (ve ValidationErrors) Fields() []string {
+	seen := make(map[string]bool)
+	fields := []string{}
+	for _, err := range ve {
+		if err.Field == "" || seen[err.Field] {
+			continue
+		}
+		seen[err.Field] = true
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+// AsValidationErrors extracts aggregated violations from err.
+// A single DomainError is returned as a one-element aggregate.
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+	var ve ValidationErrors
+	if errors.As(err, &ve) {
+		return ve, true
+	}
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return ValidationErrors{domainErr}, true
+	}
+	return nil, false
 }
 
 // IsErrorCode checks if an error matches a specific error code
@@ -108,6 +265,15 @@ func IsErrorCode(err error, code ErrorCode) bool {
 	if errAs, ok := err.(*DomainError); ok {
 		return errAs.Code == code
 	}
+	var violations ValidationErrors
+	if errors.As(err, &violations) {
+		for _, violation := range violations {
+			if violation.Code == code {
+				return true
+			}
+		}
+		return false
+	}
 	if errors.As(err, &domainErr) {
 		return domainErr.Code == code
 	}
@@ -185,12 +351,20 @@ func InvalidArchitectureError(value string) *DomainError {
 	return NewValidationError(ErrInvalidArchitecture, "Invalid architecture", fmt.Sprintf("'%s' is not a supported architecture", value))
 }
 
+func InvalidArchitectureVariantError(details string) *DomainError {
+	return NewValidationError(ErrInvalidArchitectureVariant, "Invalid architecture variant", details)
+}
+
 func DockerNotSupportedError(projectType ProjectType) *DomainError {
 	return NewConfigurationError(ErrDockerNotSupported, "Docker not supported", fmt.Sprintf("Project type %s does not support Docker", projectType))
 }
 
 func PlatformArchMismatchError(platform Platform, arch Architecture) *DomainError {
-	return NewConfigurationError(ErrPlatformArchMismatch, "Platform-architecture mismatch", fmt.Sprintf("Architecture %s is not supported on platform %s", arch, platform))
+	supported := make([]string, 0, len(platform.Architectures()))
+	for _, a := range platform.Architectures() {
+		supported = append(supported, string(a))
+	}
+	return NewConfigurationError(ErrPlatformArchMismatch, "Platform-architecture mismatch", fmt.Sprintf("Architecture %s is not supported on platform %s; %s supports %s", arch, platform, platform, strings.Join(supported, ", ")))
 }
 
 // System error constructors
@@ -233,16 +407,70 @@ func (de *DomainError) GetRecoverySuggestion() string {
 		return "Use only letters, numbers, hyphens, and underscores. Must start with a letter and be 1-63 characters. Avoid reserved Windows names."
 	case ErrInvalidMainPath:
 		return "Use relative path with only valid characters. Avoid parent directory references (..)."
+	case ErrInvalidArchitectureVariant:
+		return "Set variants only for selected architectures that have them: goamd64 v1-v4, goarm 5-7, goarm64 v8.0-v9.5, gomips hardfloat or softfloat, go386 sse2 or softfloat."
 	case ErrDockerNotSupported:
 		return "Disable Docker support or choose a project type that supports containers."
 	case ErrPlatformArchMismatch:
-		return "Select architectures that are compatible with your target platforms."
+		return "Select architectures that are compatible with your target platforms; 'go tool dist list' shows every pair."
+	case ErrMainPathRequired:
+		return "Set main_path to the package containing your main function, e.g. ./cmd/app."
+	case ErrCGONotSupported:
+		return "Remove targets without CGO support, such as js/wasm or plan9, or relax cgo_status to enabled or disabled."
 	case ErrPermissionDenied:
 		return "Check file permissions and ensure you have write access to the directory."
 	case ErrFileNotFound:
 		return "Verify the file exists and the path is correct."
 	case ErrTemplateNotFound:
 		return "Ensure the template exists and is accessible."
+	case ErrArtifactNotPlanned:
+		return "Enable the feature in the answers, e.g. 'goreleaser-wizard config set docker_support both', or print an artifact listed by 'generate --dry-run'."
+	case ErrUnsupportedMigration:
+		return "Run 'goreleaser-wizard migrate --list' to see the supported migrations."
+	case ErrBackupNotFound:
+		return "Run 'goreleaser-wizard rollback --list' to see the recorded runs."
+	case ErrYAMLSyntax:
+		return "Fix the YAML syntax at the reported line; check indentation, quoting and unclosed brackets."
+	case ErrYAMLDuplicateKey:
+		return "Remove or merge the repeated key; only one value per key is kept."
+	case ErrYAMLTabIndentation:
+		return "Indent YAML with spaces only; tabs are not allowed."
+	case ErrYAMLAliasTypeMismatch:
+		return "Point the alias at an anchor of the expected type, or copy the value in place."
+	case ErrUnknownSchemaVersion:
+		return "Use --schema auto, v1, v2 or pro."
+	case ErrSchemaUnknownKey:
+		return "Check the key's spelling. Keys removed in GoReleaser v2 can be upgraded with 'goreleaser-wizard migrate'."
+	case ErrSchemaTypeMismatch:
+		return "Change the value to the expected type, e.g. wrap a single value in a list."
+	case ErrSchemaEnumViolation:
+		return "Use one of the listed values."
+	case ErrLintViolation:
+		return "Fix the reported problem, or add '# wizard:ignore <rule-id>' to the line to suppress it."
+	case ErrUnknownLintRule:
+		return "Run 'goreleaser-wizard validate --list-rules' to see the available rules."
+	case ErrUnknownOutputFormat:
+		return "Use --format text, json, sarif, junit or github."
+	case ErrPolicyViolation:
+		return "Change the configuration to meet your organization's policy, or ask its owners for an exception."
+	case ErrInvalidPolicy:
+		return "Fix the policy file; see 'goreleaser-wizard validate --help' for the supported constraints."
+	case ErrImportUnsupported:
+		return "Keep the existing configuration and adopt changes with 'goreleaser-wizard update', which preserves hand edits."
+	case ErrUnsupportedAnswersVersion:
+		return "The answers file was written by a newer goreleaser-wizard; upgrade the wizard to use it."
+	case ErrInvalidMergePatch:
+		return "Send a JSON object whose members are answers fields, e.g. {\"sbom\": true}; null removes a field."
+	case ErrInvalidAnswersPath:
+		return "Paths follow the keys of .goreleaser-wizard.yaml, e.g. sbom, platforms[0] or build_tags[0].name."
+	case ErrInvalidAnswersValue:
+		return "Use one of the listed values."
+	case ErrInvalidConfigLayer:
+		return "Baseline and default files hold answers fields, e.g. 'signing_level: advanced'; 'goreleaser-wizard config explain' shows where each value comes from."
+	case ErrUnknownPreset:
+		return "Run 'goreleaser-wizard presets list' to see the available presets."
+	case ErrInvalidPreset:
+		return "A preset file needs a description and an answers mapping; see 'goreleaser-wizard presets show minimal-cli' for an example."
 	default:
 		return "Check the error details and try again with corrected input."
 	}
@@ -258,6 +486,22 @@ const (
 	ErrorSeverityCritical
 )
 
+// String returns the lowercase severity name
+func (s ErrorSeverity) String() string {
+	switch s {
+	case ErrorSeverityInfo:
+		return "info"
+	case ErrorSeverityWarning:
+		return "warning"
+	case ErrorSeverityError:
+		return "error"
+	case ErrorSeverityCritical:
+		return "critical"
+	default:
+		return "unknown"
+	}
+}
+
 func (de *DomainError) GetSeverity() ErrorSeverity {
 	switch de.Code {
 	// Validation errors are warnings (user can fix)
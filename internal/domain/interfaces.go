@@ -235,6 +235,20 @@ type ValidationResult struct {
 	Rules    *ValidationRules  `json:"rules"`
 }
 
+// NewValidationResult builds a result from the collected violations
+func NewValidationResult(violations ValidationErrors) *ValidationResult {
+	return &ValidationResult{
+		IsValid:  len(violations) == 0,
+		Errors:   violations,
+		Warnings: []*DomainError{},
+	}
+}
+
+// Err returns the aggregated errors of the result, or nil if it is valid
+func (vr *ValidationResult) Err() error {
+	return ValidationErrors(vr.Errors).Err()
+}
+
 type ConfigUpdate struct {
 	ProjectName        *string        `json:"project_name,omitempty"`
 	ProjectDescription *string        `json:"project_description,omitempty"`
@@ -243,6 +257,7 @@ type ConfigUpdate struct {
 	MainPath          *string        `json:"main_path,omitempty"`
 	Platforms         []Platform      `json:"platforms,omitempty"`
 	Architectures     []Architecture `json:"architectures,omitempty"`
+	ArchitectureVariants []ArchitectureVariant `json:"architecture_variants,omitempty"`
 	CGOEnabled        *bool          `json:"cgo_enabled,omitempty"`
 	BuildTags         []BuildTag     `json:"build_tags,omitempty"`
 	LDFlags           *bool          `json:"ldflags,omitempty"`
@@ -267,6 +282,7 @@ type ProjectInfo struct {
 	HasGoMod     bool             `json:"has_go_mod"`
 	HasMainFile  bool             `json:"has_main_file"`
 	MainFilePath  string           `json:"main_file_path"`
+	MissingMains []string         `json:"missing_mains,omitempty"`
 	ProjectType  ProjectType      `json:"project_type"`
 	BinaryName   string           `json:"binary_name"`
 	Buildable    bool             `json:"buildable"`
@@ -275,6 +291,13 @@ type ProjectInfo struct {
 	Modules      []string         `json:"modules"`
 }
 
+// ReleaseConfig is a GoReleaser configuration of a project and the main
+// packages or files its builds compile, relative to the project directory
+type ReleaseConfig struct {
+	Path  string   `json:"path"`
+	Mains []string `json:"mains"`
+}
+
 type ProjectValidationResult struct {
 	IsValid        bool                     `json:"is_valid"`
 	Info           *ProjectInfo              `json:"info,omitempty"`
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// LayerKind names a source of wizard answers
+type LayerKind string
+
+const (
+	// LayerBuiltin holds the values of NewSafeProjectConfig
+	LayerBuiltin LayerKind = "builtin"
+	// LayerDefaults holds what ApplyDefaults recommends for the project type
+	LayerDefaults LayerKind = "defaults"
+	// LayerOrg is an organization baseline shared by every project
+	LayerOrg LayerKind = "org"
+	// LayerUser holds the defaults of the user config in $HOME
+	LayerUser LayerKind = "user"
+	// LayerPolicy holds the values an organization policy raised the
+	// defaults to
+	LayerPolicy LayerKind = "policy"
+	// LayerPreset holds a named preset chosen for the project
+	LayerPreset LayerKind = "preset"
+	// LayerDetected holds what was detected from go.mod and the project layout
+	LayerDetected LayerKind = "detected"
+	// LayerProject holds the persisted answers of the project
+	LayerProject LayerKind = "project"
+	// LayerFlags holds values given on the command line
+	LayerFlags LayerKind = "flags"
+)
+
+// Layer is a partial set of answers from one source. Values are keyed by
+// field name, e.g. "signing_level", and hold JSON-compatible values.
+type Layer struct {
+	Kind   LayerKind
+	Source string
+	Values map[string]any
+}
+
+// String describes the layer as "kind (source)"
+func (l Layer) String() string {
+	if l.Source == "" {
+		return string(l.Kind)
+	}
+	return fmt.Sprintf("%s (%s)", l.Kind, l.Source)
+}
+
+// LayerValue is the value one layer gives a field
+type LayerValue struct {
+	Layer Layer
+	Value any
+}
+
+// LayeredConfig is a configuration merged from layers, remembering which
+// layer set each field
+type LayeredConfig struct {
+	Config *SafeProjectConfig
+	// Layers starts with the builtin and defaults layers, followed by the
+	// layers given to ResolveLayers
+	Layers []Layer
+
+	provenance map[string]int
+}
+
+// ResolveLayers merges layers, weakest first, over the built-in baseline.
+// Fields no layer sets then receive the defaults ApplyDefaults recommends for
+// the resulting project type, so an explicit "signing_level: none" is kept.
+func ResolveLayers(layers ...Layer) (*LayeredConfig, error) {
+	builtin := NewSafeProjectConfig()
+	builtinValues, err := configValues(builtin)
+	if err != nil {
+		return nil, err
+	}
+
+	lc := &LayeredConfig{
+		Layers:     []Layer{{Kind: LayerBuiltin, Values: builtinValues}, {Kind: LayerDefaults}},
+		provenance: make(map[string]int),
+	}
+
+	merged := builtin
+	for _, layer := range layers {
+		patch, err := json.Marshal(layer.Values)
+		if err != nil {
+			return nil, NewSystemError(ErrInvalidConfigLayer, "Failed to encode configuration layer", err.Error(), err).WithContext(layer.Source)
+		}
+		if merged, err = merged.ApplyMergePatch(patch); err != nil {
+			return nil, asConfigLayerError(err).WithContext(layer.Source)
+		}
+		lc.Layers = append(lc.Layers, layer)
+		for field := range layer.Values {
+			lc.provenance[field] = len(lc.Layers) - 1
+		}
+	}
+
+	// Recommend defaults only for fields still at their built-in value
+	defaulted := merged.Clone()
+	defaulted.ApplyDefaults()
+	before, err := configValues(merged)
+	if err != nil {
+		return nil, err
+	}
+	after, err := configValues(defaulted)
+	if err != nil {
+		return nil, err
+	}
+	defaults := make(map[string]any)
+	for _, field := range configFields() {
+		if _, explicit := lc.provenance[field]; explicit || reflect.DeepEqual(before[field], after[field]) {
+			continue
+		}
+		defaults[field] = after[field]
+		lc.provenance[field] = 1
+	}
+	lc.Layers[1].Source = fmt.Sprintf("recommended for %s", merged.ProjectType)
+	lc.Layers[1].Values = defaults
+
+	if len(defaults) > 0 {
+		patch, err := json.Marshal(defaults)
+		if err != nil {
+			return nil, NewSystemError(ErrInvalidConfigLayer, "Failed to encode configuration defaults", err.Error(), err)
+		}
+		if merged, err = merged.ApplyMergePatch(patch); err != nil {
+			return nil, err
+		}
+	}
+	lc.Config = merged
+	return lc, nil
+}
+
+// Source returns the layer that set a field
+func (lc *LayeredConfig) Source(field string) Layer {
+	return lc.Layers[lc.provenance[field]]
+}
+
+// Explain returns every layer that gives a field a value, weakest first. The
+// last one is the layer whose value the configuration holds.
+func (lc *LayeredConfig) Explain(field string) ([]LayerValue, error) {
+	if !isConfigField(field) {
+		return nil, NewValidationError(
+			ErrInvalidAnswersPath,
+			"Unknown answers field",
+			fmt.Sprintf("%q is not an answers field; fields are %s", field, strings.Join(configFields(), ", ")),
+		).WithField(field)
+	}
+
+	var values []LayerValue
+	for i, layer := range lc.Layers {
+		value, ok := layer.Values[field]
+		if ok || i == 0 {
+			values = append(values, LayerValue{Layer: layer, Value: value})
+		}
+	}
+	return values, nil
+}
+
+// Fields returns the answers fields in declaration order
+func (lc *LayeredConfig) Fields() []string {
+	return configFields()
+}
+
+// configFields lists the field names of SafeProjectConfig, without state
+func configFields() []string {
+	typ := reflect.TypeOf(SafeProjectConfig{})
+	var fields []string
+	for i := 0; i < typ.NumField(); i++ {
+		if name := fieldName(typ.Field(i)); name != "" && name != "state" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+func isConfigField(field string) bool {
+	for _, name := range configFields() {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
+
+// configValues returns the fields of a configuration as JSON values
+func configValues(config *SafeProjectConfig) (map[string]any, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return nil, NewSystemError(ErrInvalidConfigLayer, "Failed to encode configuration", err.Error(), err)
+	}
+	values := make(map[string]any)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, NewSystemError(ErrInvalidConfigLayer, "Failed to encode configuration", err.Error(), err)
+	}
+	delete(values, "state")
+	return values, nil
+}
+
+// asConfigLayerError reports a layer that does not fit the configuration
+func asConfigLayerError(err error) *DomainError {
+	var domainErr *DomainError
+	if !errors.As(err, &domainErr) {
+		return NewValidationError(ErrInvalidConfigLayer, "Invalid configuration layer", err.Error()).WithCause(err)
+	}
+	if domainErr.Code == ErrInvalidMergePatch {
+		return NewValidationError(ErrInvalidConfigLayer, "Invalid configuration layer", domainErr.Details).WithCause(err)
+	}
+	return domainErr
+}
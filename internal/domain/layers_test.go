@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveLayers(t *testing.T) {
+	org := Layer{Kind: LayerOrg, Source: "org.yaml", Values: map[string]any{
+		"signing_level":   "advanced",
+		"sbom":            true,
+		"docker_registry": "ghcr.io",
+	}}
+	user := Layer{Kind: LayerUser, Source: "home.yaml", Values: map[string]any{
+		"project_type":  "web",
+		"signing_level": "none",
+	}}
+	flags := Layer{Kind: LayerFlags, Source: "--set", Values: map[string]any{
+		"platforms": []any{"linux"},
+	}}
+
+	resolved, err := ResolveLayers(org, user, flags)
+	if err != nil {
+		t.Fatalf("ResolveLayers() error = %v", err)
+	}
+	config := resolved.Config
+
+	if config.SigningLevel != SigningLevelNone {
+		t.Errorf("signing_level = %s, want the user's explicit none", config.SigningLevel)
+	}
+	if !config.SBOM || config.DockerRegistry != DockerRegistryGitHub {
+		t.Errorf("org baseline not applied: sbom = %t, registry = %s", config.SBOM, config.DockerRegistry)
+	}
+	if !reflect.DeepEqual(config.Platforms, []Platform{PlatformLinux}) {
+		t.Errorf("platforms = %v", config.Platforms)
+	}
+	// Not set by any layer, so recommended for a web service
+	if config.DockerSupport != DockerSupportBuild {
+		t.Errorf("docker_support = %s, want the web service default", config.DockerSupport)
+	}
+
+	sources := map[string]LayerKind{
+		"signing_level":  LayerUser,
+		"sbom":           LayerOrg,
+		"platforms":      LayerFlags,
+		"docker_support": LayerDefaults,
+		"ldflags":        LayerBuiltin,
+	}
+	for field, kind := range sources {
+		if got := resolved.Source(field).Kind; got != kind {
+			t.Errorf("Source(%s) = %s, want %s", field, got, kind)
+		}
+	}
+}
+
+func TestResolveLayersRejectsMismatchedValues(t *testing.T) {
+	layer := Layer{Kind: LayerUser, Source: "home.yaml", Values: map[string]any{"sbom": "yes"}}
+	if _, err := ResolveLayers(layer); !IsErrorCode(err, ErrInvalidConfigLayer) {
+		t.Errorf("ResolveLayers() error = %v, want %s", err, ErrInvalidConfigLayer)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	resolved, err := ResolveLayers(
+		Layer{Kind: LayerOrg, Source: "org.yaml", Values: map[string]any{"signing_level": "advanced"}},
+		Layer{Kind: LayerProject, Source: ".goreleaser-wizard.yaml", Values: map[string]any{"signing_level": "enterprise"}},
+	)
+	if err != nil {
+		t.Fatalf("ResolveLayers() error = %v", err)
+	}
+
+	values, err := resolved.Explain("signing_level")
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	var kinds []LayerKind
+	for _, value := range values {
+		kinds = append(kinds, value.Layer.Kind)
+	}
+	if expected := []LayerKind{LayerBuiltin, LayerOrg, LayerProject}; !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("Explain() layers = %v, want %v", kinds, expected)
+	}
+	if values[len(values)-1].Value != "enterprise" {
+		t.Errorf("winning value = %v", values[len(values)-1].Value)
+	}
+
+	for _, field := range []string{"state", "signing", "platforms[0]"} {
+		if _, err := resolved.Explain(field); !IsErrorCode(err, ErrInvalidAnswersPath) {
+			t.Errorf("Explain(%s) error = %v", field, err)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// nopLogger discards all log output in tests
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{})                         {}
+func (nopLogger) Info(string, ...interface{})                          {}
+func (nopLogger) Warn(string, ...interface{})                          {}
+func (nopLogger) Error(string, ...interface{})                         {}
+func (nopLogger) Fatal(string, ...interface{})                         {}
+func (nopLogger) DebugContext(context.Context, string, ...interface{}) {}
+func (nopLogger) InfoContext(context.Context, string, ...interface{})  {}
+func (nopLogger) WarnContext(context.Context, string, ...interface{})  {}
+func (nopLogger) ErrorContext(context.Context, string, ...interface{}) {}
+func (l nopLogger) WithField(string, interface{}) Logger               { return l }
+func (l nopLogger) WithFields(map[string]interface{}) Logger           { return l }
+func (l nopLogger) WithError(error) Logger                             { return l }
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MergePatch applies an RFC 7396 JSON merge patch to a JSON document: objects
+// are merged recursively, null removes a member and any other value,
+// including an array, replaces the target
+func MergePatch(document, patch []byte) ([]byte, error) {
+	var target, changes any
+	if err := json.Unmarshal(document, &target); err != nil {
+		return nil, NewValidationError(ErrInvalidMergePatch, "Invalid merge patch target", err.Error()).WithCause(err)
+	}
+	if err := json.Unmarshal(patch, &changes); err != nil {
+		return nil, NewValidationError(ErrInvalidMergePatch, "Invalid merge patch", err.Error()).WithCause(err)
+	}
+	return json.Marshal(mergeValue(target, changes))
+}
+
+func mergeValue(target, patch any) any {
+	members, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	merged, ok := target.(map[string]any)
+	if !ok {
+		merged = map[string]any{}
+	}
+	for name, value := range members {
+		if value == nil {
+			delete(merged, name)
+		} else {
+			merged[name] = mergeValue(merged[name], value)
+		}
+	}
+	return merged
+}
+
+// ApplyMergePatch returns a copy of the configuration with an RFC 7396 merge
+// patch applied. Members are the json field names; removing one resets the
+// field to its zero value. Unknown members are rejected.
+func (spc *SafeProjectConfig) ApplyMergePatch(patch []byte) (*SafeProjectConfig, error) {
+	document, err := json.Marshal(spc)
+	if err != nil {
+		return nil, NewSystemError(ErrInvalidMergePatch, "Failed to encode configuration", err.Error(), err)
+	}
+	merged, err := MergePatch(document, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	patched := &SafeProjectConfig{}
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		return nil, NewValidationError(ErrInvalidMergePatch, "Merge patch does not fit the configuration", err.Error()).WithCause(err)
+	}
+	return patched, nil
+}
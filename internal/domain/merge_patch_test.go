@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestMergePatch runs the examples of RFC 7396, appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target, patch, expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		result, err := MergePatch([]byte(tt.target), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("MergePatch(%s, %s) error = %v", tt.target, tt.patch, err)
+			continue
+		}
+		var got, want any
+		json.Unmarshal(result, &got)
+		json.Unmarshal([]byte(tt.expected), &want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("MergePatch(%s, %s) = %s, want %s", tt.target, tt.patch, result, tt.expected)
+		}
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	config := validTestConfig()
+	config.ProjectDescription = "A tool"
+
+	patched, err := config.ApplyMergePatch([]byte(`{"sbom": true, "platforms": ["linux"], "project_description": null}`))
+	if err != nil {
+		t.Fatalf("ApplyMergePatch() error = %v", err)
+	}
+	var paths []string
+	for _, change := range config.Diff(patched) {
+		paths = append(paths, change.Path)
+	}
+	expected := []string{"project_description", "platforms[1]", "platforms[2]", "sbom"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("patched fields = %v, want %v", paths, expected)
+	}
+	if config.SBOM {
+		t.Error("ApplyMergePatch() modified its receiver")
+	}
+
+	for _, patch := range []string{`{"sbom": "yes"}`, `{"unknown": 1}`, `not json`} {
+		if _, err := config.ApplyMergePatch([]byte(patch)); !IsErrorCode(err, ErrInvalidMergePatch) {
+			t.Errorf("ApplyMergePatch(%s) error = %v", patch, err)
+		}
+	}
+}
@@ -4,122 +4,135 @@ import (
 	"fmt"
 )
 
-// Platform represents supported target platforms
-// Generated from TypeSpec specification - DO NOT MODIFY MANUALLY
+// Platform represents supported target platforms. The values follow the
+// Platform enum of typespec/goreleaser-wizard.tsp; the architectures each one
+// supports come from the toolchain matrix in platforms.json.
 type Platform string
 
 const (
-	PlatformLinux   Platform = "linux"   // Linux
-	PlatformDarwin  Platform = "darwin"  // macOS
-	PlatformWindows Platform = "windows" // Windows
-	PlatformFreeBSD Platform = "freebsd" // FreeBSD
-	PlatformOpenBSD Platform = "openbsd" // OpenBSD
-	PlatformNetBSD  Platform = "netbsd"  // NetBSD
+	PlatformLinux     Platform = "linux"     // Linux
+	PlatformDarwin    Platform = "darwin"    // macOS
+	PlatformWindows   Platform = "windows"   // Windows
+	PlatformFreeBSD   Platform = "freebsd"   // FreeBSD
+	PlatformOpenBSD   Platform = "openbsd"   // OpenBSD
+	PlatformNetBSD    Platform = "netbsd"    // NetBSD
+	PlatformDragonFly Platform = "dragonfly" // DragonFly BSD
+	PlatformIllumos   Platform = "illumos"   // illumos
+	PlatformSolaris   Platform = "solaris"   // Solaris
+	PlatformAIX       Platform = "aix"       // IBM AIX
+	PlatformPlan9     Platform = "plan9"     // Plan 9
+	PlatformAndroid   Platform = "android"   // Android
+	PlatformIOS       Platform = "ios"       // iOS
+	PlatformJS        Platform = "js"        // JavaScript hosts (browsers, Node.js)
+	PlatformWASIP1    Platform = "wasip1"    // WebAssembly System Interface
 )
 
-// Platform metadata - generated from TypeSpec invariants
-type platformMeta struct {
-	architectures   []Architecture
-	isWindowsBased bool
-	isUnixLike     bool
-	supportsCGO    bool
+// platformNames are the display names of platforms
+var platformNames = map[Platform]string{
+	PlatformLinux:     "Linux",
+	PlatformDarwin:    "macOS",
+	PlatformWindows:   "Windows",
+	PlatformFreeBSD:   "FreeBSD",
+	PlatformOpenBSD:   "OpenBSD",
+	PlatformNetBSD:    "NetBSD",
+	PlatformDragonFly: "DragonFly BSD",
+	PlatformIllumos:   "illumos",
+	PlatformSolaris:   "Solaris",
+	PlatformAIX:       "AIX",
+	PlatformPlan9:     "Plan 9",
+	PlatformAndroid:   "Android",
+	PlatformIOS:       "iOS",
+	PlatformJS:        "JavaScript",
+	PlatformWASIP1:    "WASI Preview 1",
 }
 
-var platformMetaMap = map[Platform]platformMeta{
-	PlatformLinux: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64, ArchitectureARM, Architecture386},
-		isWindowsBased:  false,
-		isUnixLike:      true,
-		supportsCGO:     true,
-	},
-	PlatformDarwin: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64},
-		isWindowsBased:  false,
-		isUnixLike:      true,
-		supportsCGO:     true,
-	},
-	PlatformWindows: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64, Architecture386},
-		isWindowsBased:  true,
-		isUnixLike:      false,
-		supportsCGO:     true,
-	},
-	PlatformFreeBSD: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64, Architecture386},
-		isWindowsBased:  false,
-		isUnixLike:      true,
-		supportsCGO:     true,
-	},
-	PlatformOpenBSD: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64, Architecture386},
-		isWindowsBased:  false,
-		isUnixLike:      true,
-		supportsCGO:     true,
-	},
-	PlatformNetBSD: {
-		architectures:   []Architecture{ArchitectureAMD64, ArchitectureARM64, Architecture386},
-		isWindowsBased:  false,
-		isUnixLike:      true,
-		supportsCGO:     true,
-	},
+// unixLikePlatforms match the 'unix' build constraint
+var unixLikePlatforms = map[Platform]bool{
+	PlatformLinux:     true,
+	PlatformDarwin:    true,
+	PlatformFreeBSD:   true,
+	PlatformOpenBSD:   true,
+	PlatformNetBSD:    true,
+	PlatformDragonFly: true,
+	PlatformIllumos:   true,
+	PlatformSolaris:   true,
+	PlatformAIX:       true,
+	PlatformAndroid:   true,
+	PlatformIOS:       true,
 }
 
-// IsValid returns true if Platform is valid
+// IsValid returns true if the Go toolchain can build for the platform
 func (p Platform) IsValid() bool {
-	_, exists := platformMetaMap[p]
-	return exists
+	for _, platform := range knownPlatforms {
+		if platform == p {
+			return true
+		}
+	}
+	return false
 }
 
 // String returns human-readable display name
 func (p Platform) String() string {
-	switch p {
-	case PlatformLinux:
-		return "Linux"
-	case PlatformDarwin:
-		return "macOS"
-	case PlatformWindows:
-		return "Windows"
-	case PlatformFreeBSD:
-		return "FreeBSD"
-	case PlatformOpenBSD:
-		return "OpenBSD"
-	case PlatformNetBSD:
-		return "NetBSD"
-	default:
-		return string(p)
+	if name, exists := platformNames[p]; exists {
+		return name
 	}
+	return string(p)
+}
+
+// Targets returns the toolchain targets of this platform
+func (p Platform) Targets() []Target {
+	var targets []Target
+	for _, target := range knownTargets {
+		if target.Platform == p {
+			targets = append(targets, target)
+		}
+	}
+	return targets
 }
 
 // Architectures returns supported architectures for this platform
 func (p Platform) Architectures() []Architecture {
-	if meta, exists := platformMetaMap[p]; exists {
-		return meta.architectures
+	var architectures []Architecture
+	for _, target := range p.Targets() {
+		architectures = append(architectures, target.Architecture)
 	}
-	return []Architecture{ArchitectureAMD64, ArchitectureARM64}
+	return architectures
 }
 
-// IsWindowsBased returns true if platform is Windows-based
-func (p Platform) IsWindowsBased() bool {
-	if meta, exists := platformMetaMap[p]; exists {
-		return meta.isWindowsBased
+// SupportsArchitecture returns true if the architecture can be built for this platform
+func (p Platform) SupportsArchitecture(arch Architecture) bool {
+	_, exists := LookupTarget(p, arch)
+	return exists
+}
+
+// IsFirstClass returns true if any target of the platform is first-class
+func (p Platform) IsFirstClass() bool {
+	for _, target := range p.Targets() {
+		if target.FirstClass {
+			return true
+		}
 	}
 	return false
 }
 
+// IsWindowsBased returns true if platform is Windows-based
+func (p Platform) IsWindowsBased() bool {
+	return p == PlatformWindows
+}
+
 // IsUnixLike returns true if platform is Unix-like
 func (p Platform) IsUnixLike() bool {
-	if meta, exists := platformMetaMap[p]; exists {
-		return meta.isUnixLike
-	}
-	return false
+	return unixLikePlatforms[p]
 }
 
-// SupportsCGO returns true if platform supports CGO
+// SupportsCGO returns true if any target of the platform supports CGO
 func (p Platform) SupportsCGO() bool {
-	if meta, exists := platformMetaMap[p]; exists {
-		return meta.supportsCGO
+	for _, target := range p.Targets() {
+		if target.CGOSupported {
+			return true
+		}
 	}
-	return true
+	return false
 }
 
 // ValidatePlatforms validates a slice of platforms
@@ -144,16 +157,8 @@ func ValidatePlatformArchCompatibility(platforms []Platform, architectures []Arc
 	}
 
 	for _, platform := range platforms {
-		platformArchs := platform.Architectures()
 		for _, arch := range architectures {
-			compatible := false
-			for _, platformArch := range platformArchs {
-				if arch == platformArch {
-					compatible = true
-					break
-				}
-			}
-			if !compatible {
+			if _, exists := LookupTarget(platform, arch); !exists {
 				return fmt.Errorf("architecture %s is not supported on platform %s", arch, platform)
 			}
 		}
@@ -162,10 +167,7 @@ func ValidatePlatformArchCompatibility(platforms []Platform, architectures []Arc
 	return nil
 }
 
-// GetAllPlatforms returns all available platforms
+// GetAllPlatforms returns every platform of the Go toolchain, first-class ones first
 func GetAllPlatforms() []Platform {
-	return []Platform{
-		PlatformLinux, PlatformDarwin, PlatformWindows,
-		PlatformFreeBSD, PlatformOpenBSD, PlatformNetBSD,
-	}
-}
\ No newline at end of file
+	return append([]Platform(nil), knownPlatforms...)
+}
@@ -1,14 +1,15 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
-	
+
 	"github.com/LarsArtmann/template-GoReleaser/internal/validation"
 )
 
 // SafeProjectConfig represents single source of truth for project configuration
-// Generated from TypeSpec specification - DO NOT MODIFY MANUALLY
 type SafeProjectConfig struct {
 	// Basic Information
 	ProjectName        string      `json:"project_name" yaml:"project_name"`
@@ -18,11 +19,12 @@ type SafeProjectConfig struct {
 	MainPath           string      `json:"main_path" yaml:"main_path"`
 
 	// Build Configuration
-	Platforms     []Platform     `json:"platforms" yaml:"platforms"`
-	Architectures []Architecture `json:"architectures" yaml:"architectures"`
-	CGOStatus     CGOStatus     `json:"cgo_status" yaml:"cgo_status"`
-	BuildTags     []BuildTag     `json:"build_tags,omitempty" yaml:"build_tags,omitempty"`
-	LDFlags       bool           `json:"ldflags" yaml:"ldflags"`
+	Platforms            []Platform            `json:"platforms" yaml:"platforms"`
+	Architectures        []Architecture        `json:"architectures" yaml:"architectures"`
+	ArchitectureVariants []ArchitectureVariant `json:"architecture_variants,omitempty" yaml:"architecture_variants,omitempty"`
+	CGOStatus            CGOStatus             `json:"cgo_status" yaml:"cgo_status"`
+	BuildTags            []BuildTag            `json:"build_tags,omitempty" yaml:"build_tags,omitempty"`
+	LDFlags              bool                  `json:"ldflags" yaml:"ldflags"`
 
 	// Release Configuration
 	GitProvider    GitProvider    `json:"git_provider" yaml:"git_provider"`
@@ -35,8 +37,8 @@ type SafeProjectConfig struct {
 	SBOM           bool           `json:"sbom" yaml:"sbom"`
 
 	// CI/CD Configuration
-	ActionLevel   ActionLevel    `json:"action_level" yaml:"action_level"`
-	ActionsOn     []ActionTrigger `json:"actions_on" yaml:"actions_on"`
+	ActionLevel ActionLevel     `json:"action_level" yaml:"action_level"`
+	ActionsOn   []ActionTrigger `json:"actions_on" yaml:"actions_on"`
 
 	// Advanced Features
 	FeatureLevel FeatureLevel `json:"feature_level" yaml:"feature_level"`
@@ -45,24 +47,26 @@ type SafeProjectConfig struct {
 	State ConfigState `json:"state" yaml:"state"`
 }
 
-// NewSafeProjectConfig creates a new safe configuration with smart defaults
+// NewSafeProjectConfig creates a new safe configuration with smart defaults.
+// These are the builtin layer that ResolveLayers starts from.
 func NewSafeProjectConfig() *SafeProjectConfig {
 	return &SafeProjectConfig{
 		// Smart defaults based on project analysis
-		ProjectType:      GetRecommendedProjectType(),
-		Platforms:        GetRecommendedPlatforms(),
-		Architectures:    GetRecommendedArchitectures(),
-		GitProvider:      GetRecommendedGitProvider(),
-		DockerRegistry:   GetRecommendedDockerRegistry(),
-		CGOStatus:        CGOStatusDisabled,
-		ActionLevel:      ActionLevelBasic,
-		SigningLevel:     SigningLevelNone,
-		FeatureLevel:     FeatureLevelBasic,
-		State:            ConfigStateDraft,
-		LDFlags:          true,
-		Homebrew:         false,
-		Snap:             false,
-		SBOM:             false,
+		ProjectType:    GetRecommendedProjectType(),
+		Platforms:      GetRecommendedPlatforms(),
+		Architectures:  GetRecommendedArchitectures(),
+		GitProvider:    GetRecommendedGitProvider(),
+		DockerRegistry: GetRecommendedDockerRegistry(),
+		CGOStatus:      CGOStatusDisabled,
+		DockerSupport:  DockerSupportNone,
+		ActionLevel:    ActionLevelBasic,
+		SigningLevel:   SigningLevelNone,
+		FeatureLevel:   FeatureLevelBasic,
+		State:          ConfigStateDraft,
+		LDFlags:        true,
+		Homebrew:       false,
+		Snap:           false,
+		SBOM:           false,
 	}
 }
 
@@ -97,9 +101,9 @@ func (spc *SafeProjectConfig) ApplyDefaults() {
 	// Apply action level defaults
 	if spc.ActionLevel == ActionLevelNone && spc.GitProvider.ActionsSupported() {
 		spc.ActionLevel = ActionLevelBasic
-		if spc.ActionsOn == nil || len(spc.ActionsOn) == 0 {
-			spc.ActionsOn = GetRecommendedTriggers(spc.ProjectType)
-		}
+	}
+	if spc.ActionLevel.IsEnabled() && len(spc.ActionsOn) == 0 {
+		spc.ActionsOn = GetRecommendedTriggers(spc.ProjectType)
 	}
 
 	// Apply feature level defaults based on project type
@@ -122,152 +126,239 @@ func (spc *SafeProjectConfig) ApplyDefaults() {
 	}
 }
 
-// ValidateInvariants enforces domain invariants and returns any violations
+// ValidateInvariants enforces domain invariants and returns any violations.
+// The returned error is a ValidationErrors aggregate holding every violation.
 func (spc *SafeProjectConfig) ValidateInvariants() error {
-	// Basic validation
-	if err := ValidateProjectName(spc.ProjectName); err != nil {
-		return err
-	}
+	return spc.Violations().Err()
+}
 
-	if err := ValidateBinaryName(spc.BinaryName); err != nil {
-		return err
+// Violations collects every invariant violation as a typed DomainError
+// carrying the offending field path, instead of stopping at the first one
+func (spc *SafeProjectConfig) Violations() ValidationErrors {
+	var violations ValidationErrors
+	add := func(code ErrorCode, message, field string, err error) {
+		if err == nil {
+			return
+		}
+		violations = append(violations, fieldError(code, message, field, err))
 	}
 
-	if err := ValidateMainPath(spc.MainPath); err != nil {
-		return err
+	// Basic validation
+	add(ErrInvalidProjectName, "Invalid project name", "project_name", ValidateProjectName(spc.ProjectName))
+	add(ErrInvalidBinaryName, "Invalid binary name", "binary_name", ValidateBinaryName(spc.BinaryName))
+
+	if spc.MainPath == "" {
+		if spc.ProjectType.RequiresMainPath() {
+			violations = append(violations, NewConfigurationError(
+				ErrMainPathRequired,
+				"Main path required for project type",
+				fmt.Sprintf("Project type %s requires a main path", spc.ProjectType),
+			).WithField("main_path"))
+		}
+	} else {
+		add(ErrInvalidMainPath, "Invalid main path", "main_path", ValidateMainPath(spc.MainPath))
 	}
 
-	if err := ValidateProjectDescription(spc.ProjectDescription); err != nil {
-		return err
-	}
+	add(ErrInvalidProjectDescription, "Invalid project description", "project_description", ValidateProjectDescription(spc.ProjectDescription))
 
 	// Type validation
-	if !spc.ProjectType.IsValid() {
-		return fmt.Errorf("invalid project type: %s", spc.ProjectType)
-	}
+	add(ErrInvalidProjectType, "Invalid project type", "project_type", ValidateProjectType(spc.ProjectType))
 
-	if err := ValidatePlatforms(spc.Platforms); err != nil {
-		return err
+	if len(spc.Platforms) == 0 {
+		violations = append(violations, NewValidationError(ErrMissingRequiredField, "No platforms selected", "At least one platform is required").WithField("platforms"))
 	}
-
-	if err := ValidateArchitectures(spc.Architectures); err != nil {
-		return err
+	for i, platform := range spc.Platforms {
+		if !platform.IsValid() {
+			violations = append(violations, InvalidPlatformError(string(platform)).WithField(fmt.Sprintf("platforms[%d]", i)))
+		}
 	}
 
-	if err := ValidateGitProvider(spc.GitProvider); err != nil {
-		return err
+	if len(spc.Architectures) == 0 {
+		violations = append(violations, NewValidationError(ErrMissingRequiredField, "No architectures selected", "At least one architecture is required").WithField("architectures"))
 	}
-
-	if err := ValidateDockerRegistry(spc.DockerRegistry); err != nil {
-		return err
+	for i, arch := range spc.Architectures {
+		if !arch.IsValid() {
+			violations = append(violations, InvalidArchitectureError(string(arch)).WithField(fmt.Sprintf("architectures[%d]", i)))
+		}
 	}
 
-	if err := ValidateActionTriggers(spc.ActionsOn); err != nil {
-		return err
-	}
+	violations = append(violations, spc.variantViolations()...)
 
-	// CGO status validation
-	if err := ValidateCGOStatus(spc.CGOStatus); err != nil {
-		return err
+	for i, tag := range spc.BuildTags {
+		add(ErrInvalidBuildTag, "Invalid build tag", fmt.Sprintf("build_tags[%d].name", i), ValidateBuildTag(tag))
 	}
-
-	// Docker support validation
-	if err := ValidateDockerSupport(spc.DockerSupport); err != nil {
-		return err
+	if len(spc.BuildTags) > 0 {
+		seen := make(map[string]bool)
+		for i, tag := range spc.BuildTags {
+			if seen[tag.Name] {
+				violations = append(violations, NewBusinessRuleError(ErrDuplicateBuildTag, "Duplicate build tag", fmt.Sprintf("Build tag '%s' is listed more than once", tag.Name)).WithField(fmt.Sprintf("build_tags[%d].name", i)))
+			}
+			seen[tag.Name] = true
+		}
+		if len(spc.BuildTags) > 50 {
+			violations = append(violations, NewBusinessRuleError(ErrTooManyBuildTags, "Too many build tags", "At most 50 build tags are supported").WithField("build_tags"))
+		}
 	}
 
-	// Signing level validation
-	if err := ValidateSigningLevel(spc.SigningLevel); err != nil {
-		return err
-	}
+	add(ErrInvalidGitProvider, "Invalid git provider", "git_provider", ValidateGitProvider(spc.GitProvider))
 
-	// Action level validation
-	if err := ValidateActionLevel(spc.ActionLevel); err != nil {
-		return err
+	if spc.DockerRegistry != "" || spc.DockerSupport.IsEnabled() {
+		add(ErrInvalidDockerRegistry, "Invalid Docker registry", "docker_registry", ValidateDockerRegistry(spc.DockerRegistry))
+	}
+	add(ErrInvalidDockerImage, "Invalid Docker image name", "docker_image", ValidateDockerImageName(spc.DockerImage))
+	// An image with a namespace is pushed as registry/namespace/name, which
+	// the registry must accept; without one the namespace is set at release
+	if spc.DockerSupport.IsEnabled() && spc.DockerRegistry.IsValid() && spc.DockerRegistry != DockerRegistryCustom && strings.Contains(spc.DockerImage, "/") {
+		add(ErrInvalidURLPattern, "Invalid Docker registry URL", "docker_registry", validateImageRegistry(spc.DockerRegistry, spc.DockerImage))
 	}
 
-	// Feature level validation
-	if err := ValidateFeatureLevel(spc.FeatureLevel); err != nil {
-		return err
+	if spc.ActionLevel.IsEnabled() && len(spc.ActionsOn) == 0 {
+		violations = append(violations, NewValidationError(ErrMissingRequiredField, "No action triggers selected", "At least one action trigger is required when actions are enabled").WithField("actions_on"))
+	}
+	for i, trigger := range spc.ActionsOn {
+		if !trigger.IsValid() {
+			violations = append(violations, NewValidationError(ErrInvalidActionTrigger, "Invalid action trigger", fmt.Sprintf("'%s' is not a supported action trigger", trigger)).WithField(fmt.Sprintf("actions_on[%d]", i)))
+		}
 	}
 
-	// Config state validation
-	if err := ValidateConfigState(spc.State); err != nil {
-		return err
+	add(ErrInvalidCGOStatus, "Invalid CGO status", "cgo_status", ValidateCGOStatus(spc.CGOStatus))
+	add(ErrInvalidDockerSupport, "Invalid Docker support level", "docker_support", ValidateDockerSupport(spc.DockerSupport))
+	add(ErrInvalidSigningLevel, "Invalid signing level", "signing_level", ValidateSigningLevel(spc.SigningLevel))
+	add(ErrInvalidActionLevel, "Invalid action level", "action_level", ValidateActionLevel(spc.ActionLevel))
+	add(ErrInvalidFeatureLevel, "Invalid feature level", "feature_level", ValidateFeatureLevel(spc.FeatureLevel))
+	add(ErrInvalidConfigState, "Invalid configuration state", "state", ValidateConfigState(spc.State))
+
+	// Answers in a state that cannot generate must not ask for the release
+	// workflow. Drafts are still being edited, and processing or generated
+	// answers passed validation and start over as a draft when revalidated.
+	switch spc.State {
+	case ConfigStateDraft, ConfigStateProcessing, ConfigStateGenerated:
+	default:
+		if spc.State.IsValid() && !spc.State.AllowsGeneration() && spc.GetGenerateActions() {
+			violations = append(violations, NewConfigurationError(
+				ErrInvalidStateTransition,
+				"State transition invalid",
+				fmt.Sprintf("Configuration in state '%s' cannot generate actions", spc.State),
+			).WithField("state"))
+		}
 	}
 
 	// Cross-field invariants
-	if spc.DockerSupport.IsEnabled() && !spc.ProjectType.DockerSupported() {
-		return fmt.Errorf("docker support enabled but project type %s does not support docker", spc.ProjectType)
+	if spc.DockerSupport.IsEnabled() && spc.ProjectType.IsValid() && !spc.ProjectType.DockerSupported() {
+		violations = append(violations, DockerNotSupportedError(spc.ProjectType).WithField("docker_support"))
 	}
 
-	if spc.CGOStatus.IsEnabled() && spc.CGOStatus.IsRequired() {
-		hasCGOSupport := false
+	// Every target built with required CGO must support it
+	if spc.CGOStatus.IsRequired() {
+		var withoutCGO []string
 		for _, platform := range spc.Platforms {
-			if platform.SupportsCGO() {
-				hasCGOSupport = true
-				break
+			for _, arch := range spc.Architectures {
+				if target, exists := LookupTarget(platform, arch); exists && !target.CGOSupported {
+					withoutCGO = append(withoutCGO, target.String())
+				}
 			}
 		}
-		if !hasCGOSupport {
-			return fmt.Errorf("cgo required but no selected platforms support cgo")
+		if len(withoutCGO) > 0 {
+			violations = append(violations, NewConfigurationError(ErrCGONotSupported, "CGO not supported", fmt.Sprintf("CGO is required but %s cannot use CGO", strings.Join(withoutCGO, ", "))).WithField("cgo_status"))
 		}
 	}
 
 	// Platform-architecture compatibility
-	return ValidatePlatformArchCompatibility(spc.Platforms, spc.Architectures)
+	for i, platform := range spc.Platforms {
+		if !platform.IsValid() {
+			continue
+		}
+		for _, arch := range spc.Architectures {
+			if arch.IsValid() && !platform.SupportsArchitecture(arch) {
+				violations = append(violations, PlatformArchMismatchError(platform, arch).WithField(fmt.Sprintf("platforms[%d]", i)))
+			}
+		}
+	}
+
+	// Security invariants
+	if containsShellMetacharacters(spc.BinaryName) {
+		violations = append(violations, NewBusinessRuleError(ErrInvalidCharacters, "Shell metacharacters detected", "Binary name contains potentially dangerous shell metacharacters").WithField("binary_name"))
+	}
+	if containsURLInjection(spc.DockerImage) {
+		violations = append(violations, NewBusinessRuleError(ErrInvalidCharacters, "URL injection detected", "Docker image name contains potentially dangerous URL injection sequences").WithField("docker_image"))
+	}
+
+	return violations
+}
+
+// validateImageRegistry checks that the registry accepts an image with a
+// namespace. The Docker Hub pattern describes the namespace alone, e.g. user
+// in user/app; other registries match the full registry/namespace/name.
+func validateImageRegistry(registry DockerRegistry, image string) error {
+	if registry != DockerRegistryDockerHub {
+		return ValidateDockerRegistryURL(registry, string(registry)+"/"+image)
+	}
+	namespace, _, _ := strings.Cut(image, "/")
+	if !regexp.MustCompile(registry.URLPattern()).MatchString(namespace) {
+		return fmt.Errorf("namespace '%s' does not match expected pattern for registry %s", namespace, registry)
+	}
+	return nil
+}
+
+// fieldError converts a validator error into a DomainError bound to a field path,
+// preserving the code of errors that are already typed
+func fieldError(code ErrorCode, message, field string, err error) *DomainError {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.WithField(field)
+	}
+	return NewValidationError(code, message, err.Error()).WithField(field)
 }
 
 // Clone creates a deep copy of the configuration
 func (spc *SafeProjectConfig) Clone() *SafeProjectConfig {
 	clone := *spc
-	
+
 	// Deep copy slices
 	if spc.Platforms != nil {
 		clone.Platforms = make([]Platform, len(spc.Platforms))
 		copy(clone.Platforms, spc.Platforms)
 	}
-	
+
 	if spc.Architectures != nil {
 		clone.Architectures = make([]Architecture, len(spc.Architectures))
 		copy(clone.Architectures, spc.Architectures)
 	}
-	
+
+	if spc.ArchitectureVariants != nil {
+		clone.ArchitectureVariants = make([]ArchitectureVariant, len(spc.ArchitectureVariants))
+		for i, variant := range spc.ArchitectureVariants {
+			clone.ArchitectureVariants[i] = ArchitectureVariant{
+				Architecture: variant.Architecture,
+				Variants:     append([]string(nil), variant.Variants...),
+			}
+		}
+	}
+
 	if spc.BuildTags != nil {
 		clone.BuildTags = make([]BuildTag, len(spc.BuildTags))
 		copy(clone.BuildTags, spc.BuildTags)
 	}
-	
+
 	if spc.ActionsOn != nil {
 		clone.ActionsOn = make([]ActionTrigger, len(spc.ActionsOn))
 		copy(clone.ActionsOn, spc.ActionsOn)
 	}
-	
+
 	return &clone
 }
 
-// Equals returns true if two configurations are equivalent
+// Equals returns true if two configurations are equivalent, i.e. Diff
+// reports no changes
 func (spc *SafeProjectConfig) Equals(other *SafeProjectConfig) bool {
 	if spc == nil || other == nil {
 		return spc == other
 	}
-	
-	return spc.ProjectName == other.ProjectName &&
-		spc.ProjectDescription == other.ProjectDescription &&
-		spc.ProjectType == other.ProjectType &&
-		spc.BinaryName == other.BinaryName &&
-		spc.MainPath == other.MainPath &&
-		spc.LDFlags == other.LDFlags &&
-		spc.GitProvider == other.GitProvider &&
-		spc.DockerRegistry == other.DockerRegistry &&
-		spc.DockerImage == other.DockerImage &&
-		spc.Homebrew == other.Homebrew &&
-		spc.Snap == other.Snap &&
-		spc.SBOM == other.SBOM &&
-		spc.State == other.State
-}
-
-// HasChanged returns true if any critical field has changed
+
+	return len(spc.Diff(other)) == 0
+}
+
+// HasChanged returns true if any field has changed
 func (spc *SafeProjectConfig) HasChanged(other *SafeProjectConfig) bool {
 	return !spc.Equals(other)
 }
@@ -321,7 +412,7 @@ func (spc *SafeProjectConfig) TransitionToState(newState ConfigState) error {
 	if !spc.State.AllowsTransitionTo(newState) {
 		return fmt.Errorf("invalid state transition from %s to %s", spc.State, newState)
 	}
-	
+
 	spc.State = newState
 	return nil
 }
@@ -336,13 +427,13 @@ func (cs ConfigState) AllowsTransitionTo(newState ConfigState) bool {
 		ConfigStateProcessing: {ConfigStateValid, ConfigStateInvalid, ConfigStateGenerated},
 		ConfigStateGenerated:  {}, // Final state - no transitions allowed
 	}
-	
+
 	for _, allowed := range allowedTransitions[cs] {
 		if allowed == newState {
 			return true
 		}
 	}
-	
+
 	return false
 }
 
@@ -427,4 +518,4 @@ func ValidateProjectDescription(desc string) error {
 // GetRecommendedPlatforms returns recommended platforms for default project type
 func GetRecommendedPlatforms() []Platform {
 	return GetRecommendedProjectType().RecommendedPlatforms()
-}
\ No newline at end of file
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func validTestConfig() *SafeProjectConfig {
+	config := NewSafeProjectConfig()
+	config.ProjectName = "my-app"
+	config.BinaryName = "my-app"
+	config.MainPath = "./cmd/app"
+	config.ActionsOn = []ActionTrigger{ActionTriggerVersionTags}
+	return config
+}
+
+func TestValidateInvariantsValidConfig(t *testing.T) {
+	if err := validTestConfig().ValidateInvariants(); err != nil {
+		t.Fatalf("ValidateInvariants() error = %v, want nil", err)
+	}
+}
+
+func TestValidateInvariantsAggregatesViolations(t *testing.T) {
+	config := validTestConfig()
+	config.ProjectName = ""
+	config.BinaryName = "my;app"
+	config.Platforms = []Platform{PlatformLinux, "plan10"}
+	config.SigningLevel = "ultra"
+	config.DockerSupport = DockerSupportBuild
+	config.ProjectType = ProjectTypeLibrary
+
+	err := config.ValidateInvariants()
+	if err == nil {
+		t.Fatal("ValidateInvariants() error = nil, want violations")
+	}
+
+	var violations ValidationErrors
+	if !errors.As(err, &violations) {
+		t.Fatalf("ValidateInvariants() error type = %T, want ValidationErrors", err)
+	}
+
+	wantFields := map[string]ErrorCode{
+		"project_name":   ErrInvalidProjectName,
+		"binary_name":    ErrInvalidBinaryName,
+		"platforms[1]":   ErrInvalidPlatform,
+		"signing_level":  ErrInvalidSigningLevel,
+		"docker_support": ErrDockerNotSupported,
+	}
+	for field, code := range wantFields {
+		found := false
+		for _, violation := range violations {
+			if violation.Field == field && violation.Code == code {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("missing violation %s for field %s in %v", code, field, violations)
+		}
+	}
+
+	if !IsErrorCode(err, ErrInvalidSigningLevel) {
+		t.Error("IsErrorCode() should find codes inside the aggregate")
+	}
+}
+
+func TestViolationsMainPathRequirement(t *testing.T) {
+	tests := []struct {
+		name        string
+		projectType ProjectType
+		wantErr     bool
+	}{
+		{"CLI requires main path", ProjectTypeCLI, true},
+		{"Library does not", ProjectTypeLibrary, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validTestConfig()
+			config.ProjectType = tt.projectType
+			config.MainPath = ""
+
+			found := false
+			for _, violation := range config.Violations() {
+				if violation.Field == "main_path" {
+					found = true
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("main_path violation = %v, want %v", found, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidationUseCaseMatchesInvariants(t *testing.T) {
+	config := validTestConfig()
+	config.ProjectName = ""
+	config.Architectures = nil
+
+	useCase := NewValidationUseCase(nopLogger{}, nil)
+	result, err := useCase.ValidateConfiguration(t.Context(), config)
+	if err != nil {
+		t.Fatalf("ValidateConfiguration() error = %v", err)
+	}
+
+	invariants := config.Violations()
+	if len(result.Errors) != len(invariants) {
+		t.Fatalf("ValidateConfiguration() reported %d errors, invariants report %d", len(result.Errors), len(invariants))
+	}
+	for i := range invariants {
+		if result.Errors[i].Code != invariants[i].Code || result.Errors[i].Field != invariants[i].Field {
+			t.Errorf("error %d = %s/%s, want %s/%s", i, result.Errors[i].Code, result.Errors[i].Field, invariants[i].Code, invariants[i].Field)
+		}
+	}
+	if result.IsValid {
+		t.Error("ValidateConfiguration() IsValid = true, want false")
+	}
+}
+
+func TestViolationsDockerRegistryURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry DockerRegistry
+		image    string
+		wantErr  bool
+	}{
+		{"GHCR image with owner", DockerRegistryGitHub, "acme/my-app", false},
+		{"GHCR image with nested path", DockerRegistryGitHub, "acme/tools/my-app", true},
+		{"Docker Hub image with user", DockerRegistryDockerHub, "acme/my-app", false},
+		{"Docker Hub user with invalid characters", DockerRegistryDockerHub, "-acme/my-app", true},
+		{"Quay image with owner", DockerRegistryQuay, "acme/my-app", false},
+		{"image without namespace", DockerRegistryGitHub, "my-app", false},
+		{"custom registry", DockerRegistryCustom, "acme/tools/my-app", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validTestConfig()
+			config.DockerSupport = DockerSupportBoth
+			config.DockerRegistry = tt.registry
+			config.DockerImage = tt.image
+
+			found := false
+			for _, violation := range config.Violations() {
+				if violation.Field == "docker_registry" {
+					found = violation.Code == ErrInvalidURLPattern
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("docker_registry violation = %v, want %v in %v", found, tt.wantErr, config.Violations())
+			}
+		})
+	}
+}
+
+func TestViolationsStateAllowsActions(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       ConfigState
+		actionLevel ActionLevel
+		wantErr     bool
+	}{
+		{"draft with actions", ConfigStateDraft, ActionLevelBasic, false},
+		{"valid with actions", ConfigStateValid, ActionLevelBasic, false},
+		{"generated with actions", ConfigStateGenerated, ActionLevelBasic, false},
+		{"invalid with actions", ConfigStateInvalid, ActionLevelBasic, true},
+		{"invalid without actions", ConfigStateInvalid, ActionLevelNone, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validTestConfig()
+			config.State = tt.state
+			config.ActionLevel = tt.actionLevel
+
+			found := false
+			for _, violation := range config.Violations() {
+				if violation.Field == "state" && violation.Code == ErrInvalidStateTransition {
+					found = true
+				}
+			}
+			if found != tt.wantErr {
+				t.Errorf("state violation = %v, want %v", found, tt.wantErr)
+			}
+		})
+	}
+}
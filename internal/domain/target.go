@@ -0,0 +1,91 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:generate sh -c "go tool dist list -json > platforms.json"
+
+// distList is the output of 'go tool dist list -json': every GOOS/GOARCH
+// pair the Go toolchain can build for
+//
+//go:embed platforms.json
+var distList []byte
+
+// Target is a platform and architecture pair the Go toolchain can build for
+type Target struct {
+	Platform     Platform     `json:"GOOS"`
+	Architecture Architecture `json:"GOARCH"`
+	// CGOSupported reports whether cgo can be used for the target
+	CGOSupported bool `json:"CgoSupported"`
+	// FirstClass targets block Go releases when broken and have builders
+	FirstClass bool `json:"FirstClass"`
+}
+
+// String returns the target as "platform/architecture"
+func (t Target) String() string {
+	return string(t.Platform) + "/" + string(t.Architecture)
+}
+
+// RequiresCGO reports whether the target only links externally, so it
+// cannot be built with CGO disabled
+func (t Target) RequiresCGO() bool {
+	switch t.Platform {
+	case PlatformIOS:
+		return true
+	case PlatformAndroid:
+		return t.Architecture != ArchitectureARM64
+	default:
+		return false
+	}
+}
+
+// knownTargets holds the toolchain matrix; knownPlatforms and
+// knownArchitectures hold the values that occur in it, first-class ones first
+var knownTargets, knownPlatforms, knownArchitectures = loadTargets()
+
+func loadTargets() ([]Target, []Platform, []Architecture) {
+	var all []Target
+	if err := json.Unmarshal(distList, &all); err != nil {
+		panic(fmt.Sprintf("invalid embedded platform matrix: %v", err))
+	}
+
+	var platforms []Platform
+	var architectures []Architecture
+	seenPlatforms := make(map[Platform]bool)
+	seenArchitectures := make(map[Architecture]bool)
+	for _, firstClass := range []bool{true, false} {
+		for _, target := range all {
+			if target.FirstClass != firstClass {
+				continue
+			}
+			if !seenPlatforms[target.Platform] {
+				seenPlatforms[target.Platform] = true
+				platforms = append(platforms, target.Platform)
+			}
+			if !seenArchitectures[target.Architecture] {
+				seenArchitectures[target.Architecture] = true
+				architectures = append(architectures, target.Architecture)
+			}
+		}
+	}
+	return all, platforms, architectures
+}
+
+// GetAllTargets returns every target of the Go toolchain
+func GetAllTargets() []Target {
+	return append([]Target(nil), knownTargets...)
+}
+
+// LookupTarget returns the target for a platform and architecture, and
+// whether the Go toolchain can build for it
+func LookupTarget(platform Platform, arch Architecture) (Target, bool) {
+	for _, target := range knownTargets {
+		if target.Platform == platform && target.Architecture == arch {
+			return target, true
+		}
+	}
+	return Target{}, false
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlatformMatrix(t *testing.T) {
+	supported := []string{
+		"linux/riscv64", "linux/ppc64le", "linux/s390x", "linux/loong64", "linux/mips64le",
+		"js/wasm", "wasip1/wasm", "android/arm64", "ios/arm64", "illumos/amd64",
+		"solaris/amd64", "aix/ppc64", "plan9/amd64", "freebsd/arm", "windows/arm64",
+	}
+	for _, name := range supported {
+		found := false
+		for _, target := range GetAllTargets() {
+			if target.String() == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s is missing from the platform matrix", name)
+		}
+	}
+
+	for _, pair := range [][2]string{{"darwin", "386"}, {"windows", "riscv64"}, {"js", "amd64"}} {
+		if _, exists := LookupTarget(Platform(pair[0]), Architecture(pair[1])); exists {
+			t.Errorf("%s/%s is not a Go target", pair[0], pair[1])
+		}
+	}
+
+	target, _ := LookupTarget(PlatformLinux, ArchitectureAMD64)
+	if !target.FirstClass || !target.CGOSupported {
+		t.Errorf("linux/amd64 = %+v, want first-class with CGO", target)
+	}
+	target, _ = LookupTarget(PlatformJS, ArchitectureWASM)
+	if target.FirstClass || target.CGOSupported {
+		t.Errorf("js/wasm = %+v, want secondary without CGO", target)
+	}
+
+	for _, pair := range [][2]string{{"ios", "arm64"}, {"android", "arm"}} {
+		if target, _ := LookupTarget(Platform(pair[0]), Architecture(pair[1])); !target.RequiresCGO() {
+			t.Errorf("%s cannot be built without CGO", target)
+		}
+	}
+	for _, pair := range [][2]string{{"android", "arm64"}, {"linux", "arm"}} {
+		if target, _ := LookupTarget(Platform(pair[0]), Architecture(pair[1])); target.RequiresCGO() {
+			t.Errorf("%s builds without CGO", target)
+		}
+	}
+}
+
+func TestPlatformsFromMatrix(t *testing.T) {
+	platforms := GetAllPlatforms()
+	if !reflect.DeepEqual(platforms[:3], []Platform{PlatformDarwin, PlatformLinux, PlatformWindows}) {
+		t.Errorf("GetAllPlatforms() = %v, want the first-class platforms first", platforms)
+	}
+	for _, platform := range platforms {
+		if platform.String() == string(platform) && platform != PlatformIllumos {
+			t.Errorf("%s has no display name", platform)
+		}
+	}
+	for _, arch := range GetAllArchitectures() {
+		if arch.String() == string(arch) {
+			t.Errorf("%s has no display name", arch)
+		}
+	}
+
+	if !PlatformPlan9.IsValid() || PlatformPlan9.SupportsCGO() || PlatformPlan9.IsUnixLike() {
+		t.Error("plan9 is valid, has no CGO and is not Unix-like")
+	}
+	if !ArchitectureRISCV64.Is64Bit() || ArchitectureMIPSLE.Is64Bit() {
+		t.Error("Is64Bit() misclassifies riscv64 or mipsle")
+	}
+	if !ArchitectureARM64.SupportedByAllPlatforms() || Architecture386.SupportedByAllPlatforms() {
+		t.Error("SupportedByAllPlatforms() should follow the first-class platforms")
+	}
+}
+
+func TestValidatePlatformArchCompatibility(t *testing.T) {
+	if err := ValidatePlatformArchCompatibility([]Platform{PlatformLinux}, []Architecture{ArchitectureRISCV64, ArchitectureLoong64}); err != nil {
+		t.Errorf("linux/riscv64 and linux/loong64 rejected: %v", err)
+	}
+	if err := ValidatePlatformArchCompatibility([]Platform{PlatformLinux, PlatformDarwin}, []Architecture{Architecture386}); err == nil {
+		t.Error("darwin/386 accepted")
+	}
+}
+
+func TestCGORequiresSupportingTargets(t *testing.T) {
+	config := validTestConfig()
+	config.CGOStatus = CGOStatusRequired
+	config.Platforms = []Platform{PlatformLinux, PlatformJS}
+	config.Architectures = []Architecture{ArchitectureAMD64, ArchitectureWASM}
+
+	var codes []ErrorCode
+	for _, violation := range config.Violations() {
+		codes = append(codes, violation.Code)
+	}
+	// linux/wasm and js/amd64 do not exist; js/wasm has no CGO
+	expected := []ErrorCode{ErrCGONotSupported, ErrPlatformArchMismatch, ErrPlatformArchMismatch}
+	if !reflect.DeepEqual(codes, expected) {
+		t.Errorf("violations = %v, want %v", codes, expected)
+	}
+}
@@ -21,47 +21,15 @@ func NewValidationUseCase(logger Logger, repo FileSystemRepository) *ValidationU
 	}
 }
 
-// ValidateConfiguration performs comprehensive validation of project configuration
+// ValidateConfiguration performs comprehensive validation of project configuration.
+// Errors are exactly the invariant violations reported by SafeProjectConfig.Violations,
+// so every surface (CLI, answers file loader, use case) reports identical results.
 func (vu *ValidationUseCase) ValidateConfiguration(ctx context.Context, config *SafeProjectConfig) (*ValidationResult, error) {
 	vu.logger.DebugContext(ctx, "Starting comprehensive configuration validation")
 	
-	result := &ValidationResult{
-		IsValid:  true,
-		Errors:   []*DomainError{},
-		Warnings: []*DomainError{},
-	}
+	result := NewValidationResult(config.Violations())
 	
-	// Step 1: Basic field validation
-	if err := vu.validateBasicFields(ctx, config); err != nil {
-		result.Errors = append(result.Errors, err)
-		result.IsValid = false
-	}
-	
-	// Step 2: Type validation
-	if err := vu.validateTypes(ctx, config); err != nil {
-		result.Errors = append(result.Errors, err)
-		result.IsValid = false
-	}
-	
-	// Step 3: Platform-architecture compatibility
-	if err := vu.validatePlatformArchCompatibility(ctx, config); err != nil {
-		result.Errors = append(result.Errors, err)
-		result.IsValid = false
-	}
-	
-	// Step 4: Business rule validation
-	if err := vu.validateBusinessRules(ctx, config); err != nil {
-		result.Errors = append(result.Errors, err)
-		result.IsValid = false
-	}
-	
-	// Step 5: Security validation
-	if err := vu.validateSecurity(ctx, config); err != nil {
-		result.Errors = append(result.Errors, err)
-		result.IsValid = false
-	}
-	
-	// Step 6: Generate warnings
+	// Generate warnings
 	vu.generateWarnings(ctx, config, result)
 	
 	vu.logger.DebugContext(ctx, "Validation completed", "valid", result.IsValid, "errors", len(result.Errors), "warnings", len(result.Warnings))
@@ -69,137 +37,6 @@ func (vu *ValidationUseCase) ValidateConfiguration(ctx context.Context, config *
 	return result, nil
 }
 
-// validateBasicFields validates basic required fields
-func (vu *ValidationUseCase) validateBasicFields(ctx context.Context, config *SafeProjectConfig) *DomainError {
-	// Project name validation
-	if err := ValidateProjectName(config.ProjectName); err != nil {
-		return NewValidationError(ErrInvalidProjectName, "Project name validation failed", err.Error()).WithContext("project_name")
-	}
-	
-	// Binary name validation
-	if err := ValidateBinaryName(config.BinaryName); err != nil {
-		return NewValidationError(ErrInvalidBinaryName, "Binary name validation failed", err.Error()).WithContext("binary_name")
-	}
-	
-	// Main path validation
-	if err := ValidateMainPath(config.MainPath); err != nil {
-		return NewValidationError(ErrInvalidMainPath, "Main path validation failed", err.Error()).WithContext("main_path")
-	}
-	
-	// Project description validation (optional)
-	if config.ProjectDescription != "" {
-		if err := ValidateProjectDescription(config.ProjectDescription); err != nil {
-			return NewValidationError(ErrInvalidProjectDescription, "Project description validation failed", err.Error()).WithContext("project_description")
-		}
-	}
-	
-	return nil
-}
-
-// validateTypes validates enum types
-func (vu *ValidationUseCase) validateTypes(ctx context.Context, config *SafeProjectConfig) *DomainError {
-	// Project type validation
-	if !config.ProjectType.IsValid() {
-		return NewValidationError(ErrInvalidProjectName, "Invalid project type", fmt.Sprintf("'%s' is not a supported project type", config.ProjectType)).WithContext("project_type")
-	}
-	
-	// Platform validation
-	if err := ValidatePlatforms(config.Platforms); err != nil {
-		return NewValidationError(ErrInvalidPlatform, "Platform validation failed", err.Error()).WithContext("platforms")
-	}
-	
-	// Architecture validation
-	if err := ValidateArchitectures(config.Architectures); err != nil {
-		return NewValidationError(ErrInvalidArchitecture, "Architecture validation failed", err.Error()).WithContext("architectures")
-	}
-	
-	// Git provider validation
-	if err := ValidateGitProvider(config.GitProvider); err != nil {
-		return NewValidationError(ErrInvalidGitProvider, "Git provider validation failed", err.Error()).WithContext("git_provider")
-	}
-	
-	// Docker registry validation
-	if err := ValidateDockerRegistry(config.DockerRegistry); err != nil {
-		return NewValidationError(ErrInvalidDockerRegistry, "Docker registry validation failed", err.Error()).WithContext("docker_registry")
-	}
-	
-	// Action triggers validation
-	if err := ValidateActionTriggers(config.ActionsOn); err != nil {
-		return NewValidationError(ErrInvalidActionTrigger, "Action trigger validation failed", err.Error()).WithContext("actions_on")
-	}
-	
-	// Build tags validation
-	if len(config.BuildTags) > 0 {
-		if err := ValidateBuildTags(config.BuildTags); err != nil {
-			return NewValidationError(ErrInvalidBuildTag, "Build tags validation failed", err.Error()).WithContext("build_tags")
-		}
-	}
-	
-	// Configuration state validation
-	if err := ValidateConfigState(config.State); err != nil {
-		return NewValidationError(ErrInvalidConfigState, "Configuration state validation failed", err.Error()).WithContext("state")
-	}
-	
-	return nil
-}
-
-// validatePlatformArchCompatibility validates platform-architecture compatibility
-func (vu *ValidationUseCase) validatePlatformArchCompatibility(ctx context.Context, config *SafeProjectConfig) *DomainError {
-	if err := ValidatePlatformArchCompatibility(config.Platforms, config.Architectures); err != nil {
-		return NewValidationError(ErrPlatformArchMismatch, "Platform architecture compatibility failed", err.Error()).WithContext("platforms_architectures")
-	}
-	return nil
-}
-
-// validateBusinessRules validates domain business rules
-func (vu *ValidationUseCase) validateBusinessRules(ctx context.Context, config *SafeProjectConfig) *DomainError {
-	// Docker support rule
-	if config.GetDockerEnabled() && !config.ProjectType.DockerSupported() {
-		return NewConfigurationError(ErrDockerNotSupported, "Docker not supported for project type", fmt.Sprintf("Project type %s does not support Docker", config.ProjectType)).WithContext("docker_enabled")
-	}
-	
-	// Main path requirement rule
-	if config.ProjectType.RequiresMainPath() && config.MainPath == "" {
-		return NewConfigurationError(ErrMainPathRequired, "Main path required for project type", fmt.Sprintf("Project type %s requires a main path", config.ProjectType)).WithContext("main_path")
-	}
-	
-	// State transition rule
-	if !config.State.AllowsGeneration() && config.GetGenerateActions() {
-		return NewConfigurationError(ErrInvalidStateTransition, "State transition invalid", fmt.Sprintf("Configuration in state '%s' cannot generate actions", config.State)).WithContext("generate_actions")
-	}
-	
-	// Docker registry URL validation
-	if config.GetDockerEnabled() {
-		if err := ValidateDockerRegistryURL(config.DockerRegistry, config.DockerImage); err != nil {
-			return NewValidationError(ErrInvalidURLPattern, "Docker registry URL validation failed", err.Error()).WithContext("docker_image")
-		}
-	}
-	
-	return nil
-}
-
-// validateSecurity performs security-focused validation
-func (vu *ValidationUseCase) validateSecurity(ctx context.Context, config *SafeProjectConfig) *DomainError {
-	// Check for potential path traversal in main path
-	if containsPathTraversal(config.MainPath) {
-		return NewBusinessRuleError(ErrInvalidCharacters, "Path traversal detected", "Main path contains potentially dangerous path traversal sequences").WithContext("main_path")
-	}
-	
-	// Check for shell metacharacters in binary name
-	if containsShellMetacharacters(config.BinaryName) {
-		return NewBusinessRuleError(ErrInvalidCharacters, "Shell metacharacters detected", "Binary name contains potentially dangerous shell metacharacters").WithContext("binary_name")
-	}
-	
-	// Check Docker image name for security issues
-	if config.DockerImage != "" {
-		if containsURLInjection(config.DockerImage) {
-			return NewBusinessRuleError(ErrInvalidCharacters, "URL injection detected", "Docker image name contains potentially dangerous URL injection sequences").WithContext("docker_image")
-		}
-	}
-	
-	return nil
-}
-
 // generateWarnings generates validation warnings
 func (vu *ValidationUseCase) generateWarnings(ctx context.Context, config *SafeProjectConfig, result *ValidationResult) {
 	// Warning for single platform
@@ -225,8 +62,10 @@ func (vu *ValidationUseCase) generateWarnings(ctx context.Context, config *SafeP
 	}
 }
 
-// ValidateProjectStructure validates project directory structure
-func (vu *ValidationUseCase) ValidateProjectStructure(ctx context.Context, projectPath string) (*ProjectValidationResult, error) {
+// ValidateProjectStructure validates project directory structure. The main
+// packages the project's GoReleaser configurations build are checked when
+// given; otherwise main.go is searched in common locations.
+func (vu *ValidationUseCase) ValidateProjectStructure(ctx context.Context, projectPath string, configs ...ReleaseConfig) (*ProjectValidationResult, error) {
 	vu.logger.DebugContext(ctx, "Validating project structure", "path", projectPath)
 	
 	result := &ProjectValidationResult{
@@ -245,7 +84,7 @@ func (vu *ValidationUseCase) ValidateProjectStructure(ctx context.Context, proje
 	}
 	
 	// Analyze project structure
-	info, err := vu.analyzeProjectStructure(ctx, projectPath)
+	info, err := vu.analyzeProjectStructure(ctx, projectPath, configs)
 	if err != nil {
 		return nil, err
 	}
@@ -258,7 +97,7 @@ func (vu *ValidationUseCase) ValidateProjectStructure(ctx context.Context, proje
 	}
 	
 	// Generate recommendations
-	vu.generateProjectRecommendations(ctx, info, result)
+	vu.generateProjectRecommendations(ctx, info, len(configs) > 0, result)
 	
 	vu.logger.DebugContext(ctx, "Project structure validation completed", "valid", result.IsValid, "issues", len(result.Issues))
 	
@@ -266,7 +105,7 @@ func (vu *ValidationUseCase) ValidateProjectStructure(ctx context.Context, proje
 }
 
 // analyzeProjectStructure analyzes the project directory structure
-func (vu *ValidationUseCase) analyzeProjectStructure(ctx context.Context, projectPath string) (*ProjectInfo, error) {
+func (vu *ValidationUseCase) analyzeProjectStructure(ctx context.Context, projectPath string, configs []ReleaseConfig) (*ProjectInfo, error) {
 	info := &ProjectInfo{
 		Path: projectPath,
 	}
@@ -285,14 +124,19 @@ func (vu *ValidationUseCase) analyzeProjectStructure(ctx context.Context, projec
 		}
 	}
 	
-	// Find main.go file
-	mainPath, err := vu.findMainFile(ctx, projectPath)
-	if err != nil {
-		vu.logger.WarnContext(ctx, "Failed to find main.go", "error", err)
-	}
-	if mainPath != "" {
-		info.HasMainFile = true
-		info.MainFilePath = mainPath
+	if len(configs) > 0 {
+		// Check the main packages the configurations build
+		vu.findBuildMains(ctx, projectPath, configs, info)
+	} else {
+		// Find main.go file
+		mainPath, err := vu.findMainFile(ctx, projectPath)
+		if err != nil {
+			vu.logger.WarnContext(ctx, "Failed to find main.go", "error", err)
+		}
+		if mainPath != "" {
+			info.HasMainFile = true
+			info.MainFilePath = mainPath
+		}
 	}
 	
 	// Determine project type and binary name
@@ -347,6 +191,41 @@ func (vu *ValidationUseCase) findMainFile(ctx context.Context, projectPath strin
 	return "", nil
 }
 
+// findBuildMains checks that every main package or file the configurations
+// build exists, recording the first one found and those that are missing
+func (vu *ValidationUseCase) findBuildMains(ctx context.Context, projectPath string, configs []ReleaseConfig, info *ProjectInfo) {
+	for _, config := range configs {
+		for _, main := range config.Mains {
+			if vu.mainExists(ctx, vu.repo.JoinPath(projectPath, main)) {
+				if info.MainFilePath == "" {
+					info.MainFilePath = main
+				}
+				continue
+			}
+			info.MissingMains = append(info.MissingMains, main)
+		}
+	}
+	info.HasMainFile = info.MainFilePath != "" && len(info.MissingMains) == 0
+}
+
+// mainExists reports whether path is a Go file or a directory holding one
+func (vu *ValidationUseCase) mainExists(ctx context.Context, path string) bool {
+	if strings.HasSuffix(path, ".go") {
+		exists, err := vu.repo.FileExists(ctx, path)
+		return err == nil && exists
+	}
+	entries, err := vu.repo.ReadDir(ctx, path)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") && !strings.HasSuffix(entry.Name(), "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
 // inferProjectType infers project type and binary name from structure
 func (vu *ValidationUseCase) inferProjectType(ctx context.Context, info *ProjectInfo) {
 	// Infer binary name from module name
@@ -382,6 +261,9 @@ func (vu *ValidationUseCase) validateProjectRequirements(ctx context.Context, in
 		return NewSystemError(ErrDependencyNotFound, "Go module not found", "Project must have a go.mod file", nil)
 	}
 	
+	if len(info.MissingMains) > 0 {
+		return NewSystemError(ErrFileNotFound, "Main file not found", fmt.Sprintf("No Go files at the build main %s", strings.Join(info.MissingMains, ", ")), nil)
+	}
 	if !info.HasMainFile {
 		return NewSystemError(ErrFileNotFound, "Main file not found", "Project must have a main.go file for compilation", nil)
 	}
@@ -390,7 +272,7 @@ func (vu *ValidationUseCase) validateProjectRequirements(ctx context.Context, in
 }
 
 // generateProjectRecommendations generates recommendations for project improvement
-func (vu *ValidationUseCase) generateProjectRecommendations(ctx context.Context, info *ProjectInfo, result *ProjectValidationResult) {
+func (vu *ValidationUseCase) generateProjectRecommendations(ctx context.Context, info *ProjectInfo, hasConfig bool, result *ProjectValidationResult) {
 	// Recommendation for missing GitHub Actions
 	if _, err := vu.repo.DirExists(ctx, vu.repo.JoinPath(info.Path, ".github", "workflows")); err != nil {
 		result.Recommendations = append(result.Recommendations, "Add GitHub Actions workflow for automated builds and releases")
@@ -402,7 +284,7 @@ func (vu *ValidationUseCase) generateProjectRecommendations(ctx context.Context,
 	}
 	
 	// Recommendation for missing GoReleaser config
-	if _, err := vu.repo.FileExists(ctx, vu.repo.JoinPath(info.Path, ".goreleaser.yaml")); err != nil {
+	if _, err := vu.repo.FileExists(ctx, vu.repo.JoinPath(info.Path, ".goreleaser.yaml")); err != nil && !hasConfig {
 		result.Recommendations = append(result.Recommendations, "Add .goreleaser.yaml configuration for automated releases")
 	}
 	
@@ -413,10 +295,6 @@ func (vu *ValidationUseCase) generateProjectRecommendations(ctx context.Context,
 }
 
 // Utility functions for security validation
-func containsPathTraversal(path string) bool {
-	return strings.Contains(path, "..") || strings.Contains(path, `\`)
-}
-
 func containsShellMetacharacters(value string) bool {
 	shellMetachars := []string{"|", "&", ";", "<", ">", "`", "$", "(", ")", "{", "}"}
 	for _, char := range shellMetachars {
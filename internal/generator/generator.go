@@ -0,0 +1,441 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/validation"
+)
+
+//go:embed templates/*.tmpl
+var templateFS embed.FS
+
+// Well-known artifact paths, relative to the project root
+const (
+	GoReleaserConfigPath = ".goreleaser.yaml"
+	WorkflowPath         = ".github/workflows/release.yml"
+	DockerfilePath       = "Dockerfile"
+)
+
+// ArtifactKind identifies a generated file type
+type ArtifactKind string
+
+const (
+	ArtifactGoReleaserConfig ArtifactKind = "goreleaser"
+	ArtifactWorkflow         ArtifactKind = "workflow"
+	ArtifactDockerfile       ArtifactKind = "dockerfile"
+)
+
+// GetAllArtifactKinds returns all artifact kinds in generation order
+func GetAllArtifactKinds() []ArtifactKind {
+	return []ArtifactKind{ArtifactGoReleaserConfig, ArtifactWorkflow, ArtifactDockerfile}
+}
+
+// IsValid returns true if ArtifactKind is valid
+func (ak ArtifactKind) IsValid() bool {
+	switch ak {
+	case ArtifactGoReleaserConfig, ArtifactWorkflow, ArtifactDockerfile:
+		return true
+	default:
+		return false
+	}
+}
+
+// Path returns the default path of the artifact relative to the project root
+func (ak ArtifactKind) Path() string {
+	switch ak {
+	case ArtifactGoReleaserConfig:
+		return GoReleaserConfigPath
+	case ArtifactWorkflow:
+		return WorkflowPath
+	case ArtifactDockerfile:
+		return DockerfilePath
+	default:
+		return ""
+	}
+}
+
+// Artifact is a single rendered file
+type Artifact struct {
+	Kind    ArtifactKind
+	Path    string // slash-separated, relative to the project root
+	Content string
+}
+
+// Generator renders release artifacts from a SafeProjectConfig.
+// It implements domain.GenerationUseCase.
+type Generator struct {
+	templates *template.Template
+}
+
+var _ domain.GenerationUseCase = (*Generator)(nil)
+
+// New creates a generator backed by the embedded templates
+func New() *Generator {
+	templates := template.Must(
+		template.New("").
+			Delims("[[", "]]").
+			ParseFS(templateFS, "templates/*.tmpl"),
+	)
+	return &Generator{templates: templates}
+}
+
+// GenerateGoReleaserConfig renders .goreleaser.yaml
+func (g *Generator) GenerateGoReleaserConfig(ctx context.Context, config *domain.SafeProjectConfig) (string, error) {
+	return g.render(ctx, "goreleaser.yaml.tmpl", config)
+}
+
+// GenerateGitHubActions renders the release workflow
+func (g *Generator) GenerateGitHubActions(ctx context.Context, config *domain.SafeProjectConfig) (string, error) {
+	return g.render(ctx, "release.yml.tmpl", config)
+}
+
+// GenerateDockerfile renders the runtime Dockerfile used by GoReleaser's dockers pipe
+func (g *Generator) GenerateDockerfile(ctx context.Context, config *domain.SafeProjectConfig) (string, error) {
+	return g.render(ctx, "Dockerfile.tmpl", config)
+}
+
+// Generate renders a single artifact kind
+func (g *Generator) Generate(ctx context.Context, config *domain.SafeProjectConfig, kind ArtifactKind) (*Artifact, error) {
+	var content string
+	var err error
+
+	switch kind {
+	case ArtifactGoReleaserConfig:
+		content, err = g.GenerateGoReleaserConfig(ctx, config)
+	case ArtifactWorkflow:
+		content, err = g.GenerateGitHubActions(ctx, config)
+	case ArtifactDockerfile:
+		content, err = g.GenerateDockerfile(ctx, config)
+	default:
+		return nil, domain.NewValidationError(
+			domain.ErrTemplateNotFound,
+			"Unknown artifact",
+			fmt.Sprintf("'%s' is not a known artifact (expected one of %v)", kind, GetAllArtifactKinds()),
+		)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Artifact{Kind: kind, Path: kind.Path(), Content: content}, nil
+}
+
+// Artifacts renders every artifact the configuration asks for, in generation order
+func (g *Generator) Artifacts(ctx context.Context, config *domain.SafeProjectConfig) ([]Artifact, error) {
+	artifacts := []Artifact{}
+	for _, kind := range Planned(config) {
+		artifact, err := g.Generate(ctx, config, kind)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, *artifact)
+	}
+	return artifacts, nil
+}
+
+// GenerateAll renders every artifact and writes it below outputPath
+func (g *Generator) GenerateAll(ctx context.Context, config *domain.SafeProjectConfig, outputPath string) error {
+	artifacts, err := g.Artifacts(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	for _, artifact := range artifacts {
+		if err := WriteArtifact(outputPath, artifact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Planned returns the artifact kinds the configuration will produce
+func Planned(config *domain.SafeProjectConfig) []ArtifactKind {
+	kinds := []ArtifactKind{ArtifactGoReleaserConfig}
+	if config.ShouldGenerateActionsFiles() {
+		kinds = append(kinds, ArtifactWorkflow)
+	}
+	if config.ShouldGenerateDockerFiles() {
+		kinds = append(kinds, ArtifactDockerfile)
+	}
+	return kinds
+}
+
+// WriteArtifact writes an artifact below root, creating parent directories
+func WriteArtifact(root string, artifact Artifact) error {
+	path := filepath.Join(root, filepath.FromSlash(artifact.Path))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return domain.NewSystemError(
+			domain.ErrDirectoryCreateFailed,
+			"Failed to create directory",
+			fmt.Sprintf("Cannot create %s", filepath.Dir(path)),
+			err,
+		).WithContext(path)
+	}
+	if err := os.WriteFile(path, []byte(artifact.Content), 0644); err != nil {
+		return domain.FileWriteFailedError(path, err)
+	}
+	return nil
+}
+
+func (g *Generator) render(ctx context.Context, name string, config *domain.SafeProjectConfig) (string, error) {
+	if ctx.Err() != nil {
+		return "", ctx.Err()
+	}
+	if config.ProjectName == "" || config.BinaryName == "" {
+		return "", domain.NewValidationError(
+			domain.ErrMissingRequiredField,
+			"Missing required field",
+			"project_name and binary_name are required for generation",
+		)
+	}
+
+	var buf bytes.Buffer
+	if err := g.templates.ExecuteTemplate(&buf, name, newTemplateData(config)); err != nil {
+		return "", domain.TemplateExecutionFailedError(name, err)
+	}
+	return buf.String(), nil
+}
+
+// IgnoreRule is a goos/goarch pair excluded from the build matrix.
+// Values are raw GOOS/GOARCH strings since the domain String methods return display names.
+type IgnoreRule struct {
+	Platform     string `json:"goos"`
+	Architecture string `json:"goarch"`
+	// Reason explains the rule; it is not rendered
+	Reason string `json:"reason"`
+}
+
+// buildVariant is a GoReleaser build key such as goarm and the
+// micro-architecture variants it builds
+type buildVariant struct {
+	Key    string
+	Values []string
+}
+
+// variantNameTemplates append the variant to archive names the way
+// GoReleaser's default name template does, leaving the default variant out
+var variantNameTemplates = map[string]string{
+	"goamd64":  `{{- if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`,
+	"goarm":    `{{- with .Arm }}v{{ . }}{{ end }}`,
+	"goarm64":  `{{- if and .Arm64 (ne .Arm64 "v8.0") }}_{{ .Arm64 }}{{ end }}`,
+	"go386":    `{{- if and .I386 (ne .I386 "sse2") }}_{{ .I386 }}{{ end }}`,
+	"gomips":   `{{- with .Mips }}_{{ . }}{{ end }}`,
+	"gomips64": `{{- with .Mips }}_{{ . }}{{ end }}`,
+}
+
+// dockerTarget is a linux architecture that gets a container image
+type dockerTarget struct {
+	Architecture string
+	// Amd64 is the GOAMD64 variant of the binary in an amd64 image
+	Amd64 string
+}
+
+// triggers groups the workflow triggers by GitHub Actions event
+type triggers struct {
+	Tags     []string
+	Branches []string
+	Release  bool
+	Manual   bool
+}
+
+// templateData is the view model shared by all templates
+type templateData struct {
+	Config          *domain.SafeProjectConfig
+	MainPath        string
+	Description     string
+	Goos            []string
+	Goarch          []string
+	Ignore          []IgnoreRule
+	Variants        []buildVariant
+	NameVariants    []string
+	Amd64           string
+	HasWindows      bool
+	IsGitHub        bool
+	IsGitLab        bool
+	Dockers         []dockerTarget
+	PublishImages   bool
+	ImageRepository string
+	RegistryHost    string
+	IsGHCR          bool
+	Sign            bool
+	Advanced        bool
+	Triggers        triggers
+}
+
+func newTemplateData(config *domain.SafeProjectConfig) templateData {
+	data := templateData{
+		Config:   config,
+		MainPath: config.MainPath,
+		IsGitHub: config.GitProvider == domain.GitProviderGitHub,
+		IsGitLab: config.GitProvider == domain.GitProviderGitLab,
+		Sign:     config.ShouldSignReleases(),
+		Advanced: config.ActionLevel == domain.ActionLevelAdvanced,
+		Triggers: newTriggers(config.ActionsOn),
+	}
+
+	if data.MainPath == "" {
+		data.MainPath = "."
+	}
+	if config.ProjectDescription != "" {
+		data.Description = quoteYAML(config.ProjectDescription)
+	}
+
+	for _, arch := range config.Architectures {
+		data.Goarch = append(data.Goarch, string(arch))
+	}
+	for _, platform := range config.Platforms {
+		data.Goos = append(data.Goos, string(platform))
+		if platform == domain.PlatformWindows {
+			data.HasWindows = true
+		}
+	}
+	data.Ignore = ignoreRules(config)
+
+	data.Variants, data.NameVariants = newBuildVariants(config.ArchitectureVariants)
+	for _, variant := range config.ArchitectureVariants {
+		// Images and formulas take a single binary; use the first variant
+		if variant.Architecture == domain.ArchitectureAMD64 && len(variant.Variants) > 0 {
+			data.Amd64 = variant.Variants[0]
+		}
+	}
+
+	if config.ShouldGenerateDockerFiles() && hasPlatform(config.Platforms, domain.PlatformLinux) {
+		for _, arch := range config.Architectures {
+			if arch == domain.ArchitectureAMD64 || arch == domain.ArchitectureARM64 {
+				target := dockerTarget{Architecture: string(arch)}
+				if arch == domain.ArchitectureAMD64 {
+					target.Amd64 = data.Amd64
+				}
+				data.Dockers = append(data.Dockers, target)
+			}
+		}
+		data.PublishImages = len(data.Dockers) > 0 && config.DockerSupport.ShouldPublish()
+		data.RegistryHost, data.ImageRepository = imageRepository(config)
+		data.IsGHCR = config.DockerRegistry == domain.DockerRegistryGitHub
+	}
+
+	return data
+}
+
+// ignoreRules excludes the selected platform and architecture pairs the Go
+// toolchain cannot build, either at all or with CGO disabled
+func ignoreRules(config *domain.SafeProjectConfig) []IgnoreRule {
+	var rules []IgnoreRule
+	for _, platform := range config.Platforms {
+		for _, arch := range config.Architectures {
+			rule := IgnoreRule{Platform: string(platform), Architecture: string(arch)}
+			target, exists := domain.LookupTarget(platform, arch)
+			switch {
+			case !exists:
+				rule.Reason = "not a Go target"
+			case target.RequiresCGO() && !config.CGOStatus.IsEnabled():
+				rule.Reason = "needs CGO, which is disabled"
+			default:
+				continue
+			}
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// newBuildVariants groups the variants by build key, since architectures
+// such as mips and mipsle share one, and returns the archive name templates
+// that tell the variants apart
+func newBuildVariants(variants []domain.ArchitectureVariant) ([]buildVariant, []string) {
+	var builds []buildVariant
+	var names []string
+	index := make(map[string]int)
+	for _, variant := range variants {
+		option, exists := variant.Architecture.VariantOption()
+		if !exists {
+			continue
+		}
+		i, seen := index[option.BuildKey]
+		if !seen {
+			i = len(builds)
+			index[option.BuildKey] = i
+			builds = append(builds, buildVariant{Key: option.BuildKey})
+			names = appendUnique(names, variantNameTemplates[option.BuildKey])
+		}
+		for _, value := range variant.Variants {
+			builds[i].Values = appendUnique(builds[i].Values, value)
+		}
+	}
+	return builds, names
+}
+
+// imageRepository returns the registry host and the image repository without tag
+func imageRepository(config *domain.SafeProjectConfig) (string, string) {
+	image := config.GetDockerImageName()
+
+	var host, namespace string
+	switch config.DockerRegistry {
+	case domain.DockerRegistryCustom:
+		host = "${{ secrets.DOCKER_REGISTRY }}"
+		return host, "{{ .Env.DOCKER_REGISTRY }}/" + image
+	case domain.DockerRegistryDockerHub:
+		host = "docker.io"
+		namespace = "{{ .Env.DOCKER_USERNAME }}"
+	default:
+		host = string(config.DockerRegistry)
+		namespace = "{{ .Env.GITHUB_OWNER }}"
+	}
+
+	if strings.Contains(image, "/") {
+		return host, host + "/" + image
+	}
+	return host, host + "/" + namespace + "/" + image
+}
+
+func newTriggers(actionTriggers []domain.ActionTrigger) triggers {
+	t := triggers{}
+	for _, trigger := range actionTriggers {
+		switch trigger {
+		case domain.ActionTriggerVersionTags:
+			t.Tags = appendUnique(t.Tags, "v*")
+		case domain.ActionTriggerAllTags:
+			t.Tags = appendUnique(t.Tags, "*")
+		case domain.ActionTriggerMain:
+			t.Branches = appendUnique(t.Branches, "main")
+		case domain.ActionTriggerRelease:
+			t.Release = true
+		case domain.ActionTriggerManual:
+			t.Manual = true
+		}
+	}
+	return t
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+func hasPlatform(platforms []domain.Platform, platform domain.Platform) bool {
+	for _, p := range platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteYAML renders free text as a single-line, single-quoted YAML scalar
+func quoteYAML(value string) string {
+	value = validation.SanitizeInput(value)
+	value = strings.Join(strings.Fields(value), " ")
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,205 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+func testConfig() *domain.SafeProjectConfig {
+	config := domain.NewSafeProjectConfig()
+	config.ProjectName = "test-app"
+	config.ProjectDescription = "A test: application"
+	config.BinaryName = "test-app"
+	config.MainPath = "./cmd/test-app"
+	config.Platforms = []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin, domain.PlatformWindows}
+	config.Architectures = []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64, domain.Architecture386}
+	config.ActionsOn = []domain.ActionTrigger{domain.ActionTriggerVersionTags, domain.ActionTriggerManual}
+	return config
+}
+
+func TestGenerateGoReleaserConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*domain.SafeProjectConfig)
+		checks []string
+		absent []string
+	}{
+		{
+			name: "basic_config",
+			checks: []string{
+				"version: 2",
+				"project_name: test-app",
+				"main: ./cmd/test-app",
+				"- CGO_ENABLED=0",
+				"- goos: darwin\n        goarch: \"386\"",
+				"formats: [zip]",
+				`owner: "{{ .Env.GITHUB_OWNER }}"`,
+			},
+			absent: []string{"dockers:", "signs:", "brews:", "sboms:", "goamd64", ".Arm"},
+		},
+		{
+			name: "docker_publish",
+			modify: func(c *domain.SafeProjectConfig) {
+				c.DockerSupport = domain.DockerSupportBoth
+				c.DockerRegistry = domain.DockerRegistryGitHub
+			},
+			checks: []string{
+				"dockers:",
+				"goarch: amd64",
+				"goarch: arm64",
+				"ghcr.io/{{ .Env.GITHUB_OWNER }}/test-app:{{ .Tag }}-amd64",
+				"docker_manifests:",
+			},
+			absent: []string{"skip_push"},
+		},
+		{
+			name: "docker_build_only",
+			modify: func(c *domain.SafeProjectConfig) {
+				c.DockerSupport = domain.DockerSupportBuild
+				c.DockerRegistry = domain.DockerRegistryDockerHub
+			},
+			checks: []string{"skip_push: true"},
+			absent: []string{"docker_manifests:"},
+		},
+		{
+			name: "signing_sbom_homebrew_snap",
+			modify: func(c *domain.SafeProjectConfig) {
+				c.SigningLevel = domain.SigningLevelAdvanced
+				c.SBOM = true
+				c.Homebrew = true
+				c.Snap = true
+			},
+			checks: []string{"signs:", "cmd: cosign", "sboms:", "brews:", "directory: Formula", "description: 'A test: application'", "snapcrafts:"},
+		},
+		{
+			name: "cgo_and_tags",
+			modify: func(c *domain.SafeProjectConfig) {
+				c.CGOStatus = domain.CGOStatusEnabled
+				c.BuildTags = []domain.BuildTag{{Name: "netgo"}}
+			},
+			checks: []string{"- CGO_ENABLED=1", "tags:\n      - netgo"},
+		},
+		{
+			name: "architecture_variants",
+			modify: func(c *domain.SafeProjectConfig) {
+				c.Architectures = []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM, domain.ArchitectureMIPS, domain.ArchitectureMIPSLE}
+				c.ArchitectureVariants = []domain.ArchitectureVariant{
+					{Architecture: domain.ArchitectureAMD64, Variants: []string{"v3"}},
+					{Architecture: domain.ArchitectureARM, Variants: []string{"6", "7"}},
+					{Architecture: domain.ArchitectureMIPS, Variants: []string{"softfloat"}},
+					{Architecture: domain.ArchitectureMIPSLE, Variants: []string{"softfloat"}},
+				}
+				c.Homebrew = true
+			},
+			checks: []string{
+				"goamd64:\n      - \"v3\"",
+				"goarm:\n      - \"6\"\n      - \"7\"",
+				"gomips:\n      - \"softfloat\"\n",
+				`{{- if not (eq .Amd64 "v1") }}{{ .Amd64 }}{{ end }}`,
+				"{{- with .Arm }}v{{ . }}{{ end }}",
+				"directory: Formula\n    goamd64: v3",
+			},
+			absent: []string{"goarm64:", "{{ .Arm64 }}", "gomips:\n      - \"softfloat\"\n      - \"softfloat\""},
+		},
+	}
+
+	g := New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := testConfig()
+			if tt.modify != nil {
+				tt.modify(config)
+			}
+
+			content, err := g.GenerateGoReleaserConfig(context.Background(), config)
+			if err != nil {
+				t.Fatalf("GenerateGoReleaserConfig() error = %v", err)
+			}
+
+			var parsed map[string]any
+			if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+				t.Fatalf("generated config is not valid YAML: %v\n%s", err, content)
+			}
+
+			for _, check := range tt.checks {
+				if !strings.Contains(content, check) {
+					t.Errorf("generated config missing %q", check)
+				}
+			}
+			for _, check := range tt.absent {
+				if strings.Contains(content, check) {
+					t.Errorf("generated config unexpectedly contains %q", check)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateGitHubActions(t *testing.T) {
+	config := testConfig()
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryGitHub
+	config.SigningLevel = domain.SigningLevelBasic
+
+	content, err := New().GenerateGitHubActions(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GenerateGitHubActions() error = %v", err)
+	}
+
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("generated workflow is not valid YAML: %v\n%s", err, content)
+	}
+
+	for _, check := range []string{
+		"- 'v*'",
+		"workflow_dispatch:",
+		"packages: write",
+		"id-token: write",
+		"fetch-depth: 0",
+		"Login to Docker Registry",
+		"Install Cosign",
+		"uses: goreleaser/goreleaser-action@v6",
+	} {
+		if !strings.Contains(content, check) {
+			t.Errorf("generated workflow missing %q", check)
+		}
+	}
+}
+
+func TestArtifactsAndGenerateAll(t *testing.T) {
+	config := testConfig()
+
+	artifacts, err := New().Artifacts(context.Background(), config)
+	if err != nil {
+		t.Fatalf("Artifacts() error = %v", err)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("Artifacts() returned %d artifacts, want 2 (no docker)", len(artifacts))
+	}
+
+	dir := t.TempDir()
+	if err := New().GenerateAll(context.Background(), config, dir); err != nil {
+		t.Fatalf("GenerateAll() error = %v", err)
+	}
+	for _, path := range []string{GoReleaserConfigPath, WorkflowPath} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err != nil {
+			t.Errorf("expected %s to be written: %v", path, err)
+		}
+	}
+}
+
+func TestGenerateRequiresNames(t *testing.T) {
+	config := testConfig()
+	config.BinaryName = ""
+
+	if _, err := New().GenerateGoReleaserConfig(context.Background(), config); !domain.IsErrorCode(err, domain.ErrMissingRequiredField) {
+		t.Errorf("GenerateGoReleaserConfig() error = %v, want %s", err, domain.ErrMissingRequiredField)
+	}
+}
@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// BuildTarget is one binary the generated configuration builds
+type BuildTarget struct {
+	Platform     string `json:"goos"`
+	Architecture string `json:"goarch"`
+	// VariantKey and Variant select the micro-architecture, e.g. goarm and
+	// 7; both are empty when the architecture is built with its default
+	VariantKey string `json:"variant_key,omitempty"`
+	Variant    string `json:"variant,omitempty"`
+	// CGO reports whether the binary is built with CGO
+	CGO        bool   `json:"cgo"`
+	FirstClass bool   `json:"first_class"`
+	Archive    string `json:"archive"`
+}
+
+// String returns the target as "os/arch" or "os/arch (goarm=7)"
+func (bt BuildTarget) String() string {
+	if bt.Variant == "" {
+		return bt.Platform + "/" + bt.Architecture
+	}
+	return fmt.Sprintf("%s/%s (%s=%s)", bt.Platform, bt.Architecture, bt.VariantKey, bt.Variant)
+}
+
+// Channel counts the artifacts of one release channel
+type Channel struct {
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Details string `json:"details,omitempty"`
+}
+
+// Matrix is what a release from the generated configuration builds and
+// publishes
+type Matrix struct {
+	Targets  []BuildTarget `json:"targets"`
+	Ignore   []IgnoreRule  `json:"ignore"`
+	Channels []Channel     `json:"channels"`
+	// EstimatedCISeconds is a rough duration of the release workflow
+	EstimatedCISeconds int `json:"estimated_ci_seconds"`
+}
+
+// EstimatedCI returns the rough duration of the release workflow
+func (m *Matrix) EstimatedCI() time.Duration {
+	return time.Duration(m.EstimatedCISeconds) * time.Second
+}
+
+// Rough costs of a release on a hosted four-core runner. GoReleaser builds
+// targets in parallel, one per core.
+const (
+	ciSetupCost      = 90 * time.Second // checkout, Go setup, module download
+	ciParallelBuilds = 4
+	ciBuildCost      = 20 * time.Second
+	ciCGOBuildCost   = 60 * time.Second // cross C toolchains are slower
+	ciArchiveCost    = 2 * time.Second
+	ciImageCost      = 45 * time.Second
+	ciEmulatedImage  = 3 * time.Minute // non-amd64 images run under QEMU
+	ciSnapCost       = time.Minute
+	ciSBOMCost       = 10 * time.Second
+	ciSigningCost    = 15 * time.Second
+	ciPublishingCost = 30 * time.Second
+)
+
+// snapArchitectures are the architectures Snapcraft publishes for
+var snapArchitectures = map[domain.Architecture]bool{
+	domain.ArchitectureAMD64:   true,
+	domain.ArchitectureARM64:   true,
+	domain.ArchitectureARM:     true,
+	domain.Architecture386:     true,
+	domain.ArchitecturePPC64LE: true,
+	domain.ArchitectureS390X:   true,
+	domain.ArchitectureRISCV64: true,
+}
+
+// BuildMatrix computes the targets, ignore rules and artifacts of a release
+// from the answers: every platform and architecture pair the toolchain can
+// build with the chosen CGO setting, once per micro-architecture variant
+func BuildMatrix(config *domain.SafeProjectConfig) *Matrix {
+	data := newTemplateData(config)
+	matrix := &Matrix{Targets: []BuildTarget{}, Ignore: data.Ignore, Channels: []Channel{}}
+	if matrix.Ignore == nil {
+		matrix.Ignore = []IgnoreRule{}
+	}
+
+	variants := make(map[domain.Architecture][]string)
+	for _, variant := range config.ArchitectureVariants {
+		variants[variant.Architecture] = variant.Variants
+	}
+	ignored := make(map[IgnoreRule]bool)
+	for _, rule := range matrix.Ignore {
+		ignored[IgnoreRule{Platform: rule.Platform, Architecture: rule.Architecture}] = true
+	}
+
+	build := time.Duration(0)
+	zips := 0
+	snaps := 0
+	for _, platform := range config.Platforms {
+		snapped := make(map[domain.Architecture]bool)
+		for _, arch := range config.Architectures {
+			if ignored[IgnoreRule{Platform: string(platform), Architecture: string(arch)}] {
+				continue
+			}
+			target, _ := domain.LookupTarget(platform, arch)
+			base := BuildTarget{
+				Platform:     string(platform),
+				Architecture: string(arch),
+				CGO:          config.CGOStatus.IsEnabled() && target.CGOSupported,
+				FirstClass:   target.FirstClass,
+				Archive:      "tar.gz",
+			}
+			if platform == domain.PlatformWindows {
+				base.Archive = "zip"
+			}
+
+			values := variants[arch]
+			if len(values) == 0 {
+				values = []string{""}
+			}
+			for _, value := range values {
+				bt := base
+				if value != "" {
+					option, _ := arch.VariantOption()
+					bt.VariantKey, bt.Variant = option.BuildKey, value
+				}
+				matrix.Targets = append(matrix.Targets, bt)
+				if bt.CGO {
+					build += ciCGOBuildCost
+				} else {
+					build += ciBuildCost
+				}
+				if bt.Archive == "zip" {
+					zips++
+				}
+			}
+
+			if config.Snap && platform == domain.PlatformLinux && snapArchitectures[arch] && !snapped[arch] {
+				snapped[arch] = true
+				snaps++
+			}
+		}
+	}
+
+	archives := len(matrix.Targets)
+	matrix.Channels = append(matrix.Channels, Channel{
+		Name:    "archives",
+		Count:   archives,
+		Details: fmt.Sprintf("%d tar.gz, %d zip, 1 checksum file", archives-zips, zips),
+	})
+
+	packages := snaps
+	details := fmt.Sprintf("%d snaps", snaps)
+	if config.Homebrew && hasBrewTarget(matrix.Targets) {
+		packages++
+		details = "1 Homebrew formula, " + details
+	}
+	matrix.Channels = append(matrix.Channels, Channel{Name: "packages", Count: packages, Details: details})
+
+	images := len(data.Dockers)
+	manifests := 0
+	if data.PublishImages {
+		manifests = 2 // the version tag and latest
+	}
+	details = fmt.Sprintf("%d images, %d manifests", images, manifests)
+	if images > 0 && !data.PublishImages {
+		details += ", not pushed"
+	}
+	matrix.Channels = append(matrix.Channels, Channel{Name: "images", Count: images + manifests, Details: details})
+
+	if config.SBOM {
+		matrix.Channels = append(matrix.Channels, Channel{Name: "sboms", Count: archives, Details: "one per archive"})
+	}
+	if data.Sign {
+		signatures := 1
+		details = "checksum file"
+		if data.PublishImages {
+			signatures += manifests
+			details += " and image manifests"
+		}
+		matrix.Channels = append(matrix.Channels, Channel{Name: "signatures", Count: signatures, Details: details})
+	}
+
+	estimate := ciSetupCost + build/ciParallelBuilds
+	estimate += time.Duration(archives) * ciArchiveCost
+	for _, docker := range data.Dockers {
+		if docker.Architecture == string(domain.ArchitectureAMD64) {
+			estimate += ciImageCost
+		} else {
+			estimate += ciEmulatedImage
+		}
+	}
+	estimate += time.Duration(snaps) * ciSnapCost
+	if config.SBOM {
+		estimate += time.Duration(archives) * ciSBOMCost
+	}
+	if data.Sign {
+		estimate += ciSigningCost
+	}
+	estimate += ciPublishingCost
+	matrix.EstimatedCISeconds = int(estimate.Round(time.Second) / time.Second)
+
+	return matrix
+}
+
+// hasBrewTarget reports whether a Homebrew formula has a binary to install
+func hasBrewTarget(targets []BuildTarget) bool {
+	for _, target := range targets {
+		if target.Platform == string(domain.PlatformDarwin) || target.Platform == string(domain.PlatformLinux) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func TestBuildMatrix(t *testing.T) {
+	config := testConfig()
+	config.Platforms = []domain.Platform{domain.PlatformLinux, domain.PlatformWindows, domain.PlatformIOS}
+	config.Architectures = []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64}
+	config.ArchitectureVariants = []domain.ArchitectureVariant{{Architecture: domain.ArchitectureAMD64, Variants: []string{"v1", "v3"}}}
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryGitHub
+	config.Snap = true
+
+	matrix := BuildMatrix(config)
+
+	var targets []string
+	for _, target := range matrix.Targets {
+		targets = append(targets, target.String())
+	}
+	expected := []string{
+		"linux/amd64 (goamd64=v1)", "linux/amd64 (goamd64=v3)", "linux/arm64",
+		"windows/amd64 (goamd64=v1)", "windows/amd64 (goamd64=v3)", "windows/arm64",
+	}
+	if !reflect.DeepEqual(targets, expected) {
+		t.Errorf("targets = %v, want %v", targets, expected)
+	}
+
+	// ios only links with CGO, which is disabled
+	if len(matrix.Ignore) != 2 || matrix.Ignore[0].Platform != "ios" || matrix.Ignore[0].Reason == "" {
+		t.Errorf("ignore = %+v, want both ios targets", matrix.Ignore)
+	}
+
+	counts := make(map[string]int)
+	for _, channel := range matrix.Channels {
+		counts[channel.Name] = channel.Count
+	}
+	if counts["archives"] != 6 || counts["packages"] != 2 || counts["images"] != 4 {
+		t.Errorf("channels = %+v", matrix.Channels)
+	}
+	if matrix.EstimatedCI() <= 0 {
+		t.Errorf("EstimatedCI() = %v", matrix.EstimatedCI())
+	}
+
+	// The generated configuration ignores the same pairs
+	content, err := New().GenerateGoReleaserConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GenerateGoReleaserConfig() error = %v", err)
+	}
+	if !strings.Contains(content, "ignore:\n      - goos: ios\n        goarch: \"amd64\"\n      - goos: ios\n        goarch: \"arm64\"") {
+		t.Errorf("generated config lacks the ios ignore entries:\n%s", content)
+	}
+}
+
+func TestBuildMatrixWithCGO(t *testing.T) {
+	config := testConfig()
+	config.Platforms = []domain.Platform{domain.PlatformIOS, domain.PlatformJS}
+	config.Architectures = []domain.Architecture{domain.ArchitectureARM64, domain.ArchitectureWASM}
+	config.CGOStatus = domain.CGOStatusEnabled
+
+	matrix := BuildMatrix(config)
+	if len(matrix.Targets) != 2 || !matrix.Targets[0].CGO || matrix.Targets[1].CGO {
+		t.Errorf("targets = %+v, want ios/arm64 with CGO and js/wasm without", matrix.Targets)
+	}
+	for _, rule := range matrix.Ignore {
+		if rule.Reason != "not a Go target" {
+			t.Errorf("unexpected ignore rule %+v", rule)
+		}
+	}
+}
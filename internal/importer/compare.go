@@ -0,0 +1,142 @@
+package importer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+// CompareGenerated renders the imported answers and reports every part of
+// the supported sections that generate would drop or change, e.g. a custom
+// before hook or release.draft. Parts already reported are not repeated.
+// Call it once the answers are complete, after the project name, binary and
+// main package are filled in.
+func (r *Result) CompareGenerated(ctx context.Context) error {
+	if r.config == nil || r.config.Kind != yaml.MappingNode {
+		return nil
+	}
+	content, err := generator.New().GenerateGoReleaserConfig(ctx, r.Config)
+	if err != nil {
+		return err
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		return domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid generated configuration",
+			err.Error(),
+		).WithCause(err)
+	}
+	generated := &yaml.Node{Kind: yaml.MappingNode}
+	if len(document.Content) > 0 {
+		generated = document.Content[0]
+	}
+
+	comparison := &comparison{result: r, reported: make(map[string]bool)}
+	for _, problem := range r.Unsupported {
+		if problem.Context == r.configPath {
+			comparison.reported[problem.Field] = true
+		}
+	}
+
+	r.path = r.configPath
+	for i := 0; i+1 < len(r.config.Content); i += 2 {
+		key := r.config.Content[i]
+		if !supportedSections[key.Value] {
+			continue
+		}
+		value := r.config.Content[i+1]
+		comparison.node(key.Value, key, value, yamlcheck.Lookup(generated, key.Value))
+	}
+	return nil
+}
+
+// comparison walks a section of the imported configuration next to the
+// generated one
+type comparison struct {
+	result   *Result
+	reported map[string]bool // fields reported before the comparison
+}
+
+// node compares an original value with the generated one at field. The key,
+// when there is one, is the position of a value that generate drops.
+func (c *comparison) node(field string, key, original, generated *yaml.Node) {
+	if c.covered(field) {
+		return
+	}
+	position := original
+	if key != nil {
+		position = key
+	}
+	if generated == nil {
+		c.result.unsupported(position, field, fmt.Sprintf("generate drops %s", field))
+		return
+	}
+
+	switch {
+	case original.Kind == yaml.MappingNode && generated.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(original.Content); i += 2 {
+			child := original.Content[i]
+			c.node(field+"."+child.Value, child, original.Content[i+1], yamlcheck.Lookup(generated, child.Value))
+		}
+	case original.Kind == yaml.SequenceNode && generated.Kind == yaml.SequenceNode:
+		c.sequence(field, original, generated)
+	case original.Kind == yaml.ScalarNode && generated.Kind == yaml.ScalarNode:
+		if normalize(original.Value) != normalize(generated.Value) {
+			c.result.unsupported(original, field, fmt.Sprintf("generate writes %q instead of %q", generated.Value, original.Value))
+		}
+	default:
+		c.result.unsupported(position, field, fmt.Sprintf("generate writes %s in a different form", field))
+	}
+}
+
+// sequence compares collection items by position and scalar items by value,
+// reporting the items generate does not write
+func (c *comparison) sequence(field string, original, generated *yaml.Node) {
+	values := make(map[string]bool)
+	for _, item := range generated.Content {
+		if item.Kind == yaml.ScalarNode {
+			values[normalize(item.Value)] = true
+		}
+	}
+
+	for i, item := range original.Content {
+		itemField := fmt.Sprintf("%s[%d]", field, i)
+		if item.Kind == yaml.ScalarNode {
+			if !values[normalize(item.Value)] && !c.covered(itemField) {
+				c.result.unsupported(item, itemField, fmt.Sprintf("generate drops %q from %s", item.Value, field))
+			}
+			continue
+		}
+		var counterpart *yaml.Node
+		if i < len(generated.Content) {
+			counterpart = generated.Content[i]
+		}
+		c.node(itemField, nil, item, counterpart)
+	}
+}
+
+// covered reports whether field, or a part of the file containing it, was
+// already reported
+func (c *comparison) covered(field string) bool {
+	for reported := range c.reported {
+		if field == reported || strings.HasPrefix(field, reported+".") || strings.HasPrefix(field, reported+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// templateSpace matches the optional spaces inside template delimiters
+var templateSpace = regexp.MustCompile(`\{\{\s*|\s*\}\}`)
+
+// normalize ignores the spacing inside templates, e.g. {{.Tag}} and {{ .Tag }}
+func normalize(value string) string {
+	return templateSpace.ReplaceAllStringFunc(strings.TrimSpace(value), strings.TrimSpace)
+}
@@ -0,0 +1,554 @@
+// Package importer reconstructs wizard answers from an existing GoReleaser
+// configuration and its release workflow, the reverse of the generator
+package importer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+// GoReleaser v2 defaults for builds without goos or goarch
+var (
+	defaultGoos   = []string{"darwin", "linux", "windows"}
+	defaultGoarch = []string{"386", "amd64", "arm64"}
+)
+
+// supportedSections lists the top-level keys the answers can represent. The
+// generator derives the others (before, checksum, changelog, ...) itself.
+var supportedSections = map[string]bool{
+	"version": true, "project_name": true, "before": true, "builds": true,
+	"archives": true, "checksum": true, "snapshot": true, "changelog": true,
+	"release": true, "dockers": true, "dockers_v2": true, "docker_manifests": true,
+	"sboms": true, "signs": true, "binary_signs": true, "docker_signs": true,
+	"brews": true, "homebrew_casks": true, "snapcrafts": true,
+}
+
+// supportedBuildKeys lists the build options the answers can represent
+var supportedBuildKeys = map[string]bool{
+	"id": true, "main": true, "binary": true, "env": true, "flags": true,
+	"ldflags": true, "mod_timestamp": true, "goos": true, "goarch": true,
+	"ignore": true, "tags": true,
+}
+
+// Files are the parsed files an import reads. The paths become the context
+// of the problems reported for each file.
+type Files struct {
+	Config       *yaml.Node
+	ConfigPath   string
+	Workflow     *yaml.Node // nil when the project has no release workflow
+	WorkflowPath string
+}
+
+// Result holds the reconstructed answers and the parts of the files they
+// cannot represent, which generate would drop
+type Result struct {
+	Config      *domain.SafeProjectConfig
+	Unsupported domain.ValidationErrors
+
+	path       string     // file being imported
+	config     *yaml.Node // imported GoReleaser configuration
+	configPath string
+}
+
+// Import maps a parsed GoReleaser configuration, and optionally its release
+// workflow, to wizard answers. Answers neither file determines keep the
+// defaults of domain.NewSafeProjectConfig; the project name, binary and main
+// package are left empty when the configuration does not set them.
+func Import(files Files) *Result {
+	result := &Result{
+		Config:     domain.NewSafeProjectConfig(),
+		path:       files.ConfigPath,
+		config:     files.Config,
+		configPath: files.ConfigPath,
+	}
+	result.Config.ActionLevel = domain.ActionLevelNone
+
+	result.checkSections(files.Config)
+	result.importProject(files.Config)
+	result.importBuild(files.Config)
+	result.importDocker(files.Config)
+	result.importPublishing(files.Config)
+	if files.Workflow != nil {
+		result.path = files.WorkflowPath
+		result.importWorkflow(files.Workflow)
+	}
+	return result
+}
+
+// unsupported records a part of the files the answers cannot represent
+func (r *Result) unsupported(node *yaml.Node, field, details string) {
+	r.Unsupported = append(r.Unsupported, domain.NewValidationError(
+		domain.ErrImportUnsupported,
+		"Not represented in wizard answers",
+		details,
+	).WithField(field).WithPosition(node.Line, node.Column).WithContext(r.path))
+}
+
+func (r *Result) checkSections(root *yaml.Node) {
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key := root.Content[i]
+		if !supportedSections[key.Value] {
+			r.unsupported(key, key.Value, fmt.Sprintf("The %s section has no wizard answer and is dropped by generate", key.Value))
+		}
+	}
+}
+
+func (r *Result) importProject(root *yaml.Node) {
+	if name := yamlcheck.Lookup(root, "project_name"); name != nil && name.Kind == yaml.ScalarNode {
+		if templated(name.Value) {
+			r.unsupported(name, "project_name", "A templated project name cannot be imported")
+		} else {
+			r.Config.ProjectName = name.Value
+		}
+	}
+
+	// The description lives in the package manager sections
+	for _, section := range []string{"brews", "homebrew_casks", "snapcrafts"} {
+		for _, item := range items(root, section) {
+			if description := scalar(yamlcheck.Lookup(item, "description")); description != "" && !templated(description) {
+				r.Config.ProjectDescription = strings.TrimSpace(description)
+				return
+			}
+		}
+	}
+}
+
+func (r *Result) importBuild(root *yaml.Node) {
+	builds := items(root, "builds")
+	if len(builds) == 0 {
+		r.setTargets(nil, defaultGoos, defaultGoarch, nil)
+		return
+	}
+	for i, extra := range builds[1:] {
+		r.unsupported(extra, fmt.Sprintf("builds[%d]", i+1), "Wizard answers describe a single build; only builds[0] is imported")
+	}
+
+	build := builds[0]
+	for i := 0; i+1 < len(build.Content); i += 2 {
+		key := build.Content[i]
+		if !supportedBuildKeys[key.Value] && variantArchitectures(key.Value) == nil {
+			r.unsupported(key, "builds[0]."+key.Value, fmt.Sprintf("The build option %s has no wizard answer", key.Value))
+		}
+	}
+
+	if binary := scalar(yamlcheck.Lookup(build, "binary")); binary != "" && !templated(binary) {
+		r.Config.BinaryName = path.Base(binary)
+	}
+	if main := scalar(yamlcheck.Lookup(build, "main")); main != "" {
+		r.Config.MainPath = main
+		if !strings.HasPrefix(main, ".") {
+			r.Config.MainPath = "./" + strings.TrimPrefix(main, "/")
+		}
+	}
+
+	r.Config.CGOStatus = domain.CGOStatusDisabled
+	for _, env := range values(yamlcheck.Lookup(build, "env")) {
+		if env == "CGO_ENABLED=1" {
+			r.Config.CGOStatus = domain.CGOStatusEnabled
+		}
+	}
+
+	r.Config.LDFlags = yamlcheck.Lookup(build, "ldflags") != nil
+	for _, tag := range values(yamlcheck.Lookup(build, "tags")) {
+		r.Config.BuildTags = append(r.Config.BuildTags, domain.BuildTag{Name: tag})
+	}
+
+	goos := values(yamlcheck.Lookup(build, "goos"))
+	if goos == nil {
+		goos = defaultGoos
+	}
+	goarch := values(yamlcheck.Lookup(build, "goarch"))
+	if goarch == nil {
+		goarch = defaultGoarch
+	}
+	r.setTargets(build, goos, goarch, yamlcheck.Lookup(build, "ignore"))
+	r.importVariants(build)
+}
+
+// importVariants maps the micro-architecture build keys, e.g. goarm, to
+// variants of every selected architecture that reads the key. A key for
+// architectures the build does not select has no effect and is ignored; one
+// for an architecture the answers could not import is reported.
+func (r *Result) importVariants(build *yaml.Node) {
+	goarch := values(yamlcheck.Lookup(build, "goarch"))
+	if goarch == nil {
+		goarch = defaultGoarch
+	}
+	for i := 0; i+1 < len(build.Content); i += 2 {
+		key, node := build.Content[i], build.Content[i+1]
+		architectures := variantArchitectures(key.Value)
+		if architectures == nil {
+			continue
+		}
+
+		var selected []domain.Architecture
+		for _, arch := range r.Config.Architectures {
+			for _, reader := range architectures {
+				if arch == reader {
+					selected = append(selected, arch)
+				}
+			}
+		}
+		if len(selected) == 0 {
+			for _, reader := range architectures {
+				if slices.Contains(goarch, string(reader)) {
+					r.unsupported(key, "builds[0]."+key.Value, fmt.Sprintf("GOARCH %s is not imported, so its %s variants are dropped", string(reader), key.Value))
+					break
+				}
+			}
+			continue
+		}
+
+		option, _ := selected[0].VariantOption()
+		items := scalars(node)
+		if node.Kind == yaml.ScalarNode {
+			items = []*yaml.Node{node}
+		}
+		var variants []string
+		for _, item := range items {
+			if !option.IsValid(item.Value) {
+				r.unsupported(item, "builds[0]."+key.Value, fmt.Sprintf("'%s' is not a valid %s value; valid values: %s", item.Value, option.EnvVar, strings.Join(option.Values, ", ")))
+				continue
+			}
+			variants = append(variants, item.Value)
+		}
+		if len(variants) == 0 {
+			continue
+		}
+		for _, arch := range selected {
+			r.Config.ArchitectureVariants = append(r.Config.ArchitectureVariants, domain.ArchitectureVariant{
+				Architecture: arch,
+				Variants:     append([]string(nil), variants...),
+			})
+		}
+	}
+}
+
+// setTargets maps a build matrix to platforms and architectures. Answers
+// pair every platform with every architecture it supports, so architectures
+// only some platforms build and ignore rules beyond that are reported.
+func (r *Result) setTargets(build *yaml.Node, goos, goarch []string, ignore *yaml.Node) {
+	node := build
+	if node == nil {
+		node = &yaml.Node{}
+	}
+
+	var platforms []domain.Platform
+	for _, name := range goos {
+		platform := domain.Platform(name)
+		if !platform.IsValid() {
+			r.unsupported(node, "builds[0].goos", fmt.Sprintf("GOOS %s is not a wizard platform", name))
+			continue
+		}
+		platforms = append(platforms, platform)
+	}
+
+	var architectures []domain.Architecture
+	for _, name := range goarch {
+		arch := domain.Architecture(name)
+		if !arch.IsValid() {
+			r.unsupported(node, "builds[0].goarch", fmt.Sprintf("GOARCH %s is not a wizard architecture", name))
+			continue
+		}
+		var missing []string
+		for _, platform := range platforms {
+			if !platform.SupportsArchitecture(arch) {
+				missing = append(missing, string(platform))
+			}
+		}
+		if len(missing) > 0 {
+			r.unsupported(node, "builds[0].goarch", fmt.Sprintf("GOARCH %s is not available on %s; answers cannot build an architecture for some platforms only", name, strings.Join(missing, ", ")))
+			continue
+		}
+		architectures = append(architectures, arch)
+	}
+
+	if ignore != nil {
+		for i, rule := range ignore.Content {
+			r.unsupported(rule, fmt.Sprintf("builds[0].ignore[%d]", i), "Ignore rules cannot be imported; the answers build every supported pair")
+		}
+	}
+
+	if len(platforms) > 0 {
+		r.Config.Platforms = platforms
+	}
+	if len(architectures) > 0 {
+		r.Config.Architectures = architectures
+	}
+}
+
+func (r *Result) importDocker(root *yaml.Node) {
+	var images []*yaml.Node
+	publish := false
+	for _, docker := range items(root, "dockers") {
+		images = append(images, scalars(yamlcheck.Lookup(docker, "image_templates"))...)
+		publish = publish || !isTrue(yamlcheck.Lookup(docker, "skip_push"))
+	}
+	for _, docker := range items(root, "dockers_v2") {
+		images = append(images, scalars(yamlcheck.Lookup(docker, "images"))...)
+		publish = true
+	}
+	if len(images) == 0 {
+		r.Config.DockerSupport = domain.DockerSupportNone
+		return
+	}
+
+	r.Config.DockerSupport = domain.DockerSupportBuild
+	if publish {
+		r.Config.DockerSupport = domain.DockerSupportBoth
+	}
+
+	registry, image := parseImage(images[0].Value)
+	r.Config.DockerRegistry = registry
+	r.Config.DockerImage = image
+	if registry == domain.DockerRegistryCustom && !strings.HasPrefix(images[0].Value, "{{") {
+		r.unsupported(images[0], "dockers", fmt.Sprintf("The registry of %s is imported as custom, read from DOCKER_REGISTRY at release time", images[0].Value))
+	}
+	for _, other := range images[1:] {
+		if otherRegistry, otherImage := parseImage(other.Value); otherRegistry != registry || otherImage != image {
+			r.unsupported(other, "dockers", fmt.Sprintf("Answers publish a single image; %s is dropped", other.Value))
+		}
+	}
+}
+
+func (r *Result) importPublishing(root *yaml.Node) {
+	if len(enabled(items(root, "signs"))) > 0 || len(enabled(items(root, "binary_signs"))) > 0 {
+		r.Config.SigningLevel = domain.SigningLevelBasic
+		if len(enabled(items(root, "docker_signs"))) > 0 {
+			r.Config.SigningLevel = domain.SigningLevelAdvanced
+		}
+	}
+	r.Config.SBOM = len(enabled(items(root, "sboms"))) > 0
+	r.Config.Homebrew = len(items(root, "brews")) > 0 || len(items(root, "homebrew_casks")) > 0
+	r.Config.Snap = len(items(root, "snapcrafts")) > 0
+
+	release := yamlcheck.Lookup(root, "release")
+	for _, provider := range []domain.GitProvider{domain.GitProviderGitHub, domain.GitProviderGitLab, domain.GitProviderGitea} {
+		if yamlcheck.Lookup(release, string(provider)) != nil {
+			r.Config.GitProvider = provider
+		}
+	}
+}
+
+// importWorkflow reads the triggers and level of the release workflow
+func (r *Result) importWorkflow(workflow *yaml.Node) {
+	r.Config.ActionLevel = domain.ActionLevelBasic
+	r.Config.ActionsOn = nil
+
+	for _, event := range events(yamlcheck.Lookup(workflow, "on")) {
+		switch event.name {
+		case "push":
+			for _, tag := range values(yamlcheck.Lookup(event.node, "tags")) {
+				switch tag {
+				case "v*", "v*.*.*", "v[0-9]+.[0-9]+.[0-9]+":
+					r.addTrigger(domain.ActionTriggerVersionTags)
+				case "*", "**":
+					r.addTrigger(domain.ActionTriggerAllTags)
+				default:
+					r.unsupported(event.node, "on.push.tags", fmt.Sprintf("The tag pattern %s is imported as version tags", tag))
+					r.addTrigger(domain.ActionTriggerVersionTags)
+				}
+			}
+			for _, branch := range values(yamlcheck.Lookup(event.node, "branches")) {
+				if branch == "main" || branch == "master" {
+					r.addTrigger(domain.ActionTriggerMain)
+				} else {
+					r.unsupported(event.node, "on.push.branches", fmt.Sprintf("Pushes to %s cannot be imported", branch))
+				}
+			}
+		case "release":
+			r.addTrigger(domain.ActionTriggerRelease)
+		case "workflow_dispatch":
+			r.addTrigger(domain.ActionTriggerManual)
+		default:
+			r.unsupported(event.key, "on."+event.name, fmt.Sprintf("The %s trigger cannot be imported", event.name))
+		}
+	}
+
+	jobs := yamlcheck.Lookup(workflow, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 1; i < len(jobs.Content); i += 2 {
+		for _, step := range mappings(yamlcheck.Lookup(jobs.Content[i], "steps")) {
+			run := scalar(yamlcheck.Lookup(step, "run"))
+			if strings.Contains(run, "go test") {
+				r.Config.ActionLevel = domain.ActionLevelAdvanced
+			}
+			with := yamlcheck.Lookup(step, "with")
+			if scalar(yamlcheck.Lookup(with, "distribution")) == "goreleaser-pro" {
+				r.Config.FeatureLevel = domain.FeatureLevelProfessional
+			}
+		}
+	}
+}
+
+func (r *Result) addTrigger(trigger domain.ActionTrigger) {
+	for _, existing := range r.Config.ActionsOn {
+		if existing == trigger {
+			return
+		}
+	}
+	r.Config.ActionsOn = append(r.Config.ActionsOn, trigger)
+}
+
+// event is a workflow trigger with its key and configuration
+type event struct {
+	name string
+	key  *yaml.Node
+	node *yaml.Node
+}
+
+// events lists the triggers of 'on', which may be a scalar, list or mapping
+func events(on *yaml.Node) []event {
+	if on == nil {
+		return nil
+	}
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return []event{{name: on.Value, key: on}}
+	case yaml.SequenceNode:
+		var result []event
+		for _, item := range on.Content {
+			result = append(result, event{name: item.Value, key: item})
+		}
+		return result
+	case yaml.MappingNode:
+		var result []event
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			result = append(result, event{name: on.Content[i].Value, key: on.Content[i], node: on.Content[i+1]})
+		}
+		return result
+	}
+	return nil
+}
+
+// registryHosts maps image hosts to wizard registries
+var registryHosts = map[string]domain.DockerRegistry{
+	"docker.io":           domain.DockerRegistryDockerHub,
+	"index.docker.io":     domain.DockerRegistryDockerHub,
+	"ghcr.io":             domain.DockerRegistryGitHub,
+	"registry.gitlab.com": domain.DockerRegistryGitLab,
+	"quay.io":             domain.DockerRegistryQuay,
+}
+
+// parseImage splits an image template into its registry and the image name
+// the generator expands again: a namespace set from the environment is
+// dropped, a literal one kept
+func parseImage(image string) (domain.DockerRegistry, string) {
+	repository, _, _ := strings.Cut(image, ":{{")
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	parts := strings.Split(repository, "/")
+	registry := domain.DockerRegistryDockerHub
+	if len(parts) > 1 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost" || templated(parts[0])) {
+		if known, ok := registryHosts[parts[0]]; ok {
+			registry = known
+		} else {
+			registry = domain.DockerRegistryCustom
+		}
+		parts = parts[1:]
+	}
+
+	if len(parts) > 1 && templated(parts[0]) {
+		parts = parts[1:]
+	}
+	return registry, strings.ToLower(strings.Join(parts, "/"))
+}
+
+// variantArchitectures returns the architectures that read a build key such
+// as goarm, or nil when the key selects no micro-architecture
+func variantArchitectures(key string) []domain.Architecture {
+	var architectures []domain.Architecture
+	for _, arch := range domain.GetVariantArchitectures() {
+		if option, _ := arch.VariantOption(); option.BuildKey == key {
+			architectures = append(architectures, arch)
+		}
+	}
+	return architectures
+}
+
+// items returns the mapping items of a top-level list
+func items(root *yaml.Node, key string) []*yaml.Node {
+	return mappings(yamlcheck.Lookup(root, key))
+}
+
+// mappings returns the mapping items of a list node
+func mappings(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var result []*yaml.Node
+	for _, item := range node.Content {
+		if item.Kind == yaml.MappingNode {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// scalars returns the scalar items of a list node
+func scalars(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var result []*yaml.Node
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// values returns the values of a list node, or of a single scalar
+func values(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}
+	}
+	result := []string{}
+	for _, item := range scalars(node) {
+		result = append(result, item.Value)
+	}
+	return result
+}
+
+// enabled drops items switched off with disable: true
+func enabled(nodes []*yaml.Node) []*yaml.Node {
+	var result []*yaml.Node
+	for _, node := range nodes {
+		if !isTrue(yamlcheck.Lookup(node, "disable")) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+func isTrue(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode && node.Value == "true"
+}
+
+func scalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+func templated(value string) bool {
+	return strings.Contains(value, "{{")
+}
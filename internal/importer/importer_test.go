@@ -0,0 +1,270 @@
+package importer
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"go.yaml.in/yaml/v3"
+)
+
+func parse(t *testing.T, content string) *yaml.Node {
+	t.Helper()
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		t.Fatalf("invalid test YAML: %v", err)
+	}
+	return document.Content[0]
+}
+
+// TestRoundTrip imports generated files and expects the answers back
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *domain.SafeProjectConfig)
+	}{
+		{name: "defaults"},
+		{
+			name: "everything",
+			modify: func(config *domain.SafeProjectConfig) {
+				config.ProjectDescription = "A release tool"
+				config.Platforms = []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin}
+				config.CGOStatus = domain.CGOStatusEnabled
+				config.BuildTags = []domain.BuildTag{{Name: "netgo"}}
+				config.DockerSupport = domain.DockerSupportBoth
+				config.DockerRegistry = domain.DockerRegistryGitHub
+				config.DockerImage = "tool"
+				config.SigningLevel = domain.SigningLevelAdvanced
+				config.SBOM = true
+				config.Homebrew = true
+				config.Snap = true
+				config.ActionLevel = domain.ActionLevelAdvanced
+				config.ActionsOn = []domain.ActionTrigger{domain.ActionTriggerVersionTags, domain.ActionTriggerMain, domain.ActionTriggerManual}
+				config.FeatureLevel = domain.FeatureLevelProfessional
+			},
+		},
+		{
+			name: "architecture_variants",
+			modify: func(config *domain.SafeProjectConfig) {
+				config.Platforms = []domain.Platform{domain.PlatformLinux}
+				config.Architectures = []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64, domain.ArchitectureARM, domain.ArchitectureMIPS, domain.ArchitectureMIPSLE}
+				config.ArchitectureVariants = []domain.ArchitectureVariant{
+					{Architecture: domain.ArchitectureAMD64, Variants: []string{"v1", "v3"}},
+					{Architecture: domain.ArchitectureARM, Variants: []string{"6", "7"}},
+					{Architecture: domain.ArchitectureMIPS, Variants: []string{"softfloat"}},
+					{Architecture: domain.ArchitectureMIPSLE, Variants: []string{"softfloat"}},
+				}
+			},
+		},
+		{
+			name: "custom_registry_build_only",
+			modify: func(config *domain.SafeProjectConfig) {
+				config.Platforms = []domain.Platform{domain.PlatformLinux}
+				config.DockerSupport = domain.DockerSupportBuild
+				config.DockerRegistry = domain.DockerRegistryCustom
+				config.DockerImage = "acme/tool"
+				config.SigningLevel = domain.SigningLevelBasic
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := domain.NewSafeProjectConfig()
+			config.ProjectName = "tool"
+			config.BinaryName = "tool"
+			config.MainPath = "./cmd/tool"
+			config.ActionsOn = []domain.ActionTrigger{domain.ActionTriggerVersionTags}
+			if tt.modify != nil {
+				tt.modify(config)
+			}
+			if err := config.ValidateInvariants(); err != nil {
+				t.Fatalf("test config is invalid: %v", err)
+			}
+
+			g := generator.New()
+			goreleaser, err := g.GenerateGoReleaserConfig(context.Background(), config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			workflow, err := g.GenerateGitHubActions(context.Background(), config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result := Import(Files{Config: parse(t, goreleaser), Workflow: parse(t, workflow)})
+			if err := result.CompareGenerated(context.Background()); err != nil {
+				t.Fatalf("CompareGenerated() error = %v", err)
+			}
+			if len(result.Unsupported) > 0 {
+				t.Errorf("Import() reported unsupported parts of generated files: %v", result.Unsupported)
+			}
+			if err := result.Config.ValidateInvariants(); err != nil {
+				t.Errorf("imported answers are invalid: %v", err)
+			}
+
+			// The project type cannot be recovered from the files
+			result.Config.ProjectType = config.ProjectType
+			if !reflect.DeepEqual(result.Config, config) {
+				t.Errorf("Import() =\n%+v\nwant\n%+v", result.Config, config)
+			}
+		})
+	}
+}
+
+func TestImportReportsUnsupported(t *testing.T) {
+	config := `version: 2
+project_name: app
+builds:
+  - id: app
+    main: ./cmd/app
+    goos: [linux, darwin, haiku]
+    goarch: [amd64, "386"]
+    goamd64: [v1, v5]
+    goarm: ["7"]
+  - id: helper
+nfpms:
+  - formats: [deb]
+dockers:
+  - image_templates:
+      - "registry.example.com/app:{{ .Tag }}"
+      - "ghcr.io/acme/other:{{ .Tag }}"
+`
+	workflow := `on:
+  push:
+    tags: ["release-*"]
+  schedule:
+    - cron: "0 0 * * *"
+jobs:
+  release:
+    steps:
+      - run: go test ./...
+`
+
+	result := Import(Files{
+		Config:       parse(t, config),
+		ConfigPath:   ".goreleaser.yaml",
+		Workflow:     parse(t, workflow),
+		WorkflowPath: "release.yml",
+	})
+	var fields []string
+	for _, problem := range result.Unsupported {
+		if problem.Code != domain.ErrImportUnsupported || problem.Line == 0 {
+			t.Errorf("unexpected problem %v", problem)
+		}
+		fields = append(fields, problem.Context+":"+problem.Field)
+	}
+	expected := []string{
+		".goreleaser.yaml:nfpms",
+		".goreleaser.yaml:builds[1]",
+		".goreleaser.yaml:builds[0].goos",
+		".goreleaser.yaml:builds[0].goarch",
+		".goreleaser.yaml:builds[0].goamd64",
+		".goreleaser.yaml:dockers",
+		".goreleaser.yaml:dockers",
+		"release.yml:on.push.tags",
+		"release.yml:on.schedule",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Unsupported fields = %v, want %v", fields, expected)
+	}
+
+	got := result.Config
+	if got.ProjectName != "app" || got.MainPath != "./cmd/app" || got.BinaryName != "" {
+		t.Errorf("project = %q %q %q", got.ProjectName, got.MainPath, got.BinaryName)
+	}
+	if !reflect.DeepEqual(got.Platforms, []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin}) ||
+		!reflect.DeepEqual(got.Architectures, []domain.Architecture{domain.ArchitectureAMD64}) {
+		t.Errorf("targets = %v %v", got.Platforms, got.Architectures)
+	}
+	if got.DockerRegistry != domain.DockerRegistryCustom || got.DockerImage != "app" || got.DockerSupport != domain.DockerSupportBoth {
+		t.Errorf("docker = %s %s %s", got.DockerRegistry, got.DockerImage, got.DockerSupport)
+	}
+	if got.ActionLevel != domain.ActionLevelAdvanced || !reflect.DeepEqual(got.ActionsOn, []domain.ActionTrigger{domain.ActionTriggerVersionTags}) {
+		t.Errorf("actions = %s %v", got.ActionLevel, got.ActionsOn)
+	}
+	if !reflect.DeepEqual(got.ArchitectureVariants, []domain.ArchitectureVariant{{Architecture: domain.ArchitectureAMD64, Variants: []string{"v1"}}}) {
+		t.Errorf("variants = %v", got.ArchitectureVariants)
+	}
+	if !strings.Contains(result.Unsupported[3].Details, "386") {
+		t.Errorf("goarch problem = %s", result.Unsupported[3].Details)
+	}
+}
+
+func TestCompareGenerated(t *testing.T) {
+	config := `version: 2
+project_name: app
+before:
+  hooks:
+    - go mod tidy
+    - make assets
+builds:
+  - id: app
+    main: ./cmd/app
+    binary: app
+    goos: [linux, darwin]
+    goarch: [amd64, arm]
+    goarm: ["6", "7"]
+archives:
+  - files:
+      - NOTICE
+changelog:
+  filters:
+    exclude:
+      - "^wip:"
+release:
+  draft: true
+signs:
+  - artifacts: checksum
+    args: ["sign-blob", "--key", "cosign.key", "--output-signature=${signature}", "${artifact}"]
+`
+
+	result := Import(Files{Config: parse(t, config), ConfigPath: ".goreleaser.yaml"})
+	if err := result.CompareGenerated(context.Background()); err != nil {
+		t.Fatalf("CompareGenerated() error = %v", err)
+	}
+	var fields []string
+	for _, problem := range result.Unsupported {
+		if problem.Code != domain.ErrImportUnsupported || problem.Line == 0 || problem.Context != ".goreleaser.yaml" {
+			t.Errorf("unexpected problem %v", problem)
+		}
+		fields = append(fields, problem.Field)
+	}
+	expected := []string{
+		"builds[0].goarch",
+		"builds[0].goarm",
+		"before.hooks[1]",
+		"archives[0].files[0]",
+		"changelog.filters.exclude[0]",
+		"release.draft",
+		"signs[0].args[1]",
+		"signs[0].args[2]",
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Unsupported fields = %v, want %v", fields, expected)
+	}
+}
+
+func TestParseImage(t *testing.T) {
+	tests := []struct {
+		image    string
+		registry domain.DockerRegistry
+		name     string
+	}{
+		{"ghcr.io/{{ .Env.GITHUB_OWNER }}/tool:{{ .Tag }}-amd64", domain.DockerRegistryGitHub, "tool"},
+		{"docker.io/{{ .Env.DOCKER_USERNAME }}/tool:{{ .Tag }}", domain.DockerRegistryDockerHub, "tool"},
+		{"{{ .Env.DOCKER_REGISTRY }}/acme/tool:{{ .Tag }}", domain.DockerRegistryCustom, "acme/tool"},
+		{"acme/Tool:latest", domain.DockerRegistryDockerHub, "acme/tool"},
+		{"quay.io/acme/tool", domain.DockerRegistryQuay, "acme/tool"},
+		{"localhost:5000/tool", domain.DockerRegistryCustom, "tool"},
+	}
+	for _, tt := range tests {
+		registry, name := parseImage(tt.image)
+		if registry != tt.registry || name != tt.name {
+			t.Errorf("parseImage(%q) = %s, %q; want %s, %q", tt.image, registry, name, tt.registry, tt.name)
+		}
+	}
+}
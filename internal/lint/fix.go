@@ -0,0 +1,391 @@
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+// Fix is a structured change that resolves one finding. Its edits are
+// applied to the original text, so comments and formatting elsewhere in the
+// file are kept. Unsafe fixes change what a release produces or may do.
+type Fix struct {
+	Rule        string
+	Description string
+	Unsafe      bool
+	Edits       []Edit
+}
+
+type editKind int
+
+const (
+	editSetValue editKind = iota
+	editAddEntry
+	editInsertEntry
+	editAddItem
+)
+
+// Edit is a single YAML node edit
+type Edit struct {
+	kind   editKind
+	node   *yaml.Node // the scalar to replace, or the collection to extend
+	key    string
+	before string // editInsertEntry: the key the new entry goes above
+	value  *yaml.Node
+}
+
+// SetValue replaces the value of a single-line scalar, keeping its quoting style
+func SetValue(node *yaml.Node, value string) Edit {
+	return Edit{kind: editSetValue, node: node, value: &yaml.Node{Kind: yaml.ScalarNode, Value: value}}
+}
+
+// AddEntry appends key: value to a block mapping
+func AddEntry(mapping *yaml.Node, key string, value *yaml.Node) Edit {
+	return Edit{kind: editAddEntry, node: mapping, key: key, value: value}
+}
+
+// InsertEntry adds key: value to a block mapping above the entry named before
+func InsertEntry(mapping *yaml.Node, before, key string, value *yaml.Node) Edit {
+	return Edit{kind: editInsertEntry, node: mapping, key: key, before: before, value: value}
+}
+
+// AddItem appends an item to a block sequence
+func AddItem(sequence *yaml.Node, value *yaml.Node) Edit {
+	return Edit{kind: editAddItem, node: sequence, value: value}
+}
+
+// Apply applies fixes to content. All edits refer to positions in content,
+// so fixes found in one lint run can be applied together.
+func Apply(content []byte, fixes []*Fix) ([]byte, error) {
+	src := newSource(content)
+
+	var spans []span
+	for _, edit := range mergeEdits(fixes) {
+		s, err := edit.resolve(src)
+		if err != nil {
+			return nil, fixError(edit.fix, err)
+		}
+		spans = append(spans, s)
+	}
+
+	// Apply from the end so earlier offsets stay valid. Reversing first keeps
+	// insertions at the same offset in the order the fixes were given.
+	for i, j := 0, len(spans)-1; i < j; i, j = i+1, j-1 {
+		spans[i], spans[j] = spans[j], spans[i]
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start > spans[j].start
+	})
+	result := []byte(src.text)
+	limit := len(result) + 1
+	for _, s := range spans {
+		if s.end > limit {
+			return nil, fixError(nil, fmt.Errorf("overlapping edits at offset %d", s.start))
+		}
+		result = append(result[:s.start], append([]byte(s.text), result[s.end:]...)...)
+		if s.end > s.start {
+			limit = s.start
+		}
+	}
+	return result, nil
+}
+
+func fixError(fix *Fix, err error) *domain.DomainError {
+	rule := ""
+	if fix != nil {
+		rule = fix.Rule
+	}
+	return domain.NewValidationError(
+		domain.ErrLintViolation,
+		"Cannot apply fix",
+		err.Error(),
+	).WithRule(rule).WithCause(err)
+}
+
+// fixEdit is an edit with the fix it belongs to
+type fixEdit struct {
+	Edit
+	fix *Fix
+}
+
+// mergeEdits combines edits adding the same key to the same mapping, so two
+// fixes both creating e.g. a permissions block produce a single one
+func mergeEdits(fixes []*Fix) []fixEdit {
+	var edits []fixEdit
+	added := make(map[*yaml.Node]map[string]*yaml.Node)
+	for _, fix := range fixes {
+		for _, edit := range fix.Edits {
+			if edit.kind != editAddEntry && edit.kind != editInsertEntry {
+				edits = append(edits, fixEdit{Edit: edit, fix: fix})
+				continue
+			}
+
+			if previous := added[edit.node][edit.key]; previous != nil {
+				if previous.Kind == yaml.MappingNode && edit.value.Kind == yaml.MappingNode {
+					for i := 0; i+1 < len(edit.value.Content); i += 2 {
+						if keyNode(previous, edit.value.Content[i].Value) == nil {
+							previous.Content = append(previous.Content, edit.value.Content[i], edit.value.Content[i+1])
+						}
+					}
+				}
+				continue
+			}
+
+			// Copy the value so merging never changes the fix itself
+			value := *edit.value
+			value.Content = append([]*yaml.Node(nil), edit.value.Content...)
+			edit.value = &value
+			if added[edit.node] == nil {
+				added[edit.node] = make(map[string]*yaml.Node)
+			}
+			added[edit.node][edit.key] = edit.value
+			edits = append(edits, fixEdit{Edit: edit, fix: fix})
+		}
+	}
+	return edits
+}
+
+// span replaces content[start:end] with text
+type span struct {
+	start, end int
+	text       string
+}
+
+// source indexes the lines of the text being fixed
+type source struct {
+	text  string
+	lines []int // byte offset of each line start
+}
+
+func newSource(content []byte) *source {
+	text := string(content)
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	src := &source{text: text, lines: []int{0}}
+	for i, c := range text {
+		if c == '\n' && i+1 < len(text) {
+			src.lines = append(src.lines, i+1)
+		}
+	}
+	return src
+}
+
+// lineStart returns the offset of a 1-based line; lines past the end map to the end
+func (s *source) lineStart(line int) int {
+	if line-1 < len(s.lines) {
+		return s.lines[line-1]
+	}
+	return len(s.text)
+}
+
+func (s *source) line(line int) string {
+	start := s.lineStart(line)
+	end := strings.IndexByte(s.text[start:], '\n')
+	if end < 0 {
+		return s.text[start:]
+	}
+	return s.text[start : start+end]
+}
+
+func (e Edit) resolve(src *source) (span, error) {
+	switch e.kind {
+	case editSetValue:
+		return setValueSpan(src, e.node, e.value.Value)
+	case editAddEntry, editInsertEntry:
+		if e.node.Kind != yaml.MappingNode || e.node.Style&yaml.FlowStyle != 0 || len(e.node.Content) == 0 {
+			return span{}, fmt.Errorf("line %d is not a block mapping", e.node.Line)
+		}
+		line, indent := lastLine(e.node)+1, e.node.Content[0].Column-1
+		if e.kind == editInsertEntry {
+			key := keyNode(e.node, e.before)
+			if key == nil {
+				return span{}, fmt.Errorf("no key '%s' on line %d", e.before, e.node.Line)
+			}
+			line = key.Line - commentLines(key.HeadComment)
+		}
+		mapping := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: e.key}, e.value,
+		}}
+		offset := src.lineStart(line)
+		return span{start: offset, end: offset, text: indentLines(encodeNode(mapping), indent)}, nil
+	case editAddItem:
+		if e.node.Kind != yaml.SequenceNode || e.node.Style&yaml.FlowStyle != 0 || len(e.node.Content) == 0 {
+			return span{}, fmt.Errorf("line %d is not a block sequence", e.node.Line)
+		}
+		first := e.node.Content[0]
+		dash := strings.LastIndex(src.line(first.Line)[:first.Column-1], "-")
+		if dash < 0 {
+			return span{}, fmt.Errorf("no list item on line %d", first.Line)
+		}
+		sequence := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{e.value}}
+		offset := src.lineStart(lastLine(e.node) + 1)
+		return span{start: offset, end: offset, text: indentLines(encodeNode(sequence), dash)}, nil
+	}
+	return span{}, fmt.Errorf("unknown edit")
+}
+
+// setValueSpan locates the text of a scalar and renders its replacement
+func setValueSpan(src *source, node *yaml.Node, value string) (span, error) {
+	if node.Kind != yaml.ScalarNode || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return span{}, fmt.Errorf("line %d is not a single-line scalar", node.Line)
+	}
+	line := src.line(node.Line)
+	start := node.Column - 1
+	if start > len(line) {
+		return span{}, fmt.Errorf("line %d has no column %d", node.Line, node.Column)
+	}
+
+	var length int
+	var text string
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		length = quotedLength(line[start:], '"')
+		text = strconv.Quote(value)
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		length = quotedLength(line[start:], '\'')
+		text = "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	default:
+		if !strings.HasPrefix(line[start:], node.Value) {
+			return span{}, fmt.Errorf("line %d does not contain %q", node.Line, node.Value)
+		}
+		length = len(node.Value)
+		// Keep integers plain, quote anything that would not read back as a string
+		tag := "!!str"
+		if _, err := strconv.Atoi(value); err == nil && node.Tag == "!!int" {
+			tag = "!!int"
+		}
+		text = encodeNode(&yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value})
+	}
+	if length < 0 {
+		return span{}, fmt.Errorf("unterminated string on line %d", node.Line)
+	}
+
+	offset := src.lineStart(node.Line) + start
+	return span{start: offset, end: offset + length, text: text}, nil
+}
+
+// quotedLength returns the length of the quoted string at the start of s, or -1
+func quotedLength(s string, quote byte) int {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case s[i] == quote && quote == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// lastLine returns the last line a node's text occupies
+func lastLine(node *yaml.Node) int {
+	last := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		last += strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		if line := lastLine(child); line > last {
+			last = line
+		}
+	}
+	return last
+}
+
+func keyNode(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i]
+		}
+	}
+	return nil
+}
+
+func commentLines(comment string) int {
+	if comment == "" {
+		return 0
+	}
+	return strings.Count(comment, "\n") + 1
+}
+
+// encodeNode renders a node with two-space indentation and no trailing newline
+func encodeNode(node *yaml.Node) string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return ""
+	}
+	encoder.Close()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func indentLines(text string, indent int) string {
+	prefix := strings.Repeat(" ", indent)
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		b.WriteString(prefix + line + "\n")
+	}
+	return b.String()
+}
+
+// pathSegment matches one element of a finding field such as jobs.release.steps[0]
+var pathSegment = regexp.MustCompile(`([^.\[\]]+)|\[(\d+)\]`)
+
+// resolve returns the node at a finding field path, or nil
+func resolve(root *yaml.Node, path string) *yaml.Node {
+	node := root
+	for _, match := range pathSegment.FindAllStringSubmatch(path, -1) {
+		if node == nil {
+			return nil
+		}
+		if match[2] != "" {
+			index, _ := strconv.Atoi(match[2])
+			if node.Kind != yaml.SequenceNode || index >= len(node.Content) {
+				return nil
+			}
+			node = node.Content[index]
+			continue
+		}
+		node = yamlcheck.Lookup(node, match[1])
+	}
+	return node
+}
+
+// parent returns the path without its last element
+func parent(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// mappingNode builds a block mapping from alternating keys and values
+func mappingNode(pairs ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Content: pairs}
+}
+
+func keyScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+}
+
+func quotedScalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: value, Style: yaml.DoubleQuotedStyle}
+}
+
+func flowList(items ...string) *yaml.Node {
+	list := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	for _, item := range items {
+		list.Content = append(list.Content, keyScalar(item))
+	}
+	return list
+}
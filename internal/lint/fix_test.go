@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// fixAll lints content, applies the fixes of every finding and returns the result
+func fixAll(t *testing.T, engine *Engine, target Target, content string, project *Project) string {
+	t.Helper()
+	var fixes []*Fix
+	for _, finding := range engine.Run(target, []byte(content), parse(t, content), project) {
+		if finding.Fix != nil {
+			fixes = append(fixes, finding.Fix)
+		}
+	}
+	fixed, err := Apply([]byte(content), fixes)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	return string(fixed)
+}
+
+func TestFixConfig(t *testing.T) {
+	config := `# release config
+project_name: app
+builds:
+  - id: app # main binary
+    goos: [linux, windows]
+  - id: cli
+    goos: [windows]
+    mod_timestamp: "{{ .CommitTimestamp }}"
+archives:
+  - ids: [app]
+    formats: [tar.gz]
+  - ids: [cli]
+    format_overrides:
+    - goos: darwin
+      formats: [tar.gz]
+`
+	expected := `# release config
+project_name: app
+builds:
+  - id: app # main binary
+    goos: [linux, windows]
+    mod_timestamp: "{{ .CommitTimestamp }}"
+  - id: cli
+    goos: [windows]
+    mod_timestamp: "{{ .CommitTimestamp }}"
+archives:
+  - ids: [app]
+    formats: [tar.gz]
+    format_overrides:
+      - goos: windows
+        formats: [zip]
+  - ids: [cli]
+    format_overrides:
+    - goos: darwin
+      formats: [tar.gz]
+    - goos: windows
+      formats: [zip]
+`
+	engine := NewEngine(configRules()...)
+	fixed := fixAll(t, engine, TargetGoReleaser, config, nil)
+	if fixed != expected {
+		t.Fatalf("fixed config =\n%s\nexpected\n%s", fixed, expected)
+	}
+	if findings := run(t, engine, fixed); len(findings) != 0 {
+		t.Errorf("findings after fixing: %v", findings)
+	}
+}
+
+func TestFixWorkflow(t *testing.T) {
+	config := `version: 2
+binary_signs:
+  - cmd: cosign
+brews:
+  - repository:
+      token: "{{ .Env.TAP_TOKEN }}"
+`
+	workflow := `name: release
+on: push
+
+# release job
+jobs:
+  release:
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: '1.22'
+      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: v1.26.2
+          args: release --clean
+`
+	expected := `name: release
+on: push
+
+permissions:
+  contents: write
+  id-token: write
+# release job
+jobs:
+  release:
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - uses: actions/setup-go@v5
+        with:
+          go-version: '1.25'
+      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: ~> v2
+          args: release --clean
+        env:
+          TAP_TOKEN: ${{ secrets.TAP_TOKEN }}
+`
+	project := &Project{Config: parse(t, config), GoVersion: "1.25"}
+	engine := NewEngine(append(workflowRules(), securityRules()...)...)
+	if err := engine.Configure(nil, []string{"workflow-unpinned-action"}); err != nil {
+		t.Fatal(err)
+	}
+
+	fixed := fixAll(t, engine, TargetWorkflow, workflow, project)
+	if fixed != expected {
+		t.Fatalf("fixed workflow =\n%s\nexpected\n%s", fixed, expected)
+	}
+}
+
+func TestSetValueKeepsStyle(t *testing.T) {
+	tests := []struct {
+		line     string
+		value    string
+		expected string
+	}{
+		{line: `key: "old \" value" # note`, value: "new", expected: `key: "new" # note`},
+		{line: `key: 'it''s' # note`, value: "x'y", expected: `key: 'x''y' # note`},
+		{line: `key: old # note`, value: "1.25", expected: `key: "1.25" # note`},
+		{line: `key: 1`, value: "0", expected: `key: 0`},
+	}
+
+	for _, tt := range tests {
+		root := parse(t, tt.line)
+		fixed, err := Apply([]byte(tt.line), []*Fix{{Edits: []Edit{SetValue(root.Content[1], tt.value)}}})
+		if err != nil {
+			t.Fatalf("Apply(%s) error = %v", tt.line, err)
+		}
+		if got := strings.TrimSuffix(string(fixed), "\n"); got != tt.expected {
+			t.Errorf("Apply(%s) = %s, expected %s", tt.line, got, tt.expected)
+		}
+	}
+}
+
+func TestApplyRejectsUnsupportedEdits(t *testing.T) {
+	content := "flow: {a: 1}\nblock: |\n  text\n"
+	root := parse(t, content)
+
+	for name, edit := range map[string]Edit{
+		"flow_mapping": AddEntry(resolve(root, "flow"), "b", keyScalar("2")),
+		"block_scalar": SetValue(resolve(root, "block"), "x"),
+		"not_a_list":   AddItem(resolve(root, "flow"), keyScalar("x")),
+	} {
+		if _, err := Apply([]byte(content), []*Fix{{Edits: []Edit{edit}}}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if node := resolve(root, "flow.a"); node == nil || node.Kind != yaml.ScalarNode || node.Value != "1" {
+		t.Errorf("resolve(flow.a) = %v", node)
+	}
+}
@@ -0,0 +1,188 @@
+// Package lint runs semantic rules over parsed GoReleaser configurations and workflows
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// Target identifies the kind of file a rule applies to
+type Target string
+
+const (
+	TargetGoReleaser Target = "goreleaser"
+	TargetWorkflow   Target = "workflow"
+)
+
+// Rule checks one kind of logic bug. Check receives the document's root node
+// and the rest of the project, and returns a violation for every occurrence;
+// the engine adds the rule id.
+type Rule struct {
+	ID          string
+	Description string
+	Target      Target
+	Severity    domain.ErrorSeverity
+	Check       func(root *yaml.Node, project *Project) []*domain.DomainError
+
+	// Fix optionally returns the change resolving one of the rule's
+	// violations, or nil when it cannot be fixed automatically
+	Fix func(root *yaml.Node, project *Project, violation *domain.DomainError) *Fix
+}
+
+// Project holds the files cross-file rules compare the linted document with
+type Project struct {
+	Config    *yaml.Node // root of .goreleaser.yaml, nil when it is missing or invalid
+	GoVersion string     // go directive of go.mod, empty when unknown
+}
+
+// Finding is a violation reported by an enabled rule
+type Finding struct {
+	Err      *domain.DomainError
+	Severity domain.ErrorSeverity
+	Fix      *Fix // nil when the finding has no automatic fix
+}
+
+// Engine runs a set of rules, each of which can be disabled
+type Engine struct {
+	rules    []Rule
+	disabled map[string]bool
+}
+
+// NewEngine creates an engine with every rule enabled
+func NewEngine(rules ...Rule) *Engine {
+	return &Engine{rules: rules, disabled: make(map[string]bool)}
+}
+
+// DefaultRules returns the built-in rules
+func DefaultRules() []Rule {
+	rules := append(configRules(), workflowRules()...)
+	return append(rules, securityRules()...)
+}
+
+// Rules returns the engine's rules in registration order
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+// Enabled reports whether a rule runs
+func (e *Engine) Enabled(id string) bool {
+	return !e.disabled[id]
+}
+
+// Configure applies the user's rule selection. Disable is applied first and
+// accepts "all", so enable can switch individual rules back on.
+func (e *Engine) Configure(enable, disable []string) error {
+	for _, id := range disable {
+		if id == "all" {
+			for _, rule := range e.rules {
+				e.disabled[rule.ID] = true
+			}
+			continue
+		}
+		if err := e.checkID(id); err != nil {
+			return err
+		}
+		e.disabled[id] = true
+	}
+	for _, id := range enable {
+		if err := e.checkID(id); err != nil {
+			return err
+		}
+		delete(e.disabled, id)
+	}
+	return nil
+}
+
+func (e *Engine) checkID(id string) error {
+	for _, rule := range e.rules {
+		if rule.ID == id {
+			return nil
+		}
+	}
+	return domain.NewValidationError(
+		domain.ErrUnknownLintRule,
+		"Unknown lint rule",
+		fmt.Sprintf("No lint rule with id '%s'", id),
+	).WithField(id)
+}
+
+// Run applies the enabled rules of a target to a parsed document. Findings on
+// lines suppressed with '# wizard:ignore rule-id' are dropped.
+func (e *Engine) Run(target Target, content []byte, root *yaml.Node, project *Project) []Finding {
+	if root == nil {
+		return nil
+	}
+	if project == nil {
+		project = &Project{}
+	}
+
+	suppressed := suppressions(content)
+	var findings []Finding
+	for _, rule := range e.rules {
+		if rule.Target != target || e.disabled[rule.ID] {
+			continue
+		}
+		for _, violation := range rule.Check(root, project) {
+			if suppressed[violation.Line][rule.ID] {
+				continue
+			}
+			finding := Finding{
+				Err:      violation.WithRule(rule.ID),
+				Severity: rule.Severity,
+			}
+			if rule.Fix != nil {
+				if fix := rule.Fix(root, project, violation); fix != nil {
+					fix.Rule = rule.ID
+					finding.Fix = fix
+				}
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Err.Line < findings[j].Err.Line
+	})
+	return findings
+}
+
+// ignorePattern matches '# wizard:ignore rule-a, rule-b'
+var ignorePattern = regexp.MustCompile(`#\s*wizard:ignore\s+([\w\-, ]+)`)
+
+// suppressions maps line numbers to the rule ids ignored on them. A trailing
+// comment applies to its own line, a comment on a line of its own to the next.
+func suppressions(content []byte) map[int]map[string]bool {
+	result := make(map[int]map[string]bool)
+	for i, line := range strings.Split(string(content), "\n") {
+		match := ignorePattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		target := i + 1
+		if strings.TrimSpace(line[:match[0]]) == "" {
+			target++
+		}
+		if result[target] == nil {
+			result[target] = make(map[string]bool)
+		}
+		for _, id := range strings.FieldsFunc(line[match[2]:match[3]], func(r rune) bool {
+			return r == ',' || r == ' '
+		}) {
+			result[target][id] = true
+		}
+	}
+	return result
+}
+
+// violation builds a rule violation positioned at node
+func violation(node *yaml.Node, field, message, details string) *domain.DomainError {
+	return domain.NewValidationError(domain.ErrLintViolation, message, details).
+		WithField(field).
+		WithPosition(node.Line, node.Column)
+}
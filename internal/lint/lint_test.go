@@ -0,0 +1,195 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+func parse(t *testing.T, content string) *yaml.Node {
+	t.Helper()
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		t.Fatalf("invalid test YAML: %v", err)
+	}
+	return document.Content[0]
+}
+
+// run lints content with all default rules and returns "rule@line" per finding
+func run(t *testing.T, engine *Engine, content string) []string {
+	t.Helper()
+	var results []string
+	for _, finding := range engine.Run(TargetGoReleaser, []byte(content), parse(t, content), nil) {
+		if finding.Err.Code != domain.ErrLintViolation {
+			t.Errorf("finding code = %s", finding.Err.Code)
+		}
+		results = append(results, finding.Err.Rule+"@"+strings.TrimSpace(strings.Split(content, "\n")[finding.Err.Line-1]))
+	}
+	return results
+}
+
+func TestDefaultRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected []string
+	}{
+		{
+			name: "clean",
+			config: `project_name: app
+builds:
+  - id: app
+    mod_timestamp: "{{ .CommitTimestamp }}"
+archives:
+  - ids: [app]
+    formats: [tar.gz]
+    format_overrides:
+      - goos: windows
+        formats: [zip]
+dockers:
+  - goarch: arm64
+`,
+		},
+		{
+			name: "duplicate_build_id",
+			config: `project_name: app
+builds:
+  - mod_timestamp: x
+  - id: app
+    mod_timestamp: x
+`,
+			expected: []string{"duplicate-build-id@- id: app"},
+		},
+		{
+			name: "archive_unknown_build",
+			config: `builds:
+  - id: cli
+    mod_timestamp: x
+    goos: [linux]
+archives:
+  - ids: [cli, server]
+`,
+			expected: []string{"archive-unknown-build@- ids: [cli, server]"},
+		},
+		{
+			name: "docker_goarch_not_built",
+			config: `builds:
+  - mod_timestamp: x
+    goos: [linux]
+    goarch: [amd64]
+dockers:
+  - goarch: arm64
+  - goarch: "{{ .Env.ARCH }}"
+`,
+			expected: []string{"docker-goarch-not-built@- goarch: arm64"},
+		},
+		{
+			name: "ignore_all_targets",
+			config: `builds:
+  - mod_timestamp: x
+    goos: [linux, darwin]
+    goarch: [arm64]
+    ignore:
+      - goarch: arm64
+  - id: other
+    mod_timestamp: x
+    goos: [linux]
+    ignore:
+      - goos: linux
+        goarm: "6"
+`,
+			expected: []string{"ignore-all-targets@- goarch: arm64"},
+		},
+		{
+			name: "windows_archive_format",
+			config: `builds:
+  - mod_timestamp: x
+archives:
+  - formats: [tar.gz]
+  - id: linux-only
+    ids: [none]
+    formats: [tar.gz]
+`,
+			expected: []string{
+				"windows-archive-format@- formats: [tar.gz]",
+				"archive-unknown-build@ids: [none]",
+			},
+		},
+		{
+			name: "mod_timestamp",
+			config: `builds:
+  - id: app
+    goos: [linux]
+`,
+			expected: []string{"mod-timestamp@- id: app"},
+		},
+		{
+			name: "suppressed",
+			config: `builds:
+  # wizard:ignore mod-timestamp
+  - id: app
+    goos: [linux]
+  - id: app # wizard:ignore duplicate-build-id, mod-timestamp
+    goos: [darwin]
+`,
+		},
+		{
+			name: "suppression_is_per_rule",
+			config: `builds:
+  - id: app # wizard:ignore duplicate-build-id
+    goos: [linux]
+`,
+			expected: []string{"mod-timestamp@- id: app # wizard:ignore duplicate-build-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := run(t, NewEngine(DefaultRules()...), tt.config)
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("findings =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	config := "builds:\n  - id: app\n  - id: app\n"
+
+	engine := NewEngine(DefaultRules()...)
+	if err := engine.Configure(nil, []string{"mod-timestamp"}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if got := run(t, engine, config); len(got) != 1 || !strings.HasPrefix(got[0], "duplicate-build-id") {
+		t.Errorf("findings with mod-timestamp disabled = %v", got)
+	}
+
+	engine = NewEngine(DefaultRules()...)
+	if err := engine.Configure([]string{"mod-timestamp"}, []string{"all"}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	if got := run(t, engine, config); len(got) != 2 || !strings.HasPrefix(got[0], "mod-timestamp") {
+		t.Errorf("findings with only mod-timestamp enabled = %v", got)
+	}
+	if engine.Enabled("duplicate-build-id") {
+		t.Error("duplicate-build-id still enabled after disabling all")
+	}
+
+	err := NewEngine(DefaultRules()...).Configure(nil, []string{"no-such-rule"})
+	if !domain.IsErrorCode(err, domain.ErrUnknownLintRule) {
+		t.Errorf("Configure(unknown) error = %v, expected %s", err, domain.ErrUnknownLintRule)
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	config := "builds:\n  - id: app\n"
+	findings := NewEngine(DefaultRules()...).Run(TargetGoReleaser, []byte(config), parse(t, config), nil)
+	if len(findings) != 1 || findings[0].Severity != domain.ErrorSeverityWarning {
+		t.Fatalf("findings = %v, expected one warning", findings)
+	}
+	if findings[0].Err.Line != 2 || findings[0].Err.Field != "builds[0].mod_timestamp" {
+		t.Errorf("finding position = %d %s", findings[0].Err.Line, findings[0].Err.Field)
+	}
+}
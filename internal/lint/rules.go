@@ -0,0 +1,464 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+// GoReleaser v2 defaults for builds without goos or goarch
+var (
+	defaultGoos   = []string{"darwin", "linux", "windows"}
+	defaultGoarch = []string{"386", "amd64", "arm64"}
+)
+
+func configRules() []Rule {
+	return []Rule{
+		{
+			ID:          "duplicate-build-id",
+			Description: "Every build needs a unique id",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkDuplicateBuildIDs,
+		},
+		{
+			ID:          "archive-unknown-build",
+			Description: "Archives may only reference existing build ids",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkArchiveBuildIDs,
+		},
+		{
+			ID:          "docker-goarch-not-built",
+			Description: "Docker images need a build for their goos/goarch",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkDockerTargets,
+		},
+		{
+			ID:          "ignore-all-targets",
+			Description: "Ignore rules must leave at least one target to build",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkIgnoreAllTargets,
+		},
+		{
+			ID:          "windows-archive-format",
+			Description: "Windows builds should be archived as zip",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityWarning,
+			Check:       checkWindowsArchiveFormat,
+			Fix:         fixWindowsArchiveFormat,
+		},
+		{
+			ID:          "mod-timestamp",
+			Description: "Builds should set mod_timestamp for reproducible binaries",
+			Target:      TargetGoReleaser,
+			Severity:    domain.ErrorSeverityWarning,
+			Check:       checkModTimestamp,
+			Fix:         fixModTimestamp,
+		},
+	}
+}
+
+// build is a builds entry with its resolved id and targets
+type build struct {
+	node  *yaml.Node
+	path  string
+	id    string
+	known bool // false when targets depend on templates or target presets
+	// targets are goos/goarch pairs left after ignore rules
+	targets map[string]bool
+	// unfiltered counts the targets before ignore rules
+	unfiltered int
+}
+
+// builds resolves every build. GoReleaser adds a default build, which has
+// no node, when there is none.
+func builds(root *yaml.Node) []build {
+	projectName := scalar(yamlcheck.Lookup(root, "project_name"))
+	section := yamlcheck.Lookup(root, "builds")
+	if section == nil || section.Kind != yaml.SequenceNode {
+		return []build{resolveBuild(nil, "builds", projectName)}
+	}
+
+	var result []build
+	for i, item := range section.Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+		result = append(result, resolveBuild(item, "builds["+strconv.Itoa(i)+"]", projectName))
+	}
+	return result
+}
+
+func resolveBuild(node *yaml.Node, path, projectName string) build {
+	b := build{node: node, path: path, id: projectName, known: true, targets: make(map[string]bool)}
+	if id := scalar(yamlcheck.Lookup(node, "id")); id != "" {
+		b.id = id
+	}
+
+	goos := values(yamlcheck.Lookup(node, "goos"))
+	goarch := values(yamlcheck.Lookup(node, "goarch"))
+	presets := values(yamlcheck.Lookup(node, "targets"))
+
+	var pairs [][2]string
+	if len(presets) > 0 {
+		for _, target := range presets {
+			parts := strings.Split(target, "_")
+			if len(parts) < 2 || strings.Contains(target, "first_class") {
+				b.known = false
+				continue
+			}
+			pairs = append(pairs, [2]string{parts[0], parts[1]})
+		}
+	} else {
+		if len(goos) == 0 {
+			goos = defaultGoos
+		}
+		if len(goarch) == 0 {
+			goarch = defaultGoarch
+		}
+		for _, system := range goos {
+			for _, arch := range goarch {
+				pairs = append(pairs, [2]string{system, arch})
+			}
+		}
+	}
+
+	var ignores [][2]string
+	if ignore := yamlcheck.Lookup(node, "ignore"); ignore != nil && ignore.Kind == yaml.SequenceNode {
+		for _, item := range ignore.Content {
+			// Entries naming a variant only drop that variant, not the target
+			if hasAny(item, "goarm", "goamd64", "goarm64", "go386", "gomips", "goppc64", "goriscv64") {
+				continue
+			}
+			ignores = append(ignores, [2]string{
+				scalar(yamlcheck.Lookup(item, "goos")),
+				scalar(yamlcheck.Lookup(item, "goarch")),
+			})
+		}
+	}
+
+	for _, pair := range pairs {
+		if templated(pair[0]) || templated(pair[1]) {
+			b.known = false
+			continue
+		}
+		b.unfiltered++
+		if !ignored(pair, ignores) {
+			b.targets[pair[0]+"/"+pair[1]] = true
+		}
+	}
+	return b
+}
+
+func ignored(pair [2]string, ignores [][2]string) bool {
+	for _, ignore := range ignores {
+		if (ignore[0] == "" || ignore[0] == pair[0]) && (ignore[1] == "" || ignore[1] == pair[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkDuplicateBuildIDs(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	seen := make(map[string]build)
+	for _, b := range builds(root) {
+		if b.node == nil {
+			continue
+		}
+		if first, ok := seen[b.id]; ok {
+			node := b.node
+			if id := yamlcheck.Lookup(b.node, "id"); id != nil {
+				node = id
+			}
+			violations = append(violations, violation(node, b.path+".id",
+				"Duplicate build id",
+				fmt.Sprintf("Build id '%s' is already used by %s (line %d)", b.id, first.path, first.node.Line),
+			))
+			continue
+		}
+		seen[b.id] = b
+	}
+	return violations
+}
+
+func checkArchiveBuildIDs(root *yaml.Node, _ *Project) []*domain.DomainError {
+	ids := make(map[string]bool)
+	for _, b := range builds(root) {
+		ids[b.id] = true
+	}
+
+	var violations []*domain.DomainError
+	for i, archive := range sequence(root, "archives") {
+		for _, key := range []string{"ids", "builds"} {
+			list := yamlcheck.Lookup(archive, key)
+			if list == nil || list.Kind != yaml.SequenceNode {
+				continue
+			}
+			for j, item := range list.Content {
+				if templated(item.Value) || ids[item.Value] {
+					continue
+				}
+				violations = append(violations, violation(item,
+					fmt.Sprintf("archives[%d].%s[%d]", i, key, j),
+					"Archive references an unknown build",
+					fmt.Sprintf("No build has id '%s'", item.Value),
+				))
+			}
+		}
+	}
+	return violations
+}
+
+func checkDockerTargets(root *yaml.Node, _ *Project) []*domain.DomainError {
+	all := builds(root)
+
+	var violations []*domain.DomainError
+	for i, docker := range sequence(root, "dockers") {
+		goos := scalar(yamlcheck.Lookup(docker, "goos"))
+		if goos == "" {
+			goos = "linux"
+		}
+		goarch := scalar(yamlcheck.Lookup(docker, "goarch"))
+		if goarch == "" {
+			goarch = "amd64"
+		}
+		if templated(goos) || templated(goarch) {
+			continue
+		}
+
+		selected := values(yamlcheck.Lookup(docker, "ids"))
+		built, known := false, true
+		for _, b := range all {
+			if len(selected) > 0 && !contains(selected, b.id) {
+				continue
+			}
+			known = known && b.known
+			built = built || b.targets[goos+"/"+goarch]
+		}
+		if built || !known {
+			continue
+		}
+
+		node := docker
+		if value := yamlcheck.Lookup(docker, "goarch"); value != nil {
+			node = value
+		}
+		violations = append(violations, violation(node, fmt.Sprintf("dockers[%d].goarch", i),
+			"Docker image has no matching build",
+			fmt.Sprintf("No build produces %s/%s", goos, goarch),
+		))
+	}
+	return violations
+}
+
+func checkIgnoreAllTargets(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	for _, b := range builds(root) {
+		if !b.known || b.unfiltered == 0 || len(b.targets) > 0 {
+			continue
+		}
+		node := yamlcheck.Lookup(b.node, "ignore")
+		if node == nil {
+			continue
+		}
+		violations = append(violations, violation(node, b.path+".ignore",
+			"Ignore rules remove every target",
+			fmt.Sprintf("Build '%s' has nothing left to build", b.id),
+		))
+	}
+	return violations
+}
+
+func checkWindowsArchiveFormat(root *yaml.Node, _ *Project) []*domain.DomainError {
+	all := builds(root)
+
+	var violations []*domain.DomainError
+	for i, archive := range sequence(root, "archives") {
+		selected := values(yamlcheck.Lookup(archive, "ids"))
+		if len(selected) == 0 {
+			selected = values(yamlcheck.Lookup(archive, "builds"))
+		}
+		windows := false
+		for _, b := range all {
+			if len(selected) > 0 && !contains(selected, b.id) {
+				continue
+			}
+			for target := range b.targets {
+				windows = windows || strings.HasPrefix(target, "windows/")
+			}
+		}
+		if !windows || hasWindowsOverride(archive) {
+			continue
+		}
+
+		formats, node := archiveFormats(archive)
+		if !allTarballs(formats) {
+			continue
+		}
+		if node == nil {
+			node = archive
+		}
+		violations = append(violations, violation(node, fmt.Sprintf("archives[%d].formats", i),
+			"Windows builds archived as tarball",
+			"Add a format_overrides entry with goos: windows and formats: [zip]",
+		))
+	}
+	return violations
+}
+
+// fixWindowsArchiveFormat adds a zip override. It is unsafe: the published
+// Windows artifacts change name and format.
+func fixWindowsArchiveFormat(root *yaml.Node, _ *Project, v *domain.DomainError) *Fix {
+	archive := resolve(root, parent(v.Field))
+	if archive == nil || archive.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	formatKey, format := "formats", flowList("zip")
+	if yamlcheck.Lookup(archive, "format") != nil {
+		formatKey, format = "format", keyScalar("zip")
+	}
+	override := mappingNode(keyScalar("goos"), keyScalar("windows"), keyScalar(formatKey), format)
+
+	fix := &Fix{Description: "Archive Windows builds as zip", Unsafe: true}
+	if overrides := yamlcheck.Lookup(archive, "format_overrides"); overrides != nil {
+		fix.Edits = []Edit{AddItem(overrides, override)}
+	} else {
+		list := &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{override}}
+		fix.Edits = []Edit{AddEntry(archive, "format_overrides", list)}
+	}
+	return fix
+}
+
+func checkModTimestamp(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	for _, b := range builds(root) {
+		if b.node == nil || yamlcheck.Lookup(b.node, "mod_timestamp") != nil {
+			continue
+		}
+		violations = append(violations, violation(b.node, b.path+".mod_timestamp",
+			"Build is not reproducible",
+			`Set mod_timestamp: "{{ .CommitTimestamp }}" so binaries do not change between runs`,
+		))
+	}
+	return violations
+}
+
+func fixModTimestamp(root *yaml.Node, _ *Project, v *domain.DomainError) *Fix {
+	build := resolve(root, parent(v.Field))
+	if build == nil || build.Kind != yaml.MappingNode {
+		return nil
+	}
+	return &Fix{
+		Description: "Set mod_timestamp to the commit timestamp",
+		Edits:       []Edit{AddEntry(build, "mod_timestamp", quotedScalar("{{ .CommitTimestamp }}"))},
+	}
+}
+
+// archiveFormats returns the formats of an archive and the node declaring
+// them; GoReleaser defaults to tar.gz
+func archiveFormats(archive *yaml.Node) ([]string, *yaml.Node) {
+	for _, key := range []string{"formats", "format"} {
+		if node := yamlcheck.Lookup(archive, key); node != nil {
+			return values(node), node
+		}
+	}
+	return []string{"tar.gz"}, nil
+}
+
+func hasWindowsOverride(archive *yaml.Node) bool {
+	overrides := yamlcheck.Lookup(archive, "format_overrides")
+	if overrides == nil || overrides.Kind != yaml.SequenceNode {
+		return false
+	}
+	for _, override := range overrides.Content {
+		if scalar(yamlcheck.Lookup(override, "goos")) == "windows" {
+			return true
+		}
+	}
+	return false
+}
+
+// tarballFormats are the archive formats Windows users cannot open natively
+var tarballFormats = map[string]bool{
+	"tar.gz": true, "tgz": true, "tar.xz": true, "txz": true,
+	"tar.zst": true, "tzst": true, "tar": true, "gz": true,
+}
+
+func allTarballs(formats []string) bool {
+	for _, format := range formats {
+		if !tarballFormats[format] {
+			return false
+		}
+	}
+	return len(formats) > 0
+}
+
+// sequence returns the mapping items of a top-level list
+func sequence(root *yaml.Node, key string) []*yaml.Node {
+	section := yamlcheck.Lookup(root, key)
+	if section == nil || section.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var items []*yaml.Node
+	for _, item := range section.Content {
+		if item.Kind == yaml.MappingNode {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func scalar(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return node.Value
+}
+
+// values returns the values of a list node, or of a single scalar
+func values(node *yaml.Node) []string {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return []string{node.Value}
+	}
+	var values []string
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			values = append(values, item.Value)
+		}
+	}
+	return values
+}
+
+func hasAny(mapping *yaml.Node, keys ...string) bool {
+	for _, key := range keys {
+		if yamlcheck.Lookup(mapping, key) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func templated(value string) bool {
+	return strings.Contains(value, "{{")
+}
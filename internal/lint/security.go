@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+func securityRules() []Rule {
+	return []Rule{
+		{
+			ID:          "workflow-unpinned-action",
+			Description: "Third-party actions must be pinned to a full commit SHA",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityWarning,
+			Check:       checkUnpinnedActions,
+		},
+		{
+			ID:          "workflow-permissions",
+			Description: "Workflows must declare top-level permissions",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityWarning,
+			Check:       checkPermissions,
+			Fix:         fixPermissions,
+		},
+		{
+			ID:          "workflow-pull-request-target",
+			Description: "pull_request_target must not check out the pull request head",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkPullRequestTarget,
+		},
+		{
+			ID:          "workflow-script-injection",
+			Description: "Untrusted event data must not be interpolated into run scripts",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkScriptInjection,
+		},
+		{
+			ID:          "workflow-insecure-command",
+			Description: "The set-output and add-path workflow commands are insecure",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkInsecureCommands,
+		},
+	}
+}
+
+// allSteps returns the steps of every job
+func allSteps(root *yaml.Node) []step {
+	jobs := yamlcheck.Lookup(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var steps []step
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		path := "jobs." + jobs.Content[i].Value
+		list := yamlcheck.Lookup(jobs.Content[i+1], "steps")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for n, item := range list.Content {
+			steps = append(steps, step{node: item, path: path + ".steps[" + strconv.Itoa(n) + "]"})
+		}
+	}
+	return steps
+}
+
+// commitSHA matches a full 40 character commit SHA
+var commitSHA = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func checkUnpinnedActions(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	for _, s := range allSteps(root) {
+		uses := yamlcheck.Lookup(s.node, "uses")
+		if uses == nil || firstParty(uses.Value) {
+			continue
+		}
+		_, ref, _ := strings.Cut(uses.Value, "@")
+		if commitSHA.MatchString(ref) {
+			continue
+		}
+		violations = append(violations, violation(uses, s.path+".uses",
+			"Action not pinned to a commit SHA",
+			fmt.Sprintf("%s can change under the same ref; pin it to a full commit SHA and keep the version in a comment", uses.Value),
+		))
+	}
+	return violations
+}
+
+// firstParty reports whether an action is maintained by GitHub or local to the repository
+func firstParty(uses string) bool {
+	return strings.HasPrefix(uses, "actions/") ||
+		strings.HasPrefix(uses, "github/") ||
+		strings.HasPrefix(uses, "./") ||
+		strings.HasPrefix(uses, "docker://")
+}
+
+func checkPermissions(root *yaml.Node, _ *Project) []*domain.DomainError {
+	if yamlcheck.Lookup(root, "permissions") != nil || root.Kind != yaml.MappingNode || len(root.Content) == 0 {
+		return nil
+	}
+	return []*domain.DomainError{violation(root.Content[0], "permissions",
+		"Top-level permissions missing",
+		"Without a permissions block the GITHUB_TOKEN gets the repository default, often write-all; declare the minimum, e.g. 'permissions: contents: read'",
+	)}
+}
+
+// fixPermissions declares the permissions GoReleaser needs, or read-only
+// access. It is unsafe: steps relying on the repository default lose access.
+func fixPermissions(root *yaml.Node, _ *Project, _ *domain.DomainError) *Fix {
+	access := "read"
+	if j, _ := releaseJob(root); j != nil {
+		access = "write"
+	}
+	permissions := mappingNode(keyScalar("contents"), keyScalar(access))
+
+	edit := AddEntry(root, "permissions", permissions)
+	if yamlcheck.Lookup(root, "jobs") != nil {
+		edit = InsertEntry(root, "jobs", "permissions", permissions)
+	}
+	return &Fix{Description: "Declare contents: " + access + " permissions", Unsafe: true, Edits: []Edit{edit}}
+}
+
+func checkPullRequestTarget(root *yaml.Node, _ *Project) []*domain.DomainError {
+	if !triggeredBy(root, "pull_request_target") {
+		return nil
+	}
+
+	var violations []*domain.DomainError
+	for _, s := range allSteps(root) {
+		if s.action() != "actions/checkout" {
+			continue
+		}
+		ref := s.with("ref")
+		if ref == nil || !untrustedRef(ref.Value) {
+			continue
+		}
+		violations = append(violations, violation(ref, s.path+".with.ref",
+			"Pull request head checked out in pull_request_target",
+			"pull_request_target runs with write permissions and secrets; checking out the pull request lets untrusted code use them",
+		))
+	}
+	return violations
+}
+
+// triggeredBy reports whether the workflow's on: lists an event
+func triggeredBy(root *yaml.Node, event string) bool {
+	on := yamlcheck.Lookup(root, "on")
+	if on == nil {
+		return false
+	}
+	switch on.Kind {
+	case yaml.MappingNode:
+		return yamlcheck.Lookup(on, event) != nil
+	default:
+		return contains(values(on), event)
+	}
+}
+
+func untrustedRef(ref string) bool {
+	for _, context := range []string{"github.event.pull_request.head", "github.head_ref", "refs/pull/"} {
+		if strings.Contains(ref, context) {
+			return true
+		}
+	}
+	return false
+}
+
+// expression matches a GitHub Actions ${{ }} expression
+var expression = regexp.MustCompile(`\$\{\{\s*(.*?)\s*\}\}`)
+
+func checkScriptInjection(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	for _, s := range allSteps(root) {
+		run := yamlcheck.Lookup(s.node, "run")
+		if run == nil || !strings.Contains(run.Value, "${{") {
+			continue
+		}
+		for i, line := range strings.Split(run.Value, "\n") {
+			for _, match := range expression.FindAllStringSubmatch(line, -1) {
+				if !untrustedExpression(match[1]) {
+					continue
+				}
+				violations = append(violations, scriptViolation(run, i, s.path+".run",
+					"Untrusted input in run script",
+					fmt.Sprintf("%s is controlled by whoever triggers the workflow and is pasted into the script; pass it through env and use \"$VAR\" instead", match[0]),
+				))
+			}
+		}
+	}
+	return violations
+}
+
+func untrustedExpression(expr string) bool {
+	return strings.Contains(expr, "github.event.") || strings.Contains(expr, "github.head_ref")
+}
+
+func checkInsecureCommands(root *yaml.Node, _ *Project) []*domain.DomainError {
+	var violations []*domain.DomainError
+	for _, s := range allSteps(root) {
+		run := yamlcheck.Lookup(s.node, "run")
+		if run == nil {
+			continue
+		}
+		// The runner matches workflow commands case-insensitively
+		script := strings.ToLower(run.Value)
+		for _, command := range []string{"::set-output", "::add-path"} {
+			if !strings.Contains(script, command) {
+				continue
+			}
+			for i, line := range strings.Split(script, "\n") {
+				if strings.Contains(line, command) {
+					violations = append(violations, scriptViolation(run, i, s.path+".run",
+						"Insecure workflow command",
+						fmt.Sprintf("%s lets anything printed to the log set outputs or PATH; write to $GITHUB_OUTPUT or $GITHUB_PATH instead", command),
+					))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// scriptViolation builds a violation positioned at the n-th line of a run script
+func scriptViolation(run *yaml.Node, n int, field, message, details string) *domain.DomainError {
+	err := violation(run, field, message, details)
+	if run.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		// Block scalars start on the line after their indicator
+		err = err.WithPosition(run.Line+1+n, run.Column)
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecurityRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		workflow string
+		expected []string
+	}{
+		{
+			name: "hardened",
+			workflow: `name: ci
+on: push
+permissions:
+  contents: read
+jobs:
+  test:
+    steps:
+      - uses: actions/checkout@v4
+      - uses: ./.github/actions/setup
+      - uses: goreleaser/goreleaser-action@9ed2f89a662bf1735a48bc8557fd212fa902bebf # v6
+      - run: echo "$TITLE"
+        env:
+          TITLE: ${{ github.event.pull_request.title }}
+      - run: echo "${{ matrix.os }}" >> "$GITHUB_OUTPUT"
+`,
+		},
+		{
+			name: "unpinned_actions",
+			workflow: `permissions: {}
+jobs:
+  test:
+    steps:
+      - uses: goreleaser/goreleaser-action@v6
+      - uses: docker/login-action@main
+`,
+			expected: []string{
+				"workflow-unpinned-action@- uses: goreleaser/goreleaser-action@v6",
+				"workflow-unpinned-action@- uses: docker/login-action@main",
+			},
+		},
+		{
+			name: "missing_permissions",
+			workflow: `name: ci
+on: push
+jobs: {}
+`,
+			expected: []string{"workflow-permissions@name: ci"},
+		},
+		{
+			name: "pull_request_target_checkout",
+			workflow: `on: [pull_request_target]
+permissions: {}
+jobs:
+  test:
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+`,
+			expected: []string{"workflow-pull-request-target@ref: ${{ github.event.pull_request.head.sha }}"},
+		},
+		{
+			name: "pull_request_checkout",
+			workflow: `on:
+  pull_request:
+permissions: {}
+jobs:
+  test:
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+`,
+		},
+		{
+			name: "script_injection",
+			workflow: `permissions: {}
+jobs:
+  test:
+    steps:
+      - run: echo "${{ github.event.issue.title }}"
+      - run: |
+          echo start
+          git checkout ${{ github.head_ref }}
+          echo "::set-output name=x::1"
+`,
+			expected: []string{
+				"workflow-script-injection@- run: echo \"${{ github.event.issue.title }}\"",
+				"workflow-script-injection@git checkout ${{ github.head_ref }}",
+				"workflow-insecure-command@echo \"::set-output name=x::1\"",
+			},
+		},
+		{
+			name: "insecure_command_any_case",
+			workflow: `permissions: {}
+jobs:
+  test:
+    steps:
+      - run: echo "::ADD-PATH::/tmp/bin"
+`,
+			expected: []string{"workflow-insecure-command@- run: echo \"::ADD-PATH::/tmp/bin\""},
+		},
+		{
+			name: "suppressed",
+			workflow: `permissions: {}
+jobs:
+  test:
+    steps:
+      - uses: acme/action@v1 # wizard:ignore workflow-unpinned-action
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runWorkflow(t, NewEngine(securityRules()...), "{}", tt.workflow, "")
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("findings =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,521 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+func workflowRules() []Rule {
+	return []Rule{
+		{
+			ID:          "workflow-docker-login",
+			Description: "Pushing Docker images needs a registry login in the release job",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkDockerLogin,
+		},
+		{
+			ID:          "workflow-id-token",
+			Description: "Keyless signing needs the id-token: write permission",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkIDToken,
+			Fix:         fixIDToken,
+		},
+		{
+			ID:          "workflow-fetch-depth",
+			Description: "The changelog needs the full history: checkout with fetch-depth: 0",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkFetchDepth,
+			Fix:         fixFetchDepth,
+		},
+		{
+			ID:          "workflow-publisher-token",
+			Description: "Tokens used by brews and scoops must be passed to GoReleaser from secrets",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkPublisherTokens,
+			Fix:         fixPublisherToken,
+		},
+		{
+			ID:          "workflow-go-version",
+			Description: "setup-go must install the Go version required by go.mod",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkGoVersion,
+			Fix:         fixGoVersion,
+		},
+		{
+			ID:          "workflow-goreleaser-version",
+			Description: "goreleaser-action must run the major version the config is written for",
+			Target:      TargetWorkflow,
+			Severity:    domain.ErrorSeverityError,
+			Check:       checkGoReleaserVersion,
+			Fix:         fixGoReleaserVersion,
+		},
+	}
+}
+
+// step is a workflow step with its location
+type step struct {
+	node *yaml.Node
+	path string
+}
+
+func (s step) uses() string {
+	return scalar(yamlcheck.Lookup(s.node, "uses"))
+}
+
+func (s step) run() string {
+	return scalar(yamlcheck.Lookup(s.node, "run"))
+}
+
+func (s step) with(key string) *yaml.Node {
+	return yamlcheck.Lookup(yamlcheck.Lookup(s.node, "with"), key)
+}
+
+// action returns the action name of a step without its ref, e.g. actions/checkout
+func (s step) action() string {
+	name, _, _ := strings.Cut(s.uses(), "@")
+	return name
+}
+
+// job is a workflow job with its steps
+type job struct {
+	node  *yaml.Node
+	path  string
+	steps []step
+}
+
+// releaseJob returns the job running GoReleaser and its GoReleaser step
+func releaseJob(root *yaml.Node) (*job, *step) {
+	jobs := yamlcheck.Lookup(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		j := &job{node: jobs.Content[i+1], path: "jobs." + jobs.Content[i].Value}
+		list := yamlcheck.Lookup(j.node, "steps")
+		if list == nil || list.Kind != yaml.SequenceNode {
+			continue
+		}
+		for n, item := range list.Content {
+			j.steps = append(j.steps, step{node: item, path: j.path + ".steps[" + strconv.Itoa(n) + "]"})
+		}
+
+		for n, s := range j.steps {
+			if s.action() == "goreleaser/goreleaser-action" || strings.Contains(s.run(), "goreleaser release") {
+				return j, &j.steps[n]
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ReleaseConfig returns where a workflow runs GoReleaser: the workdir input
+// of goreleaser-action, "." by default, and the --config argument, "" when
+// GoReleaser picks its default file. ok is false when no job runs GoReleaser.
+func ReleaseConfig(root *yaml.Node) (workdir, config string, ok bool) {
+	_, release := releaseJob(root)
+	if release == nil {
+		return "", "", false
+	}
+
+	workdir, args := ".", release.run()
+	if release.action() == "goreleaser/goreleaser-action" {
+		if dir := scalar(release.with("workdir")); dir != "" {
+			workdir = dir
+		}
+		args = scalar(release.with("args"))
+	}
+
+	fields := strings.Fields(args)
+	for i, field := range fields {
+		switch {
+		case (field == "--config" || field == "-f") && i+1 < len(fields):
+			config = fields[i+1]
+		case strings.HasPrefix(field, "--config="), strings.HasPrefix(field, "-f="):
+			config = field[strings.Index(field, "=")+1:]
+		}
+	}
+	return workdir, config, true
+}
+
+func checkDockerLogin(root *yaml.Node, project *Project) []*domain.DomainError {
+	if !pushesImages(project.Config) {
+		return nil
+	}
+	j, release := releaseJob(root)
+	if j == nil {
+		return nil
+	}
+
+	for _, s := range j.steps {
+		if s.action() == "docker/login-action" || strings.Contains(s.run(), "docker login") {
+			return nil
+		}
+	}
+	return []*domain.DomainError{violation(release.node, release.path,
+		"Docker login missing",
+		"The config pushes Docker images but the release job never logs in; add a docker/login-action step before GoReleaser",
+	)}
+}
+
+// pushesImages reports whether any dockers entry pushes its image
+func pushesImages(config *yaml.Node) bool {
+	for _, docker := range sequence(config, "dockers") {
+		if scalar(yamlcheck.Lookup(docker, "skip_push")) != "true" {
+			return true
+		}
+	}
+	return false
+}
+
+func checkIDToken(root *yaml.Node, project *Project) []*domain.DomainError {
+	if !keylessSigning(project.Config) {
+		return nil
+	}
+	j, release := releaseJob(root)
+	if j == nil {
+		return nil
+	}
+
+	// Job permissions replace the workflow permissions entirely
+	permissions := yamlcheck.Lookup(j.node, "permissions")
+	if permissions == nil {
+		permissions = yamlcheck.Lookup(root, "permissions")
+	}
+	if scalar(permissions) == "write-all" || scalar(yamlcheck.Lookup(permissions, "id-token")) == "write" {
+		return nil
+	}
+
+	node := release.node
+	if permissions != nil {
+		node = permissions
+	}
+	return []*domain.DomainError{violation(node, j.path+".permissions",
+		"id-token permission missing",
+		"The config signs with cosign without a key, which needs 'id-token: write' to obtain an OIDC token",
+	)}
+}
+
+// fixIDToken grants id-token: write. It is unsafe as it widens the token's permissions.
+func fixIDToken(root *yaml.Node, _ *Project, v *domain.DomainError) *Fix {
+	j := resolve(root, parent(v.Field))
+	permissions := yamlcheck.Lookup(j, "permissions")
+	if permissions == nil {
+		permissions = yamlcheck.Lookup(root, "permissions")
+	}
+
+	fix := &Fix{Description: "Grant id-token: write", Unsafe: true}
+	switch {
+	case permissions == nil:
+		fix.Edits = []Edit{InsertEntry(root, "jobs", "permissions", mappingNode(
+			keyScalar("contents"), keyScalar("write"),
+			keyScalar("id-token"), keyScalar("write"),
+		))}
+	case permissions.Kind != yaml.MappingNode:
+		return nil
+	case yamlcheck.Lookup(permissions, "id-token") != nil:
+		fix.Edits = []Edit{SetValue(yamlcheck.Lookup(permissions, "id-token"), "write")}
+	default:
+		fix.Edits = []Edit{AddEntry(permissions, "id-token", keyScalar("write"))}
+	}
+	return fix
+}
+
+// keylessSigning reports whether a cosign signature is made without --key
+func keylessSigning(config *yaml.Node) bool {
+	for _, section := range []string{"signs", "docker_signs", "binary_signs"} {
+		for _, sign := range sequence(config, section) {
+			if !strings.Contains(scalar(yamlcheck.Lookup(sign, "cmd")), "cosign") {
+				continue
+			}
+			keyed := false
+			for _, arg := range values(yamlcheck.Lookup(sign, "args")) {
+				keyed = keyed || strings.HasPrefix(arg, "--key")
+			}
+			if !keyed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func checkFetchDepth(root *yaml.Node, project *Project) []*domain.DomainError {
+	if project.Config == nil {
+		return nil
+	}
+	changelog := yamlcheck.Lookup(project.Config, "changelog")
+	if scalar(yamlcheck.Lookup(changelog, "disable")) == "true" || scalar(yamlcheck.Lookup(changelog, "skip")) == "true" {
+		return nil
+	}
+
+	j, _ := releaseJob(root)
+	if j == nil {
+		return nil
+	}
+	for _, s := range j.steps {
+		if s.action() != "actions/checkout" {
+			continue
+		}
+		if depth := s.with("fetch-depth"); depth != nil && depth.Value == "0" {
+			return nil
+		}
+		return []*domain.DomainError{violation(s.node, s.path+".with.fetch-depth",
+			"Shallow checkout",
+			"actions/checkout fetches a single commit by default; set 'fetch-depth: 0' so GoReleaser sees the tags and history for the changelog",
+		)}
+	}
+	return nil
+}
+
+func fixFetchDepth(root *yaml.Node, _ *Project, v *domain.DomainError) *Fix {
+	checkout := resolve(root, parent(parent(v.Field)))
+	if checkout == nil {
+		return nil
+	}
+	return &Fix{
+		Description: "Check out the full history",
+		Edits:       []Edit{setInput(checkout, "fetch-depth", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: "0"})},
+	}
+}
+
+// setInput sets an input in the with: block of a step, creating it if needed
+func setInput(s *yaml.Node, key string, value *yaml.Node) Edit {
+	with := yamlcheck.Lookup(s, "with")
+	if with == nil {
+		return AddEntry(s, "with", mappingNode(keyScalar(key), value))
+	}
+	if current := yamlcheck.Lookup(with, key); current != nil {
+		return SetValue(current, value.Value)
+	}
+	return AddEntry(with, key, value)
+}
+
+// envReference matches template references to environment variables
+var envReference = regexp.MustCompile(`\.Env\.(\w+)`)
+
+func checkPublisherTokens(root *yaml.Node, project *Project) []*domain.DomainError {
+	j, release := releaseJob(root)
+	if j == nil {
+		return nil
+	}
+
+	var violations []*domain.DomainError
+	for _, section := range []string{"brews", "scoops"} {
+		for i, publisher := range sequence(project.Config, section) {
+			for _, key := range []string{"repository", "tap", "bucket"} {
+				token := scalar(yamlcheck.Lookup(yamlcheck.Lookup(publisher, key), "token"))
+				for _, match := range envReference.FindAllStringSubmatch(token, -1) {
+					name := match[1]
+					value := envValue(root, j, release, name)
+					if value != nil && strings.Contains(value.Value, "secrets.") {
+						continue
+					}
+
+					details := fmt.Sprintf("%s[%d].%s.token reads %s, but the release step does not set it", section, i, key, name)
+					node := release.node
+					if value != nil {
+						details = fmt.Sprintf("%s is not read from a secret; use ${{ secrets.%s }}", name, name)
+						node = value
+					}
+					violations = append(violations, violation(node, release.path+".env."+name,
+						"Publisher token not passed from secrets",
+						details,
+					))
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// fixPublisherToken passes the token from the secret of the same name.
+// Replacing a value that is set some other way is unsafe.
+func fixPublisherToken(root *yaml.Node, _ *Project, v *domain.DomainError) *Fix {
+	name := v.Field[strings.LastIndex(v.Field, ".")+1:]
+	release := resolve(root, parent(parent(v.Field)))
+	if release == nil {
+		return nil
+	}
+
+	secret := keyScalar("${{ secrets." + name + " }}")
+	fix := &Fix{Description: "Pass " + name + " from secrets"}
+	env := yamlcheck.Lookup(release, "env")
+	switch {
+	case env == nil:
+		fix.Edits = []Edit{AddEntry(release, "env", mappingNode(keyScalar(name), secret))}
+	case yamlcheck.Lookup(env, name) != nil:
+		fix.Unsafe = true
+		fix.Edits = []Edit{SetValue(yamlcheck.Lookup(env, name), secret.Value)}
+	default:
+		fix.Edits = []Edit{AddEntry(env, name, secret)}
+	}
+	return fix
+}
+
+// envValue looks up an environment variable visible to a step
+func envValue(root *yaml.Node, j *job, s *step, name string) *yaml.Node {
+	for _, scope := range []*yaml.Node{s.node, j.node, root} {
+		if value := yamlcheck.Lookup(yamlcheck.Lookup(scope, "env"), name); value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// goDirective matches the go line of go.mod
+var goDirective = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)\s*$`)
+
+// ParseGoVersion returns the go directive of a go.mod file, or "" when absent
+func ParseGoVersion(gomod []byte) string {
+	if match := goDirective.FindSubmatch(gomod); match != nil {
+		return string(match[1])
+	}
+	return ""
+}
+
+func checkGoVersion(root *yaml.Node, project *Project) []*domain.DomainError {
+	if project.GoVersion == "" {
+		return nil
+	}
+	j, _ := releaseJob(root)
+	if j == nil {
+		return nil
+	}
+
+	required := majorMinor(project.GoVersion)
+	for _, s := range j.steps {
+		if s.action() != "actions/setup-go" || s.with("go-version-file") != nil {
+			continue
+		}
+		version := s.with("go-version")
+		if version == nil {
+			return []*domain.DomainError{violation(s.node, s.path+".with.go-version",
+				"Go version not pinned",
+				fmt.Sprintf("setup-go installs whatever Go is preinstalled; set go-version-file: go.mod or go-version: '%s'", required),
+			)}
+		}
+		// Expressions and aliases like stable are resolved by the runner
+		if strings.Contains(version.Value, "${{") || !strings.ContainsAny(version.Value, "0123456789") {
+			return nil
+		}
+		if majorMinor(strings.TrimLeft(version.Value, "^~>=v ")) != required {
+			return []*domain.DomainError{violation(version, s.path+".with.go-version",
+				"Go version differs from go.mod",
+				fmt.Sprintf("setup-go installs %s but go.mod requires %s; use go-version-file: go.mod", version.Value, project.GoVersion),
+			)}
+		}
+	}
+	return nil
+}
+
+// fixGoVersion installs the go.mod version. It is unsafe: releases are built
+// with a different toolchain.
+func fixGoVersion(root *yaml.Node, project *Project, v *domain.DomainError) *Fix {
+	setup := resolve(root, parent(parent(v.Field)))
+	if setup == nil {
+		return nil
+	}
+
+	fix := &Fix{Description: "Install the Go version from go.mod", Unsafe: true}
+	if current := yamlcheck.Lookup(yamlcheck.Lookup(setup, "with"), "go-version"); current != nil {
+		fix.Edits = []Edit{SetValue(current, project.GoVersion)}
+	} else {
+		fix.Edits = []Edit{setInput(setup, "go-version-file", keyScalar("go.mod"))}
+	}
+	return fix
+}
+
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+func checkGoReleaserVersion(root *yaml.Node, project *Project) []*domain.DomainError {
+	if project.Config == nil {
+		return nil
+	}
+	_, release := releaseJob(root)
+	if release == nil || release.action() != "goreleaser/goreleaser-action" {
+		return nil
+	}
+
+	expected := 1
+	if scalar(yamlcheck.Lookup(project.Config, "version")) == "2" {
+		expected = 2
+	}
+
+	version := release.with("version")
+	actual, known := actionMajor(version)
+	if !known || actual == expected {
+		return nil
+	}
+
+	node := release.node
+	value := "latest"
+	if version != nil {
+		node = version
+		value = version.Value
+	}
+	return []*domain.DomainError{violation(node, release.path+".with.version",
+		"GoReleaser version differs from the config",
+		fmt.Sprintf("The config is written for GoReleaser v%d but the action runs %s (v%d); use version: \"~> v%d\"", expected, value, actual, expected),
+	)}
+}
+
+// fixGoReleaserVersion pins the action to the config's major version. It is
+// unsafe: a different GoReleaser runs the release.
+func fixGoReleaserVersion(root *yaml.Node, project *Project, v *domain.DomainError) *Fix {
+	release := resolve(root, parent(parent(v.Field)))
+	if release == nil {
+		return nil
+	}
+
+	expected := "~> v1"
+	if scalar(yamlcheck.Lookup(project.Config, "version")) == "2" {
+		expected = "~> v2"
+	}
+	return &Fix{
+		Description: "Run GoReleaser " + expected,
+		Unsafe:      true,
+		Edits:       []Edit{setInput(release, "version", quotedScalar(expected))},
+	}
+}
+
+// actionMajor returns the GoReleaser major version a goreleaser-action
+// version input resolves to. A missing input means latest.
+func actionMajor(version *yaml.Node) (int, bool) {
+	if version == nil {
+		return 2, true
+	}
+	value := strings.TrimSpace(version.Value)
+	switch value {
+	case "latest", "nightly":
+		return 2, true
+	}
+	if strings.Contains(value, "${{") {
+		return 0, false
+	}
+
+	value = strings.TrimLeft(value, "~> ")
+	value = strings.TrimPrefix(value, "v")
+	major, err := strconv.Atoi(strings.SplitN(value, ".", 2)[0])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
@@ -0,0 +1,243 @@
+package lint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+)
+
+const releaseConfig = `version: 2
+project_name: app
+dockers:
+  - image_templates: ["ghcr.io/acme/app:{{ .Tag }}"]
+signs:
+  - cmd: cosign
+    args: ["sign-blob", "--output-signature=${signature}", "${artifact}", "--yes"]
+brews:
+  - repository:
+      owner: acme
+      name: homebrew-tap
+      token: "{{ .Env.HOMEBREW_TAP_GITHUB_TOKEN }}"
+`
+
+// runWorkflow lints a workflow against a config and returns "rule@line" per finding
+func runWorkflow(t *testing.T, engine *Engine, config, workflow, goVersion string) []string {
+	t.Helper()
+	project := &Project{Config: parse(t, config), GoVersion: goVersion}
+
+	var results []string
+	for _, finding := range engine.Run(TargetWorkflow, []byte(workflow), parse(t, workflow), project) {
+		results = append(results, finding.Err.Rule+"@"+strings.TrimSpace(strings.Split(workflow, "\n")[finding.Err.Line-1]))
+	}
+	return results
+}
+
+func TestWorkflowRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    string
+		workflow  string
+		goVersion string
+		expected  []string
+	}{
+		{
+			name:      "consistent",
+			config:    releaseConfig,
+			goVersion: "1.25.1",
+			workflow: `name: release
+on: push
+permissions:
+  contents: write
+  id-token: write
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - uses: actions/setup-go@v5
+        with:
+          go-version: "1.25"
+      - uses: docker/login-action@v3
+      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: "~> v2"
+        env:
+          HOMEBREW_TAP_GITHUB_TOKEN: ${{ secrets.TAP_TOKEN }}
+`,
+		},
+		{
+			name:      "inconsistent",
+			config:    releaseConfig,
+			goVersion: "1.25",
+			workflow: `name: release
+on: push
+permissions:
+  contents: write
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    env:
+      HOMEBREW_TAP_GITHUB_TOKEN: plain
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: "1.22.x"
+      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: "~> v1"
+`,
+			expected: []string{
+				"workflow-id-token@contents: write",
+				"workflow-publisher-token@HOMEBREW_TAP_GITHUB_TOKEN: plain",
+				"workflow-fetch-depth@- uses: actions/checkout@v4",
+				"workflow-go-version@go-version: \"1.22.x\"",
+				"workflow-docker-login@- uses: goreleaser/goreleaser-action@v6",
+				"workflow-goreleaser-version@version: \"~> v1\"",
+			},
+		},
+		{
+			name:   "job_permissions_replace_workflow_permissions",
+			config: "version: 2\nbinary_signs:\n  - cmd: cosign\n",
+			workflow: `permissions:
+  id-token: write
+jobs:
+  release:
+    permissions:
+      contents: write
+    steps:
+      - run: goreleaser release --clean
+`,
+			expected: []string{"workflow-id-token@contents: write"},
+		},
+		{
+			name: "nothing_to_check",
+			config: `version: 2
+changelog:
+  disable: true
+dockers:
+  - skip_push: true
+signs:
+  - cmd: cosign
+    args: ["--key=cosign.key"]
+`,
+			goVersion: "1.25",
+			workflow: `jobs:
+  release:
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@v5
+        with:
+          go-version: stable
+      - uses: goreleaser/goreleaser-action@v6
+`,
+		},
+		{
+			name:   "missing_publisher_token",
+			config: "brews:\n  - tap:\n      token: \"{{ .Env.TAP }}\"\n",
+			workflow: `jobs:
+  release:
+    steps:
+      - uses: goreleaser/goreleaser-action@v6
+        with:
+          version: v1.26.2
+`,
+			expected: []string{"workflow-publisher-token@- uses: goreleaser/goreleaser-action@v6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runWorkflow(t, NewEngine(workflowRules()...), tt.config, tt.workflow, tt.goVersion)
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("findings =\n%s\nexpected\n%s", strings.Join(got, "\n"), strings.Join(tt.expected, "\n"))
+			}
+		})
+	}
+}
+
+func TestGeneratedWorkflowMatchesConfig(t *testing.T) {
+	config := domain.NewSafeProjectConfig()
+	config.ProjectName = "app"
+	config.BinaryName = "app"
+	config.MainPath = "./cmd/app"
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryGitHub
+	config.SigningLevel = domain.SigningLevelAdvanced
+	config.Homebrew = true
+
+	gen := generator.New()
+	goreleaser, err := gen.GenerateGoReleaserConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GenerateGoReleaserConfig() error = %v", err)
+	}
+	workflow, err := gen.GenerateGitHubActions(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GenerateGitHubActions() error = %v", err)
+	}
+
+	if findings := runWorkflow(t, NewEngine(workflowRules()...), goreleaser, workflow, "1.25"); len(findings) != 0 {
+		t.Errorf("generated workflow is inconsistent with the generated config: %v", findings)
+	}
+
+	// Generated workflows pin actions by tag; everything else must be clean
+	engine := NewEngine(securityRules()...)
+	if err := engine.Configure(nil, []string{"workflow-unpinned-action"}); err != nil {
+		t.Fatal(err)
+	}
+	if findings := runWorkflow(t, engine, goreleaser, workflow, ""); len(findings) != 0 {
+		t.Errorf("generated workflow has security findings: %v", findings)
+	}
+}
+
+func TestParseGoVersion(t *testing.T) {
+	gomod := "module example.com/app\n\ngo 1.25.1\n\ntoolchain go1.25.3\n"
+	if got := ParseGoVersion([]byte(gomod)); got != "1.25.1" {
+		t.Errorf("ParseGoVersion() = %q, expected 1.25.1", got)
+	}
+	if got := ParseGoVersion([]byte("module example.com/app\n")); got != "" {
+		t.Errorf("ParseGoVersion() without directive = %q", got)
+	}
+}
+
+func TestReleaseConfig(t *testing.T) {
+	tests := []struct {
+		workflow string
+		workdir  string
+		config   string
+		ok       bool
+	}{
+		{
+			workflow: "jobs:\n  r:\n    steps:\n      - uses: goreleaser/goreleaser-action@v6\n        with:\n          args: release --clean\n",
+			workdir:  ".",
+			ok:       true,
+		},
+		{
+			workflow: "jobs:\n  r:\n    steps:\n      - uses: goreleaser/goreleaser-action@v6\n        with:\n          workdir: services/api\n          args: release --config=.goreleaser.prod.yaml\n",
+			workdir:  "services/api",
+			config:   ".goreleaser.prod.yaml",
+			ok:       true,
+		},
+		{
+			workflow: "jobs:\n  r:\n    steps:\n      - run: goreleaser release -f build/release.yml --clean\n",
+			workdir:  ".",
+			config:   "build/release.yml",
+			ok:       true,
+		},
+		{
+			workflow: "jobs:\n  test:\n    steps:\n      - run: go test ./...\n",
+		},
+	}
+
+	for _, tt := range tests {
+		workdir, config, ok := ReleaseConfig(parse(t, tt.workflow))
+		if workdir != tt.workdir || config != tt.config || ok != tt.ok {
+			t.Errorf("ReleaseConfig() = %q, %q, %v; expected %q, %q, %v\n%s", workdir, config, ok, tt.workdir, tt.config, tt.ok, tt.workflow)
+		}
+	}
+}
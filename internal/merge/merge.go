@@ -0,0 +1,278 @@
+// Package merge performs three-way merges of generated files with local edits
+package merge
+
+import (
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/diff"
+)
+
+// Conflict marker lines, compatible with git's diff3 style
+const (
+	MarkerOurs   = "<<<<<<< "
+	MarkerBase   = "||||||| "
+	MarkerSep    = "======="
+	MarkerTheirs = ">>>>>>> "
+)
+
+// Labels name the three sides in conflict markers
+type Labels struct {
+	Ours   string
+	Base   string
+	Theirs string
+}
+
+// DefaultLabels describe an update of a hand-edited file with freshly generated output
+var DefaultLabels = Labels{Ours: "local", Base: "base", Theirs: "generated"}
+
+// Resolution selects how a conflict is resolved
+type Resolution int
+
+const (
+	// ResolveMarkers keeps both sides between conflict markers
+	ResolveMarkers Resolution = iota
+	// ResolveOurs keeps the local edit
+	ResolveOurs
+	// ResolveTheirs takes the generated content
+	ResolveTheirs
+	// ResolveBoth keeps the local edit followed by the generated content
+	ResolveBoth
+)
+
+// Conflict is a region both sides changed differently. Lines keep their newlines.
+type Conflict struct {
+	Base   []string
+	Ours   []string
+	Theirs []string
+}
+
+// chunk is either a stable run of lines or a conflict
+type chunk struct {
+	lines    []string
+	conflict *Conflict
+}
+
+// Result is a merge outcome that can be rendered with different resolutions
+type Result struct {
+	chunks []chunk
+}
+
+// Conflicts returns the unresolved conflicts in order
+func (r *Result) Conflicts() []Conflict {
+	var conflicts []Conflict
+	for _, c := range r.chunks {
+		if c.conflict != nil {
+			conflicts = append(conflicts, *c.conflict)
+		}
+	}
+	return conflicts
+}
+
+// HasConflicts reports whether the merge needs a decision
+func (r *Result) HasConflicts() bool {
+	return len(r.Conflicts()) > 0
+}
+
+// String renders the merge with conflict markers for every conflict
+func (r *Result) String() string {
+	return r.Render(DefaultLabels, nil)
+}
+
+// Render renders the merge. resolve is called for each conflict with its index;
+// a nil resolver writes conflict markers.
+func (r *Result) Render(labels Labels, resolve func(index int, conflict Conflict) Resolution) string {
+	var b strings.Builder
+	index := 0
+	for _, c := range r.chunks {
+		if c.conflict == nil {
+			writeLines(&b, c.lines)
+			continue
+		}
+
+		resolution := ResolveMarkers
+		if resolve != nil {
+			resolution = resolve(index, *c.conflict)
+		}
+		index++
+
+		switch resolution {
+		case ResolveOurs:
+			writeLines(&b, c.conflict.Ours)
+		case ResolveTheirs:
+			writeLines(&b, c.conflict.Theirs)
+		case ResolveBoth:
+			writeLines(&b, c.conflict.Ours)
+			writeLines(&b, c.conflict.Theirs)
+		default:
+			writeMarkers(&b, labels, *c.conflict)
+		}
+	}
+	return b.String()
+}
+
+// Merge merges the changes from base to ours and from base to theirs
+func Merge(base, ours, theirs string) *Result {
+	baseLines := diff.SplitLines(base)
+	oursLines := diff.SplitLines(ours)
+	theirsLines := diff.SplitLines(theirs)
+
+	oursHunks := changes(diff.Lines(baseLines, oursLines))
+	theirsHunks := changes(diff.Lines(baseLines, theirsLines))
+
+	result := &Result{}
+	pos := 0
+	i, j := 0, 0
+	for i < len(oursHunks) || j < len(theirsHunks) {
+		// Start a group with the hunk that begins first
+		var lo, hi int
+		if j >= len(theirsHunks) || (i < len(oursHunks) && oursHunks[i].start <= theirsHunks[j].start) {
+			lo, hi = oursHunks[i].start, oursHunks[i].end
+		} else {
+			lo, hi = theirsHunks[j].start, theirsHunks[j].end
+		}
+
+		// Grow the group while hunks from either side touch it; adjacent edits conflict
+		var oursGroup, theirsGroup []hunk
+		for {
+			grown := false
+			if i < len(oursHunks) && oursHunks[i].start <= hi {
+				oursGroup = append(oursGroup, oursHunks[i])
+				hi = max(hi, oursHunks[i].end)
+				i++
+				grown = true
+			}
+			if j < len(theirsHunks) && theirsHunks[j].start <= hi {
+				theirsGroup = append(theirsGroup, theirsHunks[j])
+				hi = max(hi, theirsHunks[j].end)
+				j++
+				grown = true
+			}
+			if !grown {
+				break
+			}
+		}
+
+		if lo > pos {
+			result.stable(baseLines[pos:lo])
+		}
+
+		oursVersion := apply(baseLines, oursGroup, lo, hi)
+		theirsVersion := apply(baseLines, theirsGroup, lo, hi)
+		switch {
+		case len(theirsGroup) == 0:
+			result.stable(oursVersion)
+		case len(oursGroup) == 0:
+			result.stable(theirsVersion)
+		case equalLines(oursVersion, theirsVersion):
+			result.stable(oursVersion)
+		default:
+			result.chunks = append(result.chunks, chunk{conflict: &Conflict{
+				Base:   baseLines[lo:hi],
+				Ours:   oursVersion,
+				Theirs: theirsVersion,
+			}})
+		}
+		pos = hi
+	}
+
+	if pos < len(baseLines) {
+		result.stable(baseLines[pos:])
+	}
+	return result
+}
+
+// hunk replaces base[start:end] with lines
+type hunk struct {
+	start, end int
+	lines      []string
+}
+
+// changes collapses an edit script into replacement hunks over the base
+func changes(edits []diff.Edit) []hunk {
+	var hunks []hunk
+	pos := 0
+	for k := 0; k < len(edits); {
+		if edits[k].Kind == diff.OpEqual {
+			pos++
+			k++
+			continue
+		}
+
+		h := hunk{start: pos, end: pos}
+		for ; k < len(edits) && edits[k].Kind != diff.OpEqual; k++ {
+			if edits[k].Kind == diff.OpDelete {
+				h.end++
+			} else {
+				h.lines = append(h.lines, edits[k].Line)
+			}
+		}
+		pos = h.end
+		hunks = append(hunks, h)
+	}
+	return hunks
+}
+
+// apply returns base[lo:hi] with the given hunks applied
+func apply(base []string, hunks []hunk, lo, hi int) []string {
+	var out []string
+	pos := lo
+	for _, h := range hunks {
+		out = append(out, base[pos:h.start]...)
+		out = append(out, h.lines...)
+		pos = h.end
+	}
+	return append(out, base[pos:hi]...)
+}
+
+func (r *Result) stable(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	r.chunks = append(r.chunks, chunk{lines: lines})
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString(line)
+	}
+}
+
+func writeMarkers(b *strings.Builder, labels Labels, conflict Conflict) {
+	b.WriteString(MarkerOurs + labels.Ours + "\n")
+	writeTerminated(b, conflict.Ours)
+	b.WriteString(MarkerBase + labels.Base + "\n")
+	writeTerminated(b, conflict.Base)
+	b.WriteString(MarkerSep + "\n")
+	writeTerminated(b, conflict.Theirs)
+	b.WriteString(MarkerTheirs + labels.Theirs + "\n")
+}
+
+// writeTerminated writes lines and makes sure a marker can follow on its own line
+func writeTerminated(b *strings.Builder, lines []string) {
+	writeLines(b, lines)
+	if len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		b.WriteString("\n")
+	}
+}
+
+// HasMarkers reports whether content still contains unresolved conflict markers
+func HasMarkers(content string) bool {
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(line, MarkerOurs) || strings.HasPrefix(line, MarkerTheirs) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package merge
+
+import (
+	"strings"
+	"testing"
+)
+
+const baseConfig = `version: 2
+project_name: app
+builds:
+  - main: .
+    flags:
+      - -trimpath
+archives:
+  - formats: [tar.gz]
+checksum:
+  name_template: checksums.txt
+`
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name      string
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:     "unchanged",
+			ours:     baseConfig,
+			theirs:   baseConfig,
+			expected: baseConfig,
+		},
+		{
+			name:     "only_local_edit",
+			ours:     strings.Replace(baseConfig, "      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+			theirs:   baseConfig,
+			expected: strings.Replace(baseConfig, "      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+		},
+		{
+			name:     "only_generated_change",
+			ours:     baseConfig,
+			theirs:   strings.Replace(baseConfig, "tar.gz", "tar.gz, zip", 1),
+			expected: strings.Replace(baseConfig, "tar.gz", "tar.gz, zip", 1),
+		},
+		{
+			name:   "independent_changes",
+			ours:   strings.Replace(baseConfig, "project_name: app\n", "# keep this\nproject_name: app\n", 1),
+			theirs: strings.Replace(baseConfig, "checksums.txt", "SHA256SUMS", 1),
+			expected: strings.Replace(
+				strings.Replace(baseConfig, "project_name: app\n", "# keep this\nproject_name: app\n", 1),
+				"checksums.txt", "SHA256SUMS", 1),
+		},
+		{
+			name:     "identical_changes",
+			ours:     strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+			theirs:   strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+			expected: strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+		},
+		{
+			name:   "conflicting_changes",
+			ours:   strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1),
+			theirs: strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+			expected: strings.Replace(baseConfig, "  - main: .\n",
+				"<<<<<<< local\n  - main: ./cmd/local\n||||||| base\n  - main: .\n=======\n  - main: ./cmd/app\n>>>>>>> generated\n", 1),
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Merge(baseConfig, tt.ours, tt.theirs)
+			if got := len(result.Conflicts()); got != tt.conflicts {
+				t.Errorf("Merge() conflicts = %d, expected %d", got, tt.conflicts)
+			}
+			if got := result.String(); got != tt.expected {
+				t.Errorf("Merge() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRenderResolutions(t *testing.T) {
+	ours := strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1)
+	theirs := strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1)
+	result := Merge(baseConfig, ours, theirs)
+
+	tests := []struct {
+		name       string
+		resolution Resolution
+		expected   string
+	}{
+		{name: "ours", resolution: ResolveOurs, expected: ours},
+		{name: "theirs", resolution: ResolveTheirs, expected: theirs},
+		{
+			name:       "both",
+			resolution: ResolveBoth,
+			expected:   strings.Replace(baseConfig, "  - main: .\n", "  - main: ./cmd/local\n  - main: ./cmd/app\n", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := result.Render(DefaultLabels, func(int, Conflict) Resolution { return tt.resolution })
+			if got != tt.expected {
+				t.Errorf("Render() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHasMarkers(t *testing.T) {
+	conflicted := Merge(baseConfig,
+		strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1),
+		strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+	).String()
+
+	if !HasMarkers(conflicted) {
+		t.Error("HasMarkers() = false for conflicted merge")
+	}
+	if HasMarkers(baseConfig) {
+		t.Error("HasMarkers() = true for clean content")
+	}
+}
+
+func TestMergeYAML(t *testing.T) {
+	tests := []struct {
+		name      string
+		noBase    bool
+		ours      string
+		theirs    string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:     "adjacent_keys",
+			ours:     strings.Replace(baseConfig, "project_name: app", "project_name: local", 1),
+			theirs:   strings.Replace(baseConfig, "version: 2", "version: 3", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "project_name: app", "project_name: local", 1), "version: 2", "version: 3", 1),
+		},
+		{
+			name:   "adjacent_keys_in_item",
+			ours:   strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1),
+			theirs: strings.Replace(baseConfig, "      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1),
+				"      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+		},
+		{
+			name:     "first_key_of_item",
+			ours:     strings.Replace(baseConfig, "      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+			theirs:   strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1), "      - -trimpath\n", "      - -trimpath\n      - -v\n", 1),
+		},
+		{
+			name:     "local_comments",
+			ours:     strings.Replace(baseConfig, "checksum:\n", "# signed by the release job\nchecksum: # keep\n", 1),
+			theirs:   strings.Replace(baseConfig, "checksums.txt", "SHA256SUMS", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "checksum:\n", "# signed by the release job\nchecksum: # keep\n", 1), "checksums.txt", "SHA256SUMS", 1),
+		},
+		{
+			name:     "comment_in_changed_item",
+			ours:     strings.Replace(baseConfig, "  - main: .\n", "  - main: . # my comment\n", 1),
+			theirs:   strings.Replace(baseConfig, "      - -trimpath\n", "      - -trimpath\n    goos:\n      - linux\n", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "  - main: .\n", "  - main: . # my comment\n", 1), "      - -trimpath\n", "      - -trimpath\n    goos:\n      - linux\n", 1),
+		},
+		{
+			name:     "added_key",
+			ours:     strings.Replace(baseConfig, "project_name: app", "project_name: local", 1),
+			theirs:   strings.Replace(baseConfig, "archives:\n", "env:\n  - CGO_ENABLED=0\narchives:\n", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "project_name: app", "project_name: local", 1), "archives:\n", "env:\n  - CGO_ENABLED=0\narchives:\n", 1),
+		},
+		{
+			name:     "added_key_in_item",
+			ours:     strings.Replace(baseConfig, "project_name: app", "project_name: local", 1),
+			theirs:   strings.Replace(baseConfig, "  - main: .\n", "  - main: .\n    binary: app\n", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "project_name: app", "project_name: local", 1), "  - main: .\n", "  - main: .\n    binary: app\n", 1),
+		},
+		{
+			name:     "removed_key",
+			ours:     strings.Replace(baseConfig, "project_name: app", "project_name: local", 1),
+			theirs:   strings.Replace(baseConfig, "checksum:\n  name_template: checksums.txt\n", "", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "project_name: app", "project_name: local", 1), "checksum:\n  name_template: checksums.txt\n", "", 1),
+		},
+		{
+			name:   "conflicting_key",
+			ours:   strings.Replace(strings.Replace(baseConfig, "main: .", "main: ./cmd/local", 1), "version: 2", "version: 3", 1),
+			theirs: strings.Replace(baseConfig, "main: .", "main: ./cmd/app", 1),
+			expected: strings.Replace(strings.Replace(baseConfig, "version: 2", "version: 3", 1), "  - main: .\n",
+				"<<<<<<< local\n  - main: ./cmd/local\n||||||| base\n  - main: .\n=======\n  - main: ./cmd/app\n>>>>>>> generated\n", 1),
+			conflicts: 1,
+		},
+		{
+			name:   "without_base",
+			noBase: true,
+			ours:   "version: 2\nproject_name: local\n",
+			theirs: "version: 2\nproject_name: app\nchecksum:\n  name_template: SHA256SUMS\n",
+			expected: "version: 2\n<<<<<<< local\nproject_name: local\n||||||| base\n=======\nproject_name: app\n>>>>>>> generated\n" +
+				"checksum:\n  name_template: SHA256SUMS\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := baseConfig
+			if tt.noBase {
+				base = ""
+			}
+			result := MergeYAML(base, tt.ours, tt.theirs)
+			if got := len(result.Conflicts()); got != tt.conflicts {
+				t.Errorf("MergeYAML() conflicts = %d, expected %d", got, tt.conflicts)
+			}
+			if got := result.String(); got != tt.expected {
+				t.Errorf("MergeYAML() =\n%s\nexpected\n%s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMergeYAMLFallsBackToLines(t *testing.T) {
+	ours := strings.Replace(baseConfig, "project_name: app", "project_name: [local", 1)
+	theirs := strings.Replace(baseConfig, "checksums.txt", "SHA256SUMS", 1)
+	if got, expected := MergeYAML(baseConfig, ours, theirs).String(), Merge(baseConfig, ours, theirs).String(); got != expected {
+		t.Errorf("MergeYAML() =\n%s\nexpected the line merge\n%s", got, expected)
+	}
+}
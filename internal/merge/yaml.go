@@ -0,0 +1,465 @@
+package merge
+
+import (
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/diff"
+	"go.yaml.in/yaml/v3"
+)
+
+// MergeYAML merges YAML documents per mapping key and sequence item, so
+// edits to neighbouring keys do not conflict. Unchanged parts keep the local
+// text, including comments and formatting; a changed value is taken from
+// the generated text. Conflicts cover a single key or item. Content that is
+// not a single YAML document falls back to the line merge.
+func MergeYAML(base, ours, theirs string) *Result {
+	switch {
+	case ours == base || ours == theirs:
+		return &Result{chunks: []chunk{{lines: diff.SplitLines(theirs)}}}
+	case theirs == base:
+		return &Result{chunks: []chunk{{lines: diff.SplitLines(ours)}}}
+	}
+
+	baseSide, baseOK := parseSide(base)
+	oursSide, oursOK := parseSide(ours)
+	theirsSide, theirsOK := parseSide(theirs)
+	// Without a recorded base every key is treated as added on both sides
+	if !baseOK && strings.TrimSpace(base) == "" {
+		baseSide, baseOK = side{}, true
+	}
+	if !baseOK || !oursOK || !theirsOK || !isBlock(oursSide.root, yaml.MappingNode) || !isBlock(theirsSide.root, yaml.MappingNode) {
+		return Merge(base, ours, theirs)
+	}
+
+	m := &yamlMerger{base: baseSide, ours: oursSide, theirs: theirsSide, result: &Result{}}
+	root := baseSide.root
+	if !isBlock(root, yaml.MappingNode) {
+		root = nil
+	}
+	m.mapping(root, oursSide.root, theirsSide.root, len(baseSide.lines), len(oursSide.lines), len(theirsSide.lines))
+	m.keep(len(oursSide.lines))
+	return m.result
+}
+
+// side is one version of a YAML file
+type side struct {
+	lines []string
+	root  *yaml.Node
+}
+
+func parseSide(content string) (side, bool) {
+	var doc yaml.Node
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	if err := decoder.Decode(&doc); err != nil || len(doc.Content) == 0 {
+		return side{}, false
+	}
+	// A second document is not merged structurally
+	var next yaml.Node
+	if err := decoder.Decode(&next); err == nil {
+		return side{}, false
+	}
+	return side{lines: diff.SplitLines(content), root: doc.Content[0]}, true
+}
+
+// entry is a mapping entry or a sequence item with the lines [start, end) it
+// spans. Blank lines and comments before the next entry are not part of it.
+// column is where the key or the item's dash starts; the first key of a
+// mapping item shares its line with the dash.
+type entry struct {
+	key        string
+	value      *yaml.Node
+	start, end int
+	column     int
+}
+
+// entries returns the entries of a block collection that ends before line end
+func (s side) entries(node *yaml.Node, end int) []entry {
+	if node == nil {
+		return nil
+	}
+	var result []entry
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			result = append(result, entry{key: key.Value, value: node.Content[i+1], start: key.Line - 1, column: key.Column - 1})
+		}
+	} else {
+		for _, item := range node.Content {
+			result = append(result, entry{value: item, start: item.Line - 1, column: indentation(s.lines[item.Line-1])})
+		}
+	}
+
+	for i := range result {
+		result[i].end = end
+		if i+1 < len(result) {
+			result[i].end = result[i+1].start
+		}
+		for result[i].end > result[i].start+1 && gapLine(s.lines[result[i].end-1], result[i].column) {
+			result[i].end--
+		}
+	}
+	return result
+}
+
+// span returns the lines from the first to the last of the entries
+func (s side) span(entries []entry) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+	return s.lines[entries[0].start:entries[len(entries)-1].end]
+}
+
+// gapLine reports whether a line separates entries: a blank line, or a
+// comment that is not indented into the entry, e.g. into a block scalar
+func gapLine(line string, column int) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || (strings.HasPrefix(trimmed, "#") && indentation(line) <= column)
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// yamlMerger builds a merge result over the lines of the local file
+type yamlMerger struct {
+	base, ours, theirs side
+	result             *Result
+	pos                int
+}
+
+// keep copies the local lines up to line to
+func (m *yamlMerger) keep(to int) {
+	if to > m.pos {
+		m.result.stable(m.ours.lines[m.pos:to])
+		m.pos = to
+	}
+}
+
+// replace puts lines in place of the local lines [from, to)
+func (m *yamlMerger) replace(from, to int, lines []string) {
+	m.keep(from)
+	m.result.stable(m.terminated(lines, to))
+	m.pos = to
+}
+
+// conflict records that the local lines [from, to) conflict with theirs
+func (m *yamlMerger) conflict(from, to int, base, theirs []string) {
+	m.keep(from)
+	m.result.chunks = append(m.result.chunks, chunk{conflict: &Conflict{
+		Base:   base,
+		Ours:   m.ours.lines[from:to],
+		Theirs: m.terminated(theirs, to),
+	}})
+	m.pos = to
+}
+
+// terminated makes sure lines placed before more local content end in a newline
+func (m *yamlMerger) terminated(lines []string, at int) []string {
+	if len(lines) == 0 || at >= len(m.ours.lines) || strings.HasSuffix(lines[len(lines)-1], "\n") {
+		return lines
+	}
+	fixed := append([]string(nil), lines...)
+	fixed[len(fixed)-1] += "\n"
+	return fixed
+}
+
+// place returns the generated lines of entries, which start at column
+// theirs, moved to column local. The first line starts with lead, the local
+// text before the entry on its line, e.g. the dash of a sequence item.
+func place(lines []string, theirs, local int, lead string) []string {
+	if len(lines) == 0 {
+		return nil
+	}
+	shift := local - theirs
+	placed := make([]string, len(lines))
+	placed[0] = lead + lines[0][min(theirs, len(lines[0])):]
+	for i, line := range lines[1:] {
+		switch {
+		case strings.TrimSpace(line) == "" || shift == 0:
+			placed[i+1] = line
+		case shift > 0:
+			placed[i+1] = strings.Repeat(" ", shift) + line
+		default:
+			placed[i+1] = line[min(-shift, indentation(line)):]
+		}
+	}
+	return placed
+}
+
+// theirsFor returns generated entries placed where a local entry starts
+func (m *yamlMerger) theirsFor(entries []entry, local entry) []string {
+	if len(entries) == 0 {
+		return nil
+	}
+	lead := m.ours.lines[local.start][:local.column]
+	return place(m.theirs.span(entries), entries[0].column, local.column, lead)
+}
+
+// onDash reports whether a local entry shares its line with a sequence dash
+func (m *yamlMerger) onDash(e entry) bool {
+	return strings.TrimSpace(m.ours.lines[e.start][:e.column]) != ""
+}
+
+// pending is a generated entry without a local counterpart
+type pending struct {
+	theirs entry
+	base   *entry
+}
+
+// mapping merges block mappings key by key. base is nil when the key was
+// added on both sides.
+func (m *yamlMerger) mapping(base, ours, theirs *yaml.Node, baseEnd, oursEnd, theirsEnd int) {
+	baseEntries := m.base.entries(base, baseEnd)
+	oursEntries := m.ours.entries(ours, oursEnd)
+	theirsEntries := m.theirs.entries(theirs, theirsEnd)
+	column := oursEntries[0].column
+
+	baseByKey := make(map[string]*entry)
+	for i := range baseEntries {
+		baseByKey[baseEntries[i].key] = &baseEntries[i]
+	}
+	theirsByKey := make(map[string]*entry)
+	for i := range theirsEntries {
+		theirsByKey[theirsEntries[i].key] = &theirsEntries[i]
+	}
+	oursIndex := make(map[string]int)
+	for i, e := range oursEntries {
+		oursIndex[e.key] = i
+	}
+
+	// Generated keys missing locally go after the local key that precedes
+	// them in the generated file
+	after := make(map[int][]pending)
+	previous := -1
+	for _, e := range theirsEntries {
+		if i, ok := oursIndex[e.key]; ok {
+			previous = i
+			continue
+		}
+		b := baseByKey[e.key]
+		if b != nil && equalNodes(b.value, e.value) {
+			continue // removed locally
+		}
+		after[previous] = append(after[previous], pending{theirs: e, base: b})
+	}
+
+	// Nothing can go between a sequence dash and the first key of its item
+	if m.onDash(oursEntries[0]) {
+		after[0] = append(after[-1], after[0]...)
+	} else {
+		m.insert(oursEntries[0].start, after[-1], column)
+	}
+	for i, e := range oursEntries {
+		m.entry(baseByKey[e.key], e, theirsByKey[e.key])
+		m.insert(e.end, after[i], column)
+	}
+}
+
+// insert adds generated entries at a local line. An entry removed locally
+// but changed in the generated file conflicts.
+func (m *yamlMerger) insert(at int, entries []pending, column int) {
+	if len(entries) == 0 {
+		return
+	}
+	m.keep(at)
+	for _, p := range entries {
+		lines := place(m.theirs.span([]entry{p.theirs}), p.theirs.column, column, strings.Repeat(" ", column))
+		if p.base == nil {
+			m.result.stable(m.terminated(lines, at))
+			continue
+		}
+		m.result.chunks = append(m.result.chunks, chunk{conflict: &Conflict{
+			Base:   m.base.span([]entry{*p.base}),
+			Theirs: m.terminated(lines, at),
+		}})
+	}
+}
+
+// entry merges one local mapping entry or sequence item
+func (m *yamlMerger) entry(base *entry, ours entry, theirs *entry) {
+	var baseLines []string
+	if base != nil {
+		baseLines = m.base.span([]entry{*base})
+	}
+
+	switch {
+	case theirs == nil && base == nil:
+		// Added locally
+	case theirs == nil:
+		if equalNodes(base.value, ours.value) && !m.onDash(ours) {
+			m.replace(ours.start, ours.end, nil)
+		} else {
+			m.conflict(ours.start, ours.end, baseLines, nil)
+		}
+	case base != nil && equalNodes(base.value, theirs.value), equalNodes(ours.value, theirs.value):
+		// Unchanged in the generated file, or changed the same way
+	case sameBlock(ours.value, theirs.value, base):
+		var baseValue *yaml.Node
+		baseEnd := 0
+		if base != nil {
+			baseValue, baseEnd = base.value, base.end
+		}
+		if ours.value.Kind == yaml.MappingNode {
+			m.mapping(baseValue, ours.value, theirs.value, baseEnd, ours.end, theirs.end)
+		} else {
+			m.sequence(baseValue, ours.value, theirs.value, baseEnd, ours.end, theirs.end)
+		}
+	case base != nil && equalNodes(base.value, ours.value):
+		m.replace(ours.start, ours.end, m.theirsFor([]entry{*theirs}, ours))
+	default:
+		m.conflict(ours.start, ours.end, baseLines, m.theirsFor([]entry{*theirs}, ours))
+	}
+}
+
+// sequence merges block sequences item by item. Items are matched by value;
+// edits to different items merge, edits that overlap conflict unless they
+// change the same collection item, which is merged recursively like an item
+// changed only in the generated file.
+func (m *yamlMerger) sequence(base, ours, theirs *yaml.Node, baseEnd, oursEnd, theirsEnd int) {
+	baseEntries := m.base.entries(base, baseEnd)
+	oursEntries := m.ours.entries(ours, oursEnd)
+	theirsEntries := m.theirs.entries(theirs, theirsEnd)
+	column := oursEntries[0].column
+
+	baseTokens, oursTokens, theirsTokens := tokens(baseEntries), tokens(oursEntries), tokens(theirsEntries)
+	oursHunks := changes(diff.Lines(baseTokens, oursTokens))
+	theirsHunks := changes(diff.Lines(baseTokens, theirsTokens))
+
+	// Cursors into base, ours and theirs just after the last group
+	basePos, oursPos, theirsPos := 0, 0, 0
+	i, j := 0, 0
+	for i < len(oursHunks) || j < len(theirsHunks) {
+		var lo, hi int
+		if j >= len(theirsHunks) || (i < len(oursHunks) && oursHunks[i].start <= theirsHunks[j].start) {
+			lo, hi = oursHunks[i].start, oursHunks[i].end
+		} else {
+			lo, hi = theirsHunks[j].start, theirsHunks[j].end
+		}
+
+		var oursGroup, theirsGroup []hunk
+		for {
+			grown := false
+			if i < len(oursHunks) && overlaps(oursHunks[i], lo, hi) {
+				oursGroup = append(oursGroup, oursHunks[i])
+				hi = max(hi, oursHunks[i].end)
+				i++
+				grown = true
+			}
+			if j < len(theirsHunks) && overlaps(theirsHunks[j], lo, hi) {
+				theirsGroup = append(theirsGroup, theirsHunks[j])
+				hi = max(hi, theirsHunks[j].end)
+				j++
+				grown = true
+			}
+			if !grown {
+				break
+			}
+		}
+
+		oursLo := oursPos + lo - basePos
+		oursHi := oursLo + replaced(oursGroup, lo, hi)
+		theirsLo := theirsPos + lo - basePos
+		theirsHi := theirsLo + replaced(theirsGroup, lo, hi)
+		basePos, oursPos, theirsPos = hi, oursHi, theirsHi
+
+		from, to := itemLines(oursEntries, oursLo, oursHi)
+		var theirsLines []string
+		if theirsLo < theirsHi {
+			theirsLines = place(m.theirs.span(theirsEntries[theirsLo:theirsHi]), theirsEntries[theirsLo].column, column, strings.Repeat(" ", column))
+		}
+		// One collection item changed in the generated file, and maybe
+		// locally: merge its entries, keeping the local comments inside it
+		sameItem := hi-lo == 1 && oursHi-oursLo == 1 && theirsHi-theirsLo == 1 &&
+			sameBlock(oursEntries[oursLo].value, theirsEntries[theirsLo].value, &baseEntries[lo]) &&
+			sameFirstKey(baseEntries[lo].value, oursEntries[oursLo].value, theirsEntries[theirsLo].value)
+		switch {
+		case len(theirsGroup) == 0:
+			// Only changed locally
+		case sameItem:
+			m.entry(&baseEntries[lo], oursEntries[oursLo], &theirsEntries[theirsLo])
+		case len(oursGroup) == 0:
+			m.replace(from, to, theirsLines)
+		case equalLines(oursTokens[oursLo:oursHi], theirsTokens[theirsLo:theirsHi]):
+			// Changed the same way
+		default:
+			m.conflict(from, to, m.base.span(baseEntries[lo:hi]), theirsLines)
+		}
+	}
+}
+
+// overlaps reports whether a hunk belongs to the group of base items
+// [lo, hi). Changes to neighbouring items stay apart; an insertion next to a
+// change joins it, as their order is ambiguous.
+func overlaps(h hunk, lo, hi int) bool {
+	return h.start < hi || (h.start == hi && (h.start == h.end || lo == hi))
+}
+
+// replaced returns how many items the hunks leave in place of base[lo:hi]
+func replaced(hunks []hunk, lo, hi int) int {
+	count := hi - lo
+	for _, h := range hunks {
+		count += len(h.lines) - (h.end - h.start)
+	}
+	return count
+}
+
+// itemLines returns the local lines of items [lo, hi), or the line an item
+// would be inserted at when the range is empty
+func itemLines(entries []entry, lo, hi int) (int, int) {
+	if lo < hi {
+		return entries[lo].start, entries[hi-1].end
+	}
+	if lo < len(entries) {
+		return entries[lo].start, entries[lo].start
+	}
+	end := entries[len(entries)-1].end
+	return end, end
+}
+
+// tokens identifies items by value, so comments and formatting do not count
+func tokens(entries []entry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = canonical(e.value)
+	}
+	return result
+}
+
+func canonical(node *yaml.Node) string {
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return node.Tag + ":" + node.Value
+	}
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return node.Tag + ":" + node.Value
+	}
+	return string(data)
+}
+
+func equalNodes(a, b *yaml.Node) bool {
+	return canonical(a) == canonical(b)
+}
+
+// sameBlock reports whether the values are block collections of one kind
+// that can be merged entry by entry
+func sameBlock(ours, theirs *yaml.Node, base *entry) bool {
+	if !isBlock(ours, ours.Kind) || !isBlock(theirs, ours.Kind) {
+		return false
+	}
+	return base == nil || isBlock(base.value, ours.Kind)
+}
+
+func isBlock(node *yaml.Node, kind yaml.Kind) bool {
+	return node != nil && node.Kind == kind && (kind == yaml.MappingNode || kind == yaml.SequenceNode) &&
+		node.Style&yaml.FlowStyle == 0 && len(node.Content) > 0
+}
+
+// sameFirstKey reports whether mapping items start with the same key, so
+// the key that shares a line with the dash stays in place
+func sameFirstKey(base, ours, theirs *yaml.Node) bool {
+	if ours.Kind != yaml.MappingNode {
+		return true
+	}
+	return base.Content[0].Value == ours.Content[0].Value && ours.Content[0].Value == theirs.Content[0].Value
+}
@@ -0,0 +1,149 @@
+// Package migrate upgrades GoReleaser v1 configurations and release workflows to v2
+package migrate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// Configuration schema versions the engine migrates between
+const (
+	SourceVersion = 1
+	TargetVersion = 2
+)
+
+// Target identifies the kind of file a rule applies to
+type Target string
+
+const (
+	TargetGoReleaser Target = "goreleaser"
+	TargetWorkflow   Target = "workflow"
+)
+
+// Change is a single rewrite made by a rule
+type Change struct {
+	Rule   string
+	Path   string
+	Line   int
+	Before string
+	After  string
+}
+
+// Rule fixes one GoReleaser deprecation
+type Rule struct {
+	ID          string
+	Description string
+	Target      Target
+	apply       func(root *yaml.Node) []Change
+}
+
+// Result is the outcome of migrating a single file
+type Result struct {
+	Content []byte
+	Changes []Change
+}
+
+// Changed reports whether any rule rewrote the file
+func (r *Result) Changed() bool {
+	return len(r.Changes) > 0
+}
+
+// Rules returns every migration rule in application order
+func Rules() []Rule {
+	return append(configRules(), workflowRules()...)
+}
+
+// MigrateConfig applies the GoReleaser configuration rules
+func MigrateConfig(data []byte) (*Result, error) {
+	return run(data, TargetGoReleaser)
+}
+
+// MigrateWorkflow applies the GitHub Actions workflow rules
+func MigrateWorkflow(data []byte) (*Result, error) {
+	return run(data, TargetWorkflow)
+}
+
+// DetectVersion returns the schema version of a GoReleaser configuration.
+// Configurations without a version header are version 1.
+func DetectVersion(data []byte) (int, error) {
+	root, _, err := parse(data)
+	if err != nil {
+		return 0, err
+	}
+	if value := valueOf(root, "version"); value != nil && value.Value == "2" {
+		return TargetVersion, nil
+	}
+	return SourceVersion, nil
+}
+
+// ValidateVersions checks that a migration between two versions is supported.
+// Versions may be given as "1", "v1", "2" or "v2".
+func ValidateVersions(from, to string) error {
+	from = strings.TrimPrefix(from, "v")
+	to = strings.TrimPrefix(to, "v")
+
+	if from != fmt.Sprint(SourceVersion) || to != fmt.Sprint(TargetVersion) {
+		return domain.NewValidationError(
+			domain.ErrUnsupportedMigration,
+			"Unsupported migration",
+			fmt.Sprintf("cannot migrate from v%s to v%s (supported: v%d to v%d)", from, to, SourceVersion, TargetVersion),
+		)
+	}
+	return nil
+}
+
+func run(data []byte, target Target) (*Result, error) {
+	root, doc, err := parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Content: data, Changes: []Change{}}
+	if root == nil {
+		return result, nil
+	}
+
+	for _, rule := range Rules() {
+		if rule.Target == target {
+			result.Changes = append(result.Changes, rule.apply(root)...)
+		}
+	}
+
+	if result.Changed() {
+		result.Content = []byte(encode(doc) + "\n")
+	}
+	return result, nil
+}
+
+// parse returns the root mapping of a document, or nil for an empty document
+func parse(data []byte) (*yaml.Node, *yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil {
+		if strings.TrimSpace(string(data)) == "" {
+			return nil, nil, nil
+		}
+		return nil, nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid YAML",
+			err.Error(),
+		).WithCause(err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid configuration",
+			"the top level of the document must be a mapping",
+		)
+	}
+	return root, &doc, nil
+}
@@ -0,0 +1,220 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+const v1Config = `# release configuration
+project_name: app
+builds:
+  - main: ./cmd/app
+    gobinary: go1.22
+archives:
+  - format: tar.gz
+    format_overrides:
+      - goos: windows
+        format: zip
+snapshot:
+  name_template: "{{ incpatch .Version }}-next"
+brews:
+  - tap:
+      owner: acme
+      name: homebrew-tap
+    folder: Formula
+scoop:
+  bucket:
+    owner: acme
+    name: scoop-bucket
+changelog:
+  skip: true
+`
+
+func TestMigrateConfig(t *testing.T) {
+	result, err := MigrateConfig([]byte(v1Config))
+	if err != nil {
+		t.Fatalf("MigrateConfig() error = %v", err)
+	}
+
+	expectedRules := []string{
+		"version-header",
+		"archives-format",
+		"archives-format",
+		"builds-gobinary",
+		"snapshot-version-template",
+		"changelog-skip",
+		"brews-tap",
+		"brews-folder",
+		"scoop-to-scoops",
+		"scoops-bucket",
+	}
+	var rules []string
+	for _, change := range result.Changes {
+		rules = append(rules, change.Rule)
+	}
+	if strings.Join(rules, ",") != strings.Join(expectedRules, ",") {
+		t.Errorf("rules = %v, expected %v", rules, expectedRules)
+	}
+
+	var migrated map[string]any
+	if err := yaml.Unmarshal(result.Content, &migrated); err != nil {
+		t.Fatalf("migrated config is not valid YAML: %v", err)
+	}
+
+	content := string(result.Content)
+	if !strings.HasPrefix(content, "# release configuration\nversion: 2\n") {
+		t.Errorf("version header not inserted below the leading comment:\n%s", content)
+	}
+	for _, expected := range []string{
+		"formats: [tar.gz]",
+		"formats: [zip]",
+		"tool: go1.22",
+		"version_template:",
+		"repository:\n      owner: acme",
+		"directory: Formula",
+		"scoops:\n  - repository:",
+		"disable: true",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("migrated config missing %q\n%s", expected, content)
+		}
+	}
+
+	// Migrating again is a no-op
+	again, err := MigrateConfig(result.Content)
+	if err != nil {
+		t.Fatalf("MigrateConfig() second pass error = %v", err)
+	}
+	if again.Changed() {
+		t.Errorf("second pass made changes: %v", again.Changes)
+	}
+}
+
+func TestMigrateConfigChangeSnippets(t *testing.T) {
+	result, err := MigrateConfig([]byte("version: 2\narchives:\n  - format: zip\n"))
+	if err != nil {
+		t.Fatalf("MigrateConfig() error = %v", err)
+	}
+	if len(result.Changes) != 1 {
+		t.Fatalf("expected 1 change, got %v", result.Changes)
+	}
+
+	change := result.Changes[0]
+	if change.Path != "archives[0].format" || change.Line != 3 {
+		t.Errorf("change location = %s:%d, expected archives[0].format:3", change.Path, change.Line)
+	}
+	if change.Before != "format: zip" || change.After != "formats: [zip]" {
+		t.Errorf("snippets = %q -> %q", change.Before, change.After)
+	}
+}
+
+func TestMigrateConfigKeepsTrailingComment(t *testing.T) {
+	result, err := MigrateConfig([]byte("version: 2\narchives:\n  - format: tar.gz   # keep\n    name_template: app\n"))
+	if err != nil {
+		t.Fatalf("MigrateConfig() error = %v", err)
+	}
+
+	expected := "version: 2\narchives:\n  - formats: [tar.gz] # keep\n    name_template: app\n"
+	if got := string(result.Content); got != expected {
+		t.Errorf("MigrateConfig() =\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestMigrateConfigSupersededKey(t *testing.T) {
+	result, err := MigrateConfig([]byte("version: 2\nbrews:\n  - tap: {owner: a}\n    repository: {owner: b}\n"))
+	if err != nil {
+		t.Fatalf("MigrateConfig() error = %v", err)
+	}
+	if strings.Contains(string(result.Content), "tap:") || !strings.Contains(string(result.Content), "owner: b") {
+		t.Errorf("deprecated key not dropped:\n%s", result.Content)
+	}
+}
+
+func TestMigrateWorkflow(t *testing.T) {
+	workflow := `name: release
+on:
+  push:
+    tags: ["v*"]
+jobs:
+  release:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: goreleaser/goreleaser-action@v4
+        with:
+          version: latest
+          args: release --rm-dist
+  snapshot:
+    runs-on: ubuntu-latest
+    steps:
+      - run: goreleaser build --snapshot --rm-dist
+      - uses: goreleaser/goreleaser-action@5a54d7e660bda43b405e8463261b3d25631ffe86
+`
+
+	result, err := MigrateWorkflow([]byte(workflow))
+	if err != nil {
+		t.Fatalf("MigrateWorkflow() error = %v", err)
+	}
+
+	var paths []string
+	for _, change := range result.Changes {
+		paths = append(paths, change.Rule+"@"+change.Path)
+	}
+	expected := []string{
+		"workflow-rm-dist@jobs.release.steps[1].with.args",
+		"workflow-rm-dist@jobs.snapshot.steps[0].run",
+		"workflow-action-version@jobs.release.steps[1].uses",
+		"workflow-goreleaser-version@jobs.release.steps[1].with.version",
+	}
+	if strings.Join(paths, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("changes =\n%s\nexpected\n%s", strings.Join(paths, "\n"), strings.Join(expected, "\n"))
+	}
+
+	content := string(result.Content)
+	for _, want := range []string{"goreleaser-action@v6", `version: "~> v2"`, "args: release --clean", "--snapshot --clean", "@5a54d7e660bda43b405e8463261b3d25631ffe86"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("migrated workflow missing %q\n%s", want, content)
+		}
+	}
+}
+
+func TestDetectVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected int
+	}{
+		{name: "v2_header", config: "version: 2\nproject_name: app\n", expected: 2},
+		{name: "no_header", config: "project_name: app\n", expected: 1},
+		{name: "empty", config: "", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectVersion([]byte(tt.config))
+			if err != nil {
+				t.Fatalf("DetectVersion() error = %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("DetectVersion() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateVersions(t *testing.T) {
+	if err := ValidateVersions("v1", "v2"); err != nil {
+		t.Errorf("ValidateVersions(v1, v2) error = %v", err)
+	}
+	if err := ValidateVersions("1", "2"); err != nil {
+		t.Errorf("ValidateVersions(1, 2) error = %v", err)
+	}
+
+	err := ValidateVersions("2", "1")
+	if !domain.IsErrorCode(err, domain.ErrUnsupportedMigration) {
+		t.Errorf("ValidateVersions(2, 1) error = %v, expected %s", err, domain.ErrUnsupportedMigration)
+	}
+}
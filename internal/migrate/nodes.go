@@ -0,0 +1,127 @@
+package migrate
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// entry is a key/value pair of a mapping node together with its position
+type entry struct {
+	mapping *yaml.Node
+	index   int // index of the key in mapping.Content
+	path    string
+}
+
+func (e entry) key() *yaml.Node {
+	return e.mapping.Content[e.index]
+}
+
+func (e entry) value() *yaml.Node {
+	return e.mapping.Content[e.index+1]
+}
+
+// lookup finds key in a mapping node
+func lookup(mapping *yaml.Node, key string) (int, bool) {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return 0, false
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// valueOf returns the value of key in a mapping node, or nil
+func valueOf(mapping *yaml.Node, key string) *yaml.Node {
+	if i, ok := lookup(mapping, key); ok {
+		return mapping.Content[i+1]
+	}
+	return nil
+}
+
+// removeAt deletes the key/value pair starting at index
+func removeAt(mapping *yaml.Node, index int) {
+	mapping.Content = append(mapping.Content[:index], mapping.Content[index+2:]...)
+}
+
+// insertAt inserts a key/value pair at index
+func insertAt(mapping *yaml.Node, index int, key, value *yaml.Node) {
+	content := make([]*yaml.Node, 0, len(mapping.Content)+2)
+	content = append(content, mapping.Content[:index]...)
+	content = append(content, key, value)
+	mapping.Content = append(content, mapping.Content[index:]...)
+}
+
+// sections returns the mapping nodes of a top-level section. A mapping section
+// yields itself, a sequence section yields each mapping item.
+func sections(root *yaml.Node, name string) []entry {
+	i, ok := lookup(root, name)
+	if !ok {
+		return nil
+	}
+
+	value := root.Content[i+1]
+	switch value.Kind {
+	case yaml.MappingNode:
+		return []entry{{mapping: value, path: name}}
+	case yaml.SequenceNode:
+		var items []entry
+		for n, item := range value.Content {
+			if item.Kind == yaml.MappingNode {
+				items = append(items, entry{mapping: item, path: name + "[" + strconv.Itoa(n) + "]"})
+			}
+		}
+		return items
+	default:
+		return nil
+	}
+}
+
+// items returns the mapping items of a sequence value below a mapping
+func items(parent entry, key string) []entry {
+	value := valueOf(parent.mapping, key)
+	if value == nil || value.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var result []entry
+	for n, item := range value.Content {
+		if item.Kind == yaml.MappingNode {
+			result = append(result, entry{mapping: item, path: parent.path + "." + key + "[" + strconv.Itoa(n) + "]"})
+		}
+	}
+	return result
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func intScalar(value int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(value)}
+}
+
+// snippet renders a single key/value pair as YAML
+func snippet(key string, value *yaml.Node) string {
+	if value == nil {
+		return "(none)"
+	}
+	mapping := &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{scalar(key), value}}
+	return encode(mapping)
+}
+
+// encode renders a node with two-space indentation
+func encode(node *yaml.Node) string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return ""
+	}
+	encoder.Close()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,201 @@
+package migrate
+
+import (
+	"go.yaml.in/yaml/v3"
+)
+
+// configRules are the GoReleaser v1 to v2 deprecations, see
+// https://goreleaser.com/deprecations/
+func configRules() []Rule {
+	return []Rule{
+		{
+			ID:          "version-header",
+			Description: "Add the 'version: 2' header required by GoReleaser v2",
+			Target:      TargetGoReleaser,
+			apply:       versionHeader,
+		},
+		{
+			ID:          "archives-format",
+			Description: "Replace archives.format with the formats list",
+			Target:      TargetGoReleaser,
+			apply:       archivesFormat,
+		},
+		renameKey("archives-builds", "Rename archives.builds to ids", "archives", "builds", "ids"),
+		renameKey("archives-strip-binary-directory", "Rename archives.strip_parent_binary_folder to strip_binary_directory", "archives", "strip_parent_binary_folder", "strip_binary_directory"),
+		renameKey("builds-gobinary", "Rename builds.gobinary to tool", "builds", "gobinary", "tool"),
+		renameKey("nfpms-builds", "Rename nfpms.builds to ids", "nfpms", "builds", "ids"),
+		renameKey("snapcrafts-builds", "Rename snapcrafts.builds to ids", "snapcrafts", "builds", "ids"),
+		renameKey("snapshot-version-template", "Rename snapshot.name_template to version_template", "snapshot", "name_template", "version_template"),
+		renameKey("changelog-skip", "Rename changelog.skip to disable", "changelog", "skip", "disable"),
+		renameKey("brews-tap", "Rename brews.tap to repository", "brews", "tap", "repository"),
+		renameKey("brews-folder", "Rename brews.folder to directory", "brews", "folder", "directory"),
+		renameKey("krews-index", "Rename krews.index to repository", "krews", "index", "repository"),
+		renameKey("blobs-folder", "Rename blobs.folder to directory", "blobs", "folder", "directory"),
+		{
+			ID:          "scoop-to-scoops",
+			Description: "Replace the single scoop section with the scoops list",
+			Target:      TargetGoReleaser,
+			apply:       scoopToScoops,
+		},
+		renameKey("scoops-bucket", "Rename scoops.bucket to repository", "scoops", "bucket", "repository"),
+	}
+}
+
+func versionHeader(root *yaml.Node) []Change {
+	i, ok := lookup(root, "version")
+	if !ok {
+		key := scalar("version")
+		// Keep the file's leading comment above the new header
+		if len(root.Content) > 0 {
+			key.HeadComment, root.Content[0].HeadComment = root.Content[0].HeadComment, ""
+		}
+		insertAt(root, 0, key, intScalar(TargetVersion))
+		return []Change{{
+			Rule:   "version-header",
+			Path:   "version",
+			Line:   1,
+			Before: "(none)",
+			After:  snippet("version", root.Content[1]),
+		}}
+	}
+
+	value := root.Content[i+1]
+	if value.Value == "2" {
+		return nil
+	}
+
+	before := snippet("version", value)
+	root.Content[i+1] = intScalar(TargetVersion)
+	return []Change{{
+		Rule:   "version-header",
+		Path:   "version",
+		Line:   root.Content[i].Line,
+		Before: before,
+		After:  snippet("version", root.Content[i+1]),
+	}}
+}
+
+func archivesFormat(root *yaml.Node) []Change {
+	var changes []Change
+	for _, archive := range sections(root, "archives") {
+		if change := formatToFormats(archive); change != nil {
+			changes = append(changes, *change)
+		}
+		for _, override := range items(archive, "format_overrides") {
+			if change := formatToFormats(override); change != nil {
+				changes = append(changes, *change)
+			}
+		}
+	}
+	return changes
+}
+
+// formatToFormats rewrites `format: x` to `formats: [x]`
+func formatToFormats(e entry) *Change {
+	i, ok := lookup(e.mapping, "format")
+	if !ok {
+		return nil
+	}
+
+	e.index = i
+	before := snippet("format", e.value())
+	if _, exists := lookup(e.mapping, "formats"); exists {
+		line := e.key().Line
+		removeAt(e.mapping, i)
+		return superseded("archives-format", e.path+".format", line, "formats", before)
+	}
+
+	// A trailing comment stays on the line; inside the flow sequence it would
+	// be written before the closing bracket
+	value := e.value()
+	formats := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle, Content: []*yaml.Node{value}, LineComment: value.LineComment}
+	value.LineComment = ""
+	e.key().Value = "formats"
+	e.mapping.Content[i+1] = formats
+	return &Change{
+		Rule:   "archives-format",
+		Path:   e.path + ".format",
+		Line:   e.key().Line,
+		Before: before,
+		After:  snippet("formats", formats),
+	}
+}
+
+func scoopToScoops(root *yaml.Node) []Change {
+	i, ok := lookup(root, "scoop")
+	if !ok {
+		return nil
+	}
+
+	key, value := root.Content[i], root.Content[i+1]
+	before := snippet("scoop", value)
+	if _, exists := lookup(root, "scoops"); exists {
+		removeAt(root, i)
+		return []Change{*superseded("scoop-to-scoops", "scoop", key.Line, "scoops", before)}
+	}
+
+	key.Value = "scoops"
+	root.Content[i+1] = &yaml.Node{Kind: yaml.SequenceNode, Content: []*yaml.Node{value}}
+	return []Change{{
+		Rule:   "scoop-to-scoops",
+		Path:   "scoop",
+		Line:   key.Line,
+		Before: before,
+		After:  snippet("scoops", root.Content[i+1]),
+	}}
+}
+
+// renameKey builds a rule renaming a deprecated key in every item of a section
+func renameKey(id, description, section, oldKey, newKey string) Rule {
+	return Rule{
+		ID:          id,
+		Description: description,
+		Target:      TargetGoReleaser,
+		apply: func(root *yaml.Node) []Change {
+			var changes []Change
+			for _, e := range sections(root, section) {
+				if change := rename(id, e, oldKey, newKey); change != nil {
+					changes = append(changes, *change)
+				}
+			}
+			return changes
+		},
+	}
+}
+
+// rename renames oldKey to newKey in a mapping. If newKey is already set,
+// the deprecated key is dropped.
+func rename(rule string, e entry, oldKey, newKey string) *Change {
+	i, ok := lookup(e.mapping, oldKey)
+	if !ok {
+		return nil
+	}
+
+	e.index = i
+	before := snippet(oldKey, e.value())
+	if _, exists := lookup(e.mapping, newKey); exists {
+		line := e.key().Line
+		removeAt(e.mapping, i)
+		return superseded(rule, e.path+"."+oldKey, line, newKey, before)
+	}
+
+	e.key().Value = newKey
+	return &Change{
+		Rule:   rule,
+		Path:   e.path + "." + oldKey,
+		Line:   e.key().Line,
+		Before: before,
+		After:  snippet(newKey, e.value()),
+	}
+}
+
+// superseded reports a deprecated key dropped because its replacement is already set
+func superseded(rule, path string, line int, newKey, before string) *Change {
+	return &Change{
+		Rule:   rule,
+		Path:   path,
+		Line:   line,
+		Before: before,
+		After:  "(removed, superseded by " + newKey + ")",
+	}
+}
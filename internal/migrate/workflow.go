@@ -0,0 +1,162 @@
+package migrate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// goreleaserActionPattern matches tag references to the GoReleaser action
+var goreleaserActionPattern = regexp.MustCompile(`^goreleaser/goreleaser-action@v([0-9]+)$`)
+
+// goreleaserActionVersion is the action major version that installs GoReleaser v2
+const goreleaserActionVersion = 6
+
+// workflowRules update GitHub Actions workflows that run GoReleaser
+func workflowRules() []Rule {
+	return []Rule{
+		{
+			ID:          "workflow-rm-dist",
+			Description: "Replace the removed --rm-dist flag with --clean",
+			Target:      TargetWorkflow,
+			apply:       workflowRmDist,
+		},
+		{
+			ID:          "workflow-action-version",
+			Description: "Upgrade goreleaser/goreleaser-action to v6",
+			Target:      TargetWorkflow,
+			apply:       workflowActionVersion,
+		},
+		{
+			ID:          "workflow-goreleaser-version",
+			Description: "Install GoReleaser v2 instead of latest or a v1 release",
+			Target:      TargetWorkflow,
+			apply:       workflowGoReleaserVersion,
+		},
+	}
+}
+
+// steps returns every step of every job in a workflow
+func steps(root *yaml.Node) []entry {
+	jobs := valueOf(root, "jobs")
+	if jobs == nil || jobs.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var result []entry
+	for i := 0; i+1 < len(jobs.Content); i += 2 {
+		job := entry{mapping: jobs.Content[i+1], path: "jobs." + jobs.Content[i].Value}
+		result = append(result, items(job, "steps")...)
+	}
+	return result
+}
+
+// isGoReleaserStep reports whether a step uses the GoReleaser action
+func isGoReleaserStep(step entry) bool {
+	uses := valueOf(step.mapping, "uses")
+	return uses != nil && strings.HasPrefix(uses.Value, "goreleaser/goreleaser-action")
+}
+
+func workflowRmDist(root *yaml.Node) []Change {
+	var changes []Change
+	for _, step := range steps(root) {
+		if with := valueOf(step.mapping, "with"); with != nil && isGoReleaserStep(step) {
+			if change := replaceInScalar("workflow-rm-dist", entry{mapping: with, path: step.path + ".with"}, "args"); change != nil {
+				changes = append(changes, *change)
+			}
+		}
+		if run := valueOf(step.mapping, "run"); run != nil && strings.Contains(run.Value, "goreleaser") {
+			if change := replaceInScalar("workflow-rm-dist", step, "run"); change != nil {
+				changes = append(changes, *change)
+			}
+		}
+	}
+	return changes
+}
+
+// replaceInScalar replaces --rm-dist with --clean in a scalar value
+func replaceInScalar(rule string, e entry, key string) *Change {
+	i, ok := lookup(e.mapping, key)
+	if !ok {
+		return nil
+	}
+	e.index = i
+
+	value := e.value()
+	if value.Kind != yaml.ScalarNode || !strings.Contains(value.Value, "--rm-dist") {
+		return nil
+	}
+
+	before := snippet(key, value)
+	value.Value = strings.ReplaceAll(value.Value, "--rm-dist", "--clean")
+	return &Change{
+		Rule:   rule,
+		Path:   e.path + "." + key,
+		Line:   value.Line,
+		Before: before,
+		After:  snippet(key, value),
+	}
+}
+
+func workflowActionVersion(root *yaml.Node) []Change {
+	var changes []Change
+	for _, step := range steps(root) {
+		uses := valueOf(step.mapping, "uses")
+		if uses == nil {
+			continue
+		}
+
+		// SHA-pinned references are left for the user to bump
+		match := goreleaserActionPattern.FindStringSubmatch(uses.Value)
+		if match == nil {
+			continue
+		}
+		if major, err := strconv.Atoi(match[1]); err != nil || major >= goreleaserActionVersion {
+			continue
+		}
+
+		before := snippet("uses", uses)
+		uses.Value = "goreleaser/goreleaser-action@v" + strconv.Itoa(goreleaserActionVersion)
+		changes = append(changes, Change{
+			Rule:   "workflow-action-version",
+			Path:   step.path + ".uses",
+			Line:   uses.Line,
+			Before: before,
+			After:  snippet("uses", uses),
+		})
+	}
+	return changes
+}
+
+func workflowGoReleaserVersion(root *yaml.Node) []Change {
+	var changes []Change
+	for _, step := range steps(root) {
+		if !isGoReleaserStep(step) {
+			continue
+		}
+		version := valueOf(valueOf(step.mapping, "with"), "version")
+		if version == nil || !isV1Version(version.Value) {
+			continue
+		}
+
+		before := snippet("version", version)
+		version.Value = "~> v2"
+		version.Style = yaml.DoubleQuotedStyle
+		changes = append(changes, Change{
+			Rule:   "workflow-goreleaser-version",
+			Path:   step.path + ".with.version",
+			Line:   version.Line,
+			Before: before,
+			After:  snippet("version", version),
+		})
+	}
+	return changes
+}
+
+// isV1Version reports whether an action version input installs GoReleaser v1
+func isV1Version(version string) bool {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "~> ")
+	return version == "latest" || version == "v1" || strings.HasPrefix(version, "v1.") || strings.HasPrefix(version, "1.")
+}
@@ -0,0 +1,302 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/yamlcheck"
+	"go.yaml.in/yaml/v3"
+)
+
+// defaultArchiveFiles are the files GoReleaser adds to archives without files
+var defaultArchiveFiles = []string{"license*", "LICENSE*", "readme*", "README*", "changelog*", "CHANGELOG*"}
+
+// CheckConfig returns every policy violation of a parsed GoReleaser
+// configuration, positioned at the offending node
+func (p *Policy) CheckConfig(root *yaml.Node) domain.ValidationErrors {
+	if root == nil {
+		return nil
+	}
+	var violations domain.ValidationErrors
+	violations = append(violations, p.checkSigning(root)...)
+	violations = append(violations, p.checkSBOM(root)...)
+	violations = append(violations, p.checkRegistries(root)...)
+	violations = append(violations, p.checkCGO(root)...)
+	violations = append(violations, p.checkArchives(root)...)
+	return violations
+}
+
+func (p *Policy) checkSigning(root *yaml.Node) domain.ValidationErrors {
+	if !p.requiresSigning() {
+		return nil
+	}
+	var violations domain.ValidationErrors
+	if len(enabled(items(root, "signs"))) == 0 && len(enabled(items(root, "binary_signs"))) == 0 {
+		violations = append(violations, at(root, "signs", violation("signing",
+			"Signing required by policy",
+			fmt.Sprintf("The policy requires signing level %s; add a signs section", p.Signing.MinLevel),
+		)))
+	}
+	if signingRank[p.Signing.MinLevel] >= signingRank[domain.SigningLevelAdvanced] &&
+		publishesImages(root) && len(enabled(items(root, "docker_signs"))) == 0 {
+		violations = append(violations, at(root, "docker_signs", violation("signing",
+			"Image signing required by policy",
+			fmt.Sprintf("Signing level %s also covers published images; add a docker_signs section", p.Signing.MinLevel),
+		)))
+	}
+	return violations
+}
+
+func (p *Policy) checkSBOM(root *yaml.Node) domain.ValidationErrors {
+	if !p.SBOM.Required || len(enabled(items(root, "sboms"))) > 0 {
+		return nil
+	}
+	return domain.ValidationErrors{at(root, "sboms", violation("sbom",
+		"SBOM required by policy",
+		"The policy requires a software bill of materials; add an sboms section",
+	))}
+}
+
+func (p *Policy) checkRegistries(root *yaml.Node) domain.ValidationErrors {
+	if len(p.Docker.AllowedRegistries) == 0 {
+		return nil
+	}
+	var violations domain.ValidationErrors
+	check := func(node *yaml.Node, field string) {
+		host := imageHost(node.Value)
+		if host != "" && p.allowsRegistry(host) {
+			return
+		}
+		details := fmt.Sprintf("Image %s goes to %s; the policy allows %s", node.Value, host, strings.Join(p.Docker.AllowedRegistries, ", "))
+		if host == "" {
+			details = fmt.Sprintf("The registry of %s is a template and cannot be checked; the policy allows %s", node.Value, strings.Join(p.Docker.AllowedRegistries, ", "))
+		}
+		violations = append(violations, at(node, field, violation("docker-registry", "Docker registry not allowed by policy", details)))
+	}
+
+	for i, docker := range items(root, "dockers") {
+		if skipped(docker, "skip_push") {
+			continue
+		}
+		for j, image := range scalars(yamlcheck.Lookup(docker, "image_templates")) {
+			check(image, fmt.Sprintf("dockers[%d].image_templates[%d]", i, j))
+		}
+	}
+	for i, docker := range items(root, "dockers_v2") {
+		for j, image := range scalars(yamlcheck.Lookup(docker, "images")) {
+			check(image, fmt.Sprintf("dockers_v2[%d].images[%d]", i, j))
+		}
+	}
+	for i, manifest := range items(root, "docker_manifests") {
+		if name := yamlcheck.Lookup(manifest, "name_template"); name != nil && name.Kind == yaml.ScalarNode {
+			check(name, fmt.Sprintf("docker_manifests[%d].name_template", i))
+		}
+	}
+	return violations
+}
+
+func (p *Policy) checkCGO(root *yaml.Node) domain.ValidationErrors {
+	if len(p.CGO.Forbidden) == 0 {
+		return nil
+	}
+	var violations domain.ValidationErrors
+	for i, build := range items(root, "builds") {
+		if skipped(build, "skip") {
+			continue
+		}
+		var cgo *yaml.Node
+		for _, env := range scalars(yamlcheck.Lookup(build, "env")) {
+			if strings.HasPrefix(env.Value, "CGO_ENABLED=") {
+				cgo = env
+			}
+		}
+
+		switch {
+		case cgo != nil && cgo.Value == "CGO_ENABLED=1":
+			if p.forbids(domain.CGOStatusEnabled) || p.forbids(domain.CGOStatusRequired) {
+				violations = append(violations, at(cgo, fmt.Sprintf("builds[%d].env", i), violation("cgo",
+					"CGO forbidden by policy",
+					"The build sets CGO_ENABLED=1, which the policy forbids for release builds",
+				)))
+			}
+		case p.forbids(domain.CGOStatusDisabled):
+			violations = append(violations, at(build, fmt.Sprintf("builds[%d].env", i), violation("cgo",
+				"CGO required by policy",
+				"The policy forbids builds without CGO; set CGO_ENABLED=1 in env",
+			)))
+		}
+	}
+	return violations
+}
+
+func (p *Policy) checkArchives(root *yaml.Node) domain.ValidationErrors {
+	if len(p.Archives.RequiredFiles) == 0 {
+		return nil
+	}
+	archives := items(root, "archives")
+	if yamlcheck.Lookup(root, "archives") == nil {
+		// GoReleaser's default archive ships the default files
+		archives = []*yaml.Node{nil}
+	}
+
+	var violations domain.ValidationErrors
+	for i, archive := range archives {
+		if binaryOnly(archive) {
+			continue
+		}
+		files := archiveFiles(archive)
+		for _, required := range p.Archives.RequiredFiles {
+			if includes(files, required) {
+				continue
+			}
+			node := yamlcheck.Lookup(archive, "files")
+			if node == nil {
+				node = archive
+			}
+			if node == nil {
+				node = root
+			}
+			violations = append(violations, at(node, fmt.Sprintf("archives[%d].files", i), violation("archive-files",
+				"Archive misses a file required by policy",
+				fmt.Sprintf("The policy requires %s in every archive; add it to files", required),
+			)))
+		}
+	}
+	return violations
+}
+
+// at positions a violation at node and binds it to a field path
+func at(node *yaml.Node, field string, err *domain.DomainError) *domain.DomainError {
+	return err.WithField(field).WithPosition(node.Line, node.Column)
+}
+
+// items returns the mapping items of a top-level list
+func items(root *yaml.Node, key string) []*yaml.Node {
+	section := yamlcheck.Lookup(root, key)
+	if section == nil || section.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var result []*yaml.Node
+	for _, item := range section.Content {
+		if item.Kind == yaml.MappingNode {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// enabled drops items switched off with disable: true
+func enabled(nodes []*yaml.Node) []*yaml.Node {
+	var result []*yaml.Node
+	for _, node := range nodes {
+		if !skipped(node, "disable") {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// skipped reports whether a boolean option of an item is literally true.
+// Templated values may be false at release time, so they do not count.
+func skipped(item *yaml.Node, key string) bool {
+	value := yamlcheck.Lookup(item, key)
+	return value != nil && value.Kind == yaml.ScalarNode && value.Value == "true"
+}
+
+// scalars returns the scalar items of a list node
+func scalars(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var result []*yaml.Node
+	for _, item := range node.Content {
+		if item.Kind == yaml.ScalarNode {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// publishesImages reports whether the configuration pushes any image
+func publishesImages(root *yaml.Node) bool {
+	for _, docker := range items(root, "dockers") {
+		if !skipped(docker, "skip_push") {
+			return true
+		}
+	}
+	return len(items(root, "dockers_v2")) > 0 || len(items(root, "docker_manifests")) > 0
+}
+
+// imageHost returns the registry host of an image reference, following
+// Docker's rule that a first component without '.' or ':' is a Docker Hub
+// namespace. It returns "" when the host is templated.
+func imageHost(image string) string {
+	first, _, found := strings.Cut(image, "/")
+	if strings.Contains(first, "{{") {
+		return ""
+	}
+	if !found || (!strings.ContainsAny(first, ".:") && first != "localhost") {
+		return string(domain.DockerRegistryDockerHub)
+	}
+	if first == "index.docker.io" {
+		return string(domain.DockerRegistryDockerHub)
+	}
+	return first
+}
+
+// binaryOnly reports whether an archive ships bare binaries, which cannot
+// carry extra files
+func binaryOnly(archive *yaml.Node) bool {
+	formats := yamlcheck.Lookup(archive, "formats")
+	if formats == nil {
+		formats = yamlcheck.Lookup(archive, "format")
+	}
+	if formats == nil {
+		return false
+	}
+	if formats.Kind == yaml.ScalarNode {
+		return formats.Value == "binary"
+	}
+	values := scalars(formats)
+	for _, format := range values {
+		if format.Value != "binary" {
+			return false
+		}
+	}
+	return len(values) > 0
+}
+
+// archiveFiles returns the file globs of an archive, or GoReleaser's defaults
+func archiveFiles(archive *yaml.Node) []string {
+	files := yamlcheck.Lookup(archive, "files")
+	if files == nil {
+		return defaultArchiveFiles
+	}
+	var globs []string
+	for _, entry := range files.Content {
+		switch entry.Kind {
+		case yaml.ScalarNode:
+			globs = append(globs, entry.Value)
+		case yaml.MappingNode:
+			if src := yamlcheck.Lookup(entry, "src"); src != nil {
+				globs = append(globs, src.Value)
+			}
+		}
+	}
+	return globs
+}
+
+// includes reports whether any glob adds the required file
+func includes(globs []string, required string) bool {
+	for _, glob := range globs {
+		if glob == required {
+			return true
+		}
+		if matched, err := path.Match(glob, required); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,278 @@
+// Package policy enforces organization-wide constraints on wizard answers and
+// GoReleaser configurations
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// FileName is the policy file looked up in the project root
+const FileName = ".goreleaser-wizard-policy.yaml"
+
+// Version is the policy file format this build understands
+const Version = 1
+
+// Policy holds the constraints a project must meet. Zero values leave the
+// corresponding aspect unconstrained.
+type Policy struct {
+	Version  int           `yaml:"version"`
+	Signing  SigningPolicy `yaml:"signing"`
+	SBOM     SBOMPolicy    `yaml:"sbom"`
+	Docker   DockerPolicy  `yaml:"docker"`
+	CGO      CGOPolicy     `yaml:"cgo"`
+	Archives ArchivePolicy `yaml:"archives"`
+
+	path string
+}
+
+// SigningPolicy sets the minimum signing level. In a GoReleaser configuration
+// basic requires signs; advanced and above also require docker_signs when
+// images are published.
+type SigningPolicy struct {
+	MinLevel domain.SigningLevel `yaml:"min_level"`
+}
+
+// SBOMPolicy requires a software bill of materials for every release
+type SBOMPolicy struct {
+	Required bool `yaml:"required"`
+}
+
+// DockerPolicy restricts the registries images may be pushed to
+type DockerPolicy struct {
+	AllowedRegistries []string `yaml:"allowed_registries"`
+}
+
+// CGOPolicy forbids CGO statuses. A GoReleaser build setting CGO_ENABLED=1
+// counts as enabled and required, since it fails without a C toolchain.
+type CGOPolicy struct {
+	Forbidden []domain.CGOStatus `yaml:"forbidden"`
+}
+
+// ArchivePolicy lists files every archive must contain, such as LICENSE
+type ArchivePolicy struct {
+	RequiredFiles []string `yaml:"required_files"`
+}
+
+// Path returns the file the policy was loaded from
+func (p *Policy) Path() string {
+	return p.path
+}
+
+// Find returns the policy file of a project: the configured path when set,
+// otherwise FileName in root. It returns "" when neither exists.
+func Find(root, configured string) string {
+	if configured != "" {
+		return configured
+	}
+	path := filepath.Join(root, FileName)
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// Load reads and checks a policy file
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.FileNotFoundError(path, err)
+		}
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read policy",
+			fmt.Sprintf("Cannot read %s", path),
+			err,
+		).WithContext(path)
+	}
+
+	policy, err := Parse(data)
+	if err != nil {
+		return nil, asInvalid(err).WithContext(path)
+	}
+	policy.path = path
+	return policy, nil
+}
+
+// Parse decodes a policy, rejecting unknown keys and invalid values
+func Parse(data []byte) (*Policy, error) {
+	policy := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(policy); err != nil && !errors.Is(err, io.EOF) {
+		return nil, domain.NewConfigurationError(
+			domain.ErrInvalidPolicy,
+			"Invalid policy file",
+			err.Error(),
+		).WithCause(err)
+	}
+
+	if policy.Version == 0 {
+		policy.Version = Version
+	}
+	if policy.Version != Version {
+		return nil, invalid("version", fmt.Sprintf("Policy version %d is not supported; this build understands version %d", policy.Version, Version))
+	}
+	if level := policy.Signing.MinLevel; level != "" && !level.IsValid() {
+		return nil, invalid("signing.min_level", fmt.Sprintf("'%s' is not a signing level; use none, basic, advanced or enterprise", level))
+	}
+	for i, status := range policy.CGO.Forbidden {
+		if !status.IsValid() {
+			return nil, invalid(fmt.Sprintf("cgo.forbidden[%d]", i), fmt.Sprintf("'%s' is not a CGO status; use disabled, enabled or required", status))
+		}
+	}
+	if policy.forbids(domain.CGOStatusDisabled) && policy.forbids(domain.CGOStatusEnabled) && policy.forbids(domain.CGOStatusRequired) {
+		return nil, invalid("cgo.forbidden", "Every CGO status is forbidden, so no project can comply")
+	}
+	for i, registry := range policy.Docker.AllowedRegistries {
+		if strings.TrimSpace(registry) == "" || strings.Contains(registry, "/") {
+			return nil, invalid(fmt.Sprintf("docker.allowed_registries[%d]", i), fmt.Sprintf("'%s' is not a registry host", registry))
+		}
+	}
+	return policy, nil
+}
+
+func invalid(field, details string) *domain.DomainError {
+	return domain.NewConfigurationError(domain.ErrInvalidPolicy, "Invalid policy file", details).WithField(field)
+}
+
+func asInvalid(err error) *domain.DomainError {
+	var domainErr *domain.DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	return domain.NewConfigurationError(domain.ErrInvalidPolicy, "Invalid policy file", err.Error()).WithCause(err)
+}
+
+// signingRank orders signing levels from weakest to strongest
+var signingRank = map[domain.SigningLevel]int{
+	domain.SigningLevelNone:       0,
+	domain.SigningLevelBasic:      1,
+	domain.SigningLevelAdvanced:   2,
+	domain.SigningLevelEnterprise: 3,
+}
+
+// requiresSigning reports whether the policy asks for any signing
+func (p *Policy) requiresSigning() bool {
+	return signingRank[p.Signing.MinLevel] > 0
+}
+
+// allowsRegistry reports whether images may be pushed to a registry host
+func (p *Policy) allowsRegistry(host string) bool {
+	if len(p.Docker.AllowedRegistries) == 0 {
+		return true
+	}
+	for _, allowed := range p.Docker.AllowedRegistries {
+		if strings.EqualFold(allowed, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// forbids reports whether a CGO status is forbidden
+func (p *Policy) forbids(status domain.CGOStatus) bool {
+	for _, forbidden := range p.CGO.Forbidden {
+		if forbidden == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply changes wizard answers to meet the policy, so init starts from
+// compliant defaults. Answers the policy cannot decide, such as a custom
+// registry host, are left for Check to report.
+func (p *Policy) Apply(config *domain.SafeProjectConfig) {
+	if signingRank[config.SigningLevel] < signingRank[p.Signing.MinLevel] {
+		config.SigningLevel = p.Signing.MinLevel
+	}
+	if p.SBOM.Required {
+		config.SBOM = true
+	}
+	if config.DockerSupport.IsEnabled() && !p.allowsRegistry(string(config.DockerRegistry)) {
+		for _, allowed := range p.Docker.AllowedRegistries {
+			if registry := domain.DockerRegistry(allowed); registry.IsValid() && registry != domain.DockerRegistryCustom {
+				config.DockerRegistry = registry
+				break
+			}
+		}
+	}
+	if p.forbids(config.CGOStatus) {
+		// Relax CGO first, so projects that can use it keep it
+		for _, status := range []domain.CGOStatus{domain.CGOStatusEnabled, domain.CGOStatusDisabled, domain.CGOStatusRequired} {
+			if !p.forbids(status) {
+				config.CGOStatus = status
+				break
+			}
+		}
+	}
+}
+
+// Layer returns the values Apply changes in the answers, as the policy layer
+// of a layered configuration
+func (p *Policy) Layer(config *domain.SafeProjectConfig) domain.Layer {
+	applied := config.Clone()
+	p.Apply(applied)
+
+	values := make(map[string]any)
+	if applied.SigningLevel != config.SigningLevel {
+		values["signing_level"] = string(applied.SigningLevel)
+	}
+	if applied.SBOM != config.SBOM {
+		values["sbom"] = applied.SBOM
+	}
+	if applied.DockerRegistry != config.DockerRegistry {
+		values["docker_registry"] = string(applied.DockerRegistry)
+	}
+	if applied.CGOStatus != config.CGOStatus {
+		values["cgo_status"] = string(applied.CGOStatus)
+	}
+	return domain.Layer{Kind: domain.LayerPolicy, Source: p.Path(), Values: values}
+}
+
+// Check returns every policy violation of wizard answers. Archive contents
+// are fixed by the generator, so they are only checked in configurations.
+func (p *Policy) Check(config *domain.SafeProjectConfig) domain.ValidationErrors {
+	var violations domain.ValidationErrors
+	if signingRank[config.SigningLevel] < signingRank[p.Signing.MinLevel] {
+		violations = append(violations, violation("signing",
+			"Signing level below policy",
+			fmt.Sprintf("Signing level is %s; the policy requires at least %s", config.SigningLevel, p.Signing.MinLevel),
+		).WithField("signing_level"))
+	}
+	if p.SBOM.Required && !config.SBOM {
+		violations = append(violations, violation("sbom",
+			"SBOM required by policy",
+			"The policy requires a software bill of materials; set sbom: true",
+		).WithField("sbom"))
+	}
+	if config.DockerSupport.IsEnabled() && !p.allowsRegistry(string(config.DockerRegistry)) {
+		violations = append(violations, violation("docker-registry",
+			"Docker registry not allowed by policy",
+			fmt.Sprintf("Images go to %s; the policy allows %s", config.DockerRegistry, strings.Join(p.Docker.AllowedRegistries, ", ")),
+		).WithField("docker_registry"))
+	}
+	if p.forbids(config.CGOStatus) {
+		violations = append(violations, violation("cgo",
+			"CGO status forbidden by policy",
+			fmt.Sprintf("CGO status %s is forbidden by the policy", config.CGOStatus),
+		).WithField("cgo_status"))
+	}
+	return violations
+}
+
+// violation builds a policy violation reported under rule policy-<name>
+func violation(name, message, details string) *domain.DomainError {
+	return domain.NewBusinessRuleError(domain.ErrPolicyViolation, message, details).
+		WithRule("policy-" + name)
+}
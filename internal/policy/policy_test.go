@@ -0,0 +1,245 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+const strict = `version: 1
+signing:
+  min_level: advanced
+sbom:
+  required: true
+docker:
+  allowed_registries: [ghcr.io]
+cgo:
+  forbidden: [required]
+archives:
+  required_files: [LICENSE]
+`
+
+func mustParse(t *testing.T, content string) *Policy {
+	t.Helper()
+	policy, err := Parse([]byte(content))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return policy
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		field   string // field of the expected error, "" when valid
+	}{
+		{name: "strict", content: strict},
+		{name: "empty", content: ""},
+		{name: "unknown_key", content: "signing:\n  level: basic\n"},
+		{name: "future_version", content: "version: 2\n", field: "version"},
+		{name: "bad_signing_level", content: "signing:\n  min_level: paranoid\n", field: "signing.min_level"},
+		{name: "bad_cgo_status", content: "cgo:\n  forbidden: [sometimes]\n", field: "cgo.forbidden[0]"},
+		{name: "all_cgo_forbidden", content: "cgo:\n  forbidden: [disabled, enabled, required]\n", field: "cgo.forbidden"},
+		{name: "registry_with_path", content: "docker:\n  allowed_registries: [ghcr.io/org]\n", field: "docker.allowed_registries[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			wantErr := tt.field != "" || tt.name == "unknown_key"
+			if (err != nil) != wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, wantErr)
+			}
+			if err == nil {
+				return
+			}
+			if !domain.IsErrorCode(err, domain.ErrInvalidPolicy) {
+				t.Errorf("Parse() error code = %v, want %s", err, domain.ErrInvalidPolicy)
+			}
+			if tt.field != "" && err.(*domain.DomainError).Field != tt.field {
+				t.Errorf("Parse() field = %q, want %q", err.(*domain.DomainError).Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestFindAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	if got := Find(dir, ""); got != "" {
+		t.Errorf("Find() without a policy = %q", got)
+	}
+	if got := Find(dir, "org/policy.yaml"); got != "org/policy.yaml" {
+		t.Errorf("Find() with a configured path = %q", got)
+	}
+
+	path := filepath.Join(dir, FileName)
+	if err := os.WriteFile(path, []byte(strict), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := Find(dir, ""); got != path {
+		t.Errorf("Find() = %q, want %q", got, path)
+	}
+	policy, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if policy.Path() != path || policy.Signing.MinLevel != domain.SigningLevelAdvanced {
+		t.Errorf("Load() = %+v", policy)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.yaml")); !domain.IsErrorCode(err, domain.ErrFileNotFound) {
+		t.Errorf("Load() of a missing file error = %v", err)
+	}
+}
+
+func TestApplyAndCheck(t *testing.T) {
+	policy := mustParse(t, strict)
+
+	config := domain.NewSafeProjectConfig()
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryDockerHub
+	config.CGOStatus = domain.CGOStatusRequired
+
+	var rules []string
+	for _, violation := range policy.Check(config) {
+		rules = append(rules, violation.Rule+"@"+violation.Field)
+	}
+	expected := []string{
+		"policy-signing@signing_level",
+		"policy-sbom@sbom",
+		"policy-docker-registry@docker_registry",
+		"policy-cgo@cgo_status",
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("Check() = %v, want %v", rules, expected)
+	}
+
+	policy.Apply(config)
+	if violations := policy.Check(config); len(violations) > 0 {
+		t.Errorf("Check() after Apply() = %v", violations)
+	}
+	if config.SigningLevel != domain.SigningLevelAdvanced || !config.SBOM ||
+		config.DockerRegistry != domain.DockerRegistryGitHub || config.CGOStatus != domain.CGOStatusEnabled {
+		t.Errorf("Apply() = %+v", config)
+	}
+
+	// Stronger answers are kept
+	config.SigningLevel = domain.SigningLevelEnterprise
+	policy.Apply(config)
+	if config.SigningLevel != domain.SigningLevelEnterprise {
+		t.Errorf("Apply() lowered the signing level to %s", config.SigningLevel)
+	}
+}
+
+func TestLayer(t *testing.T) {
+	policy := mustParse(t, strict)
+
+	config := domain.NewSafeProjectConfig()
+	config.SigningLevel = domain.SigningLevelEnterprise
+	config.CGOStatus = domain.CGOStatusRequired
+
+	layer := policy.Layer(config)
+	if layer.Kind != domain.LayerPolicy {
+		t.Errorf("Layer() kind = %s, want %s", layer.Kind, domain.LayerPolicy)
+	}
+	// Only the values Apply changes are part of the layer
+	expected := map[string]any{"sbom": true, "cgo_status": string(domain.CGOStatusEnabled)}
+	if !reflect.DeepEqual(layer.Values, expected) {
+		t.Errorf("Layer() values = %v, want %v", layer.Values, expected)
+	}
+	if config.SBOM || config.CGOStatus != domain.CGOStatusRequired {
+		t.Errorf("Layer() changed the answers: %+v", config)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   string
+		expected []string // rule@trimmed line
+	}{
+		{
+			name: "compliant",
+			config: `version: 2
+builds:
+  - env: [CGO_ENABLED=0]
+archives:
+  - files: [LICENSE*, README*]
+dockers:
+  - image_templates: ["ghcr.io/org/app:{{ .Tag }}"]
+sboms:
+  - artifacts: archive
+signs:
+  - artifacts: checksum
+docker_signs:
+  - artifacts: manifests
+`,
+		},
+		{
+			name: "default_archive_ships_license",
+			config: `version: 2
+sboms:
+  - artifacts: archive
+signs:
+  - artifacts: checksum
+`,
+		},
+		{
+			name: "violations",
+			config: `version: 2
+builds:
+  - env:
+      - CGO_ENABLED=1
+archives:
+  - files:
+      - README*
+  - formats: [binary]
+dockers:
+  - image_templates:
+      - "org/app:{{ .Tag }}"
+      - "{{ .Env.REGISTRY }}/app:{{ .Tag }}"
+  - image_templates: ["quay.io/org/app"]
+    skip_push: true
+sboms:
+  - disable: true
+`,
+			expected: []string{
+				"policy-signing@version: 2",
+				"policy-signing@version: 2",
+				"policy-sbom@version: 2",
+				`policy-docker-registry@- "org/app:{{ .Tag }}"`,
+				`policy-docker-registry@- "{{ .Env.REGISTRY }}/app:{{ .Tag }}"`,
+				"policy-cgo@- CGO_ENABLED=1",
+				"policy-archive-files@- README*",
+			},
+		},
+	}
+
+	policy := mustParse(t, strict)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var document yaml.Node
+			if err := yaml.Unmarshal([]byte(tt.config), &document); err != nil {
+				t.Fatal(err)
+			}
+			lines := strings.Split(tt.config, "\n")
+
+			var results []string
+			for _, violation := range policy.CheckConfig(document.Content[0]) {
+				if violation.Code != domain.ErrPolicyViolation {
+					t.Errorf("violation code = %s", violation.Code)
+				}
+				results = append(results, violation.Rule+"@"+strings.TrimSpace(lines[violation.Line-1]))
+			}
+			if !reflect.DeepEqual(results, tt.expected) {
+				t.Errorf("CheckConfig() =\n%v\nwant\n%v", results, tt.expected)
+			}
+		})
+	}
+}
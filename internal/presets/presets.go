@@ -0,0 +1,206 @@
+// Package presets provides named partial wizard answers for common release
+// shapes, such as a Kubernetes service or a desktop application
+package presets
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/answers"
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+//go:embed builtin/*.yaml
+var builtinFiles embed.FS
+
+// Preset is a named set of partial answers and the reasoning behind them
+type Preset struct {
+	Name        string
+	Description string
+	Rationale   string
+	// Source is the file of a user-defined preset, or "" for a built-in one
+	Source string
+	Layer  domain.Layer
+
+	answers *yaml.Node
+}
+
+// Builtin reports whether the preset ships with the wizard
+func (p *Preset) Builtin() bool {
+	return p.Source == ""
+}
+
+// Answers renders the preset's answers as written in its file
+func (p *Preset) Answers() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(p.answers); err != nil {
+		return nil, invalid(p.Name, err.Error()).WithCause(err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, invalid(p.Name, err.Error()).WithCause(err)
+	}
+	return buf.Bytes(), nil
+}
+
+// file is the on-disk form of a preset
+type file struct {
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description"`
+	Rationale   string    `yaml:"rationale"`
+	Answers     yaml.Node `yaml:"answers"`
+}
+
+// Catalog holds the built-in presets and those of the user
+type Catalog struct {
+	presets map[string]*Preset
+}
+
+// Load returns the built-in presets together with the user-defined presets
+// in dir, one .yaml file each. A user preset replaces a built-in one of the
+// same name. An empty dir loads the built-in presets only.
+func Load(dir string) (*Catalog, error) {
+	catalog := &Catalog{presets: make(map[string]*Preset)}
+
+	names, err := builtinFiles.ReadDir("builtin")
+	if err != nil {
+		return nil, domain.NewSystemError(domain.ErrFileReadFailed, "Failed to read built-in presets", err.Error(), err)
+	}
+	for _, entry := range names {
+		data, err := builtinFiles.ReadFile(path.Join("builtin", entry.Name()))
+		if err != nil {
+			return nil, domain.NewSystemError(domain.ErrFileReadFailed, "Failed to read built-in presets", err.Error(), err)
+		}
+		preset, err := Parse(strings.TrimSuffix(entry.Name(), ".yaml"), "", data)
+		if err != nil {
+			return nil, err
+		}
+		catalog.presets[preset.Name] = preset
+	}
+
+	if dir == "" {
+		return catalog, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, domain.FileNotFoundError(dir, err)
+		}
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read presets",
+			fmt.Sprintf("Cannot read %s", dir),
+			err,
+		).WithContext(dir)
+	}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		source := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, domain.NewSystemError(
+				domain.ErrFileReadFailed,
+				"Failed to read preset",
+				fmt.Sprintf("Cannot read %s", source),
+				err,
+			).WithContext(source)
+		}
+		preset, err := Parse(strings.TrimSuffix(entry.Name(), ext), source, data)
+		if err != nil {
+			return nil, err
+		}
+		catalog.presets[preset.Name] = preset
+	}
+	return catalog, nil
+}
+
+// Parse decodes a preset. The name defaults to the file name; source is the
+// file of a user-defined preset, or "" for a built-in one.
+func Parse(name, source string, data []byte) (*Preset, error) {
+	var f file
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, invalid(name, err.Error()).WithContext(source).WithCause(err)
+	}
+	if f.Name != "" {
+		name = f.Name
+	}
+	if f.Description == "" {
+		return nil, invalid(name, "a preset needs a description").WithField("description").WithContext(source)
+	}
+	if f.Answers.Kind != yaml.MappingNode || len(f.Answers.Content) == 0 {
+		return nil, invalid(name, "a preset needs answers, e.g. 'answers: {signing_level: advanced}'").WithField("answers").WithContext(source)
+	}
+
+	layerSource := name
+	if source != "" {
+		layerSource = fmt.Sprintf("%s: %s", name, source)
+	}
+	content, err := yaml.Marshal(&f.Answers)
+	if err != nil {
+		return nil, invalid(name, err.Error()).WithContext(source).WithCause(err)
+	}
+	layer, err := answers.DecodeLayer(domain.LayerPreset, layerSource, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Preset{
+		Name:        name,
+		Description: f.Description,
+		Rationale:   strings.TrimSpace(f.Rationale),
+		Source:      source,
+		Layer:       layer,
+		answers:     &f.Answers,
+	}, nil
+}
+
+// Get returns a preset by name
+func (c *Catalog) Get(name string) (*Preset, error) {
+	preset, ok := c.presets[name]
+	if !ok {
+		return nil, domain.NewValidationError(
+			domain.ErrUnknownPreset,
+			"Unknown preset",
+			fmt.Sprintf("%q is not a preset; available presets: %s", name, strings.Join(c.Names(), ", ")),
+		).WithField("preset")
+	}
+	return preset, nil
+}
+
+// List returns every preset, sorted by name
+func (c *Catalog) List() []*Preset {
+	list := make([]*Preset, 0, len(c.presets))
+	for _, name := range c.Names() {
+		list = append(list, c.presets[name])
+	}
+	return list
+}
+
+// Names returns the preset names in order
+func (c *Catalog) Names() []string {
+	names := make([]string, 0, len(c.presets))
+	for name := range c.presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func invalid(name, details string) *domain.DomainError {
+	return domain.NewConfigurationError(domain.ErrInvalidPreset, fmt.Sprintf("Invalid preset %q", name), details)
+}
@@ -0,0 +1,124 @@
+package presets
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// detected stands in for what init detects from go.mod
+var detected = domain.Layer{Kind: domain.LayerDetected, Source: "go.mod", Values: map[string]any{
+	"project_name": "tool",
+	"binary_name":  "tool",
+	"main_path":    "./cmd/tool",
+}}
+
+func TestBuiltinPresets(t *testing.T) {
+	catalog, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	expected := []string{"desktop-app", "enterprise-secure", "k8s-service", "library-with-tool", "minimal", "minimal-cli", "pro"}
+	if !reflect.DeepEqual(catalog.Names(), expected) {
+		t.Errorf("Names() = %v, want %v", catalog.Names(), expected)
+	}
+
+	for _, preset := range catalog.List() {
+		if !preset.Builtin() || preset.Rationale == "" {
+			t.Errorf("%s: builtin = %t, rationale = %q", preset.Name, preset.Builtin(), preset.Rationale)
+		}
+		resolved, err := domain.ResolveLayers(preset.Layer, detected)
+		if err != nil {
+			t.Errorf("%s: ResolveLayers() error = %v", preset.Name, err)
+			continue
+		}
+		if err := resolved.Config.ValidateInvariants(); err != nil {
+			t.Errorf("%s: answers are invalid: %v", preset.Name, err)
+		}
+		for field := range preset.Layer.Values {
+			if resolved.Source(field).Kind != domain.LayerPreset {
+				t.Errorf("%s: %s was not set by the preset", preset.Name, field)
+			}
+		}
+	}
+}
+
+func TestCombinedPresets(t *testing.T) {
+	catalog, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	service, _ := catalog.Get("k8s-service")
+	secure, _ := catalog.Get("enterprise-secure")
+
+	resolved, err := domain.ResolveLayers(service.Layer, secure.Layer, detected)
+	if err != nil {
+		t.Fatalf("ResolveLayers() error = %v", err)
+	}
+	config := resolved.Config
+	if config.ProjectType != domain.ProjectTypeWeb || config.SigningLevel != domain.SigningLevelEnterprise || config.DockerSupport != domain.DockerSupportBoth {
+		t.Errorf("combined presets = %s, %s, %s", config.ProjectType, config.SigningLevel, config.DockerSupport)
+	}
+}
+
+func TestUserPresets(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"team-api.yaml": "description: Our API services\nanswers:\n  project_type: api\n  docker_registry: quay.io\n",
+		"minimal.yml":   "name: minimal\ndescription: Team minimal\nanswers:\n  homebrew: true\n",
+		"notes.txt":     "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	catalog, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	team, err := catalog.Get("team-api")
+	if err != nil || team.Builtin() || team.Layer.Values["docker_registry"] != "quay.io" {
+		t.Errorf("Get(team-api) = %+v, %v", team, err)
+	}
+	minimal, _ := catalog.Get("minimal")
+	if minimal.Description != "Team minimal" {
+		t.Errorf("user preset did not replace the built-in one: %q", minimal.Description)
+	}
+
+	if _, err := catalog.Get("missing"); !domain.IsErrorCode(err, domain.ErrUnknownPreset) {
+		t.Errorf("Get(missing) error = %v", err)
+	}
+}
+
+func TestParseRejectsInvalidPresets(t *testing.T) {
+	tests := map[string]domain.ErrorCode{
+		"answers: {sbom: true}\n":                         domain.ErrInvalidPreset,
+		"description: No answers\n":                       domain.ErrInvalidPreset,
+		"description: x\nanswers: {sbom: true}\nextra: 1": domain.ErrInvalidPreset,
+		"description: x\nanswers: {signing: basic}\n":     domain.ErrInvalidAnswersPath,
+		"description: x\nanswers: {state: valid}\n":       domain.ErrInvalidConfigLayer,
+	}
+	for content, code := range tests {
+		if _, err := Parse("broken", "broken.yaml", []byte(content)); !domain.IsErrorCode(err, code) {
+			t.Errorf("Parse(%q) error = %v, want %s", content, err, code)
+		}
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	catalog, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	preset, _ := catalog.Get("pro")
+	data, err := preset.Answers()
+	if err != nil || string(data) != "feature_level: professional\n" {
+		t.Errorf("Answers() = %q, %v", data, err)
+	}
+}
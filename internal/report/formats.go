@@ -0,0 +1,294 @@
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type jsonFinding struct {
+	Level   Level  `json:"level"`
+	RuleID  string `json:"rule_id"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Field   string `json:"field,omitempty"`
+}
+
+type jsonReport struct {
+	Tool            string        `json:"tool"`
+	Version         string        `json:"version"`
+	ExitCode        int           `json:"exit_code"`
+	Checks          []Check       `json:"checks"`
+	Findings        []jsonFinding `json:"findings"`
+	Recommendations []string      `json:"recommendations"`
+}
+
+func writeJSON(w io.Writer, report *Report) error {
+	out := jsonReport{
+		Tool:            report.Tool,
+		Version:         report.Version,
+		ExitCode:        report.ExitCode,
+		Checks:          report.Checks,
+		Findings:        []jsonFinding{},
+		Recommendations: report.Recommendations,
+	}
+	if out.Checks == nil {
+		out.Checks = []Check{}
+	}
+	if out.Recommendations == nil {
+		out.Recommendations = []string{}
+	}
+	for _, f := range report.findings() {
+		out.Findings = append(out.Findings, jsonFinding{
+			Level:   f.level,
+			RuleID:  RuleID(f.err),
+			Code:    string(f.err.Code),
+			Message: f.err.Message,
+			Details: f.err.Details,
+			File:    f.err.Context,
+			Line:    f.err.Line,
+			Column:  f.err.Column,
+			Field:   f.err.Field,
+		})
+	}
+	return encodeJSON(w, out)
+}
+
+func encodeJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+// SARIF 2.1.0, limited to what code scanning reads
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	Help             sarifMessage `json:"help"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     Level           `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+func writeSARIF(w io.Writer, report *Report) error {
+	driver := sarifDriver{
+		Name:           report.Tool,
+		Version:        report.Version,
+		InformationURI: "https://github.com/LarsArtmann/template-GoReleaser",
+		Rules:          []sarifRule{},
+	}
+	results := []sarifResult{}
+	index := make(map[string]int)
+
+	for _, f := range report.findings() {
+		id := RuleID(f.err)
+		if _, ok := index[id]; !ok {
+			description := report.RuleDescriptions[id]
+			if description == "" {
+				description = f.err.Message
+			}
+			index[id] = len(driver.Rules)
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:               id,
+				ShortDescription: sarifMessage{Text: description},
+				Help:             sarifMessage{Text: f.err.GetRecoverySuggestion()},
+			})
+		}
+
+		result := sarifResult{
+			RuleID:    id,
+			RuleIndex: index[id],
+			Level:     f.level,
+			Message:   sarifMessage{Text: text(f.err)},
+		}
+		if f.err.Context != "" {
+			location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: f.err.Context}}
+			if f.err.Line > 0 {
+				location.Region = &sarifRegion{StartLine: f.err.Line, StartColumn: f.err.Column}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+		results = append(results, result)
+	}
+
+	return encodeJSON(w, sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
+
+// JUnit XML as read by common CI dashboards: one test case per finding,
+// errors fail, warnings pass with the warning in system-out
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnit(w io.Writer, report *Report) error {
+	suite := junitSuite{Name: report.Tool + " validate"}
+	for _, f := range report.findings() {
+		classname := f.err.Context
+		if classname == "" {
+			classname = "project"
+		}
+		testCase := junitCase{
+			Name:      fmt.Sprintf("%s: %s", RuleID(f.err), f.err.Message),
+			Classname: classname,
+		}
+		if location := f.err.Location(); f.err.Line > 0 {
+			testCase.Name = fmt.Sprintf("%s %s", location, testCase.Name)
+		}
+		if f.level == LevelError {
+			testCase.Failure = &junitFailure{Message: f.err.Message, Type: RuleID(f.err), Text: f.err.Details}
+			suite.Failures++
+		} else {
+			testCase.SystemOut = fmt.Sprintf("%s: %s", f.level, text(f.err))
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	// Dashboards show an empty suite as missing; record the passing run
+	if len(suite.Cases) == 0 {
+		suite.Cases = append(suite.Cases, junitCase{Name: "validate", Classname: "project"})
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitSuites{
+		Name:     suite.Name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitSuite{suite},
+	}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// GitHub Actions workflow commands, see
+// https://docs.github.com/actions/reference/workflow-commands-for-github-actions
+
+func writeGitHub(w io.Writer, report *Report) error {
+	for _, f := range report.findings() {
+		command := "error"
+		if f.level == LevelWarning {
+			command = "warning"
+		}
+
+		var properties []string
+		if f.err.Context != "" {
+			properties = append(properties, "file="+escapeProperty(f.err.Context))
+			if f.err.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", f.err.Line))
+			}
+			if f.err.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", f.err.Column))
+			}
+		}
+		properties = append(properties, "title="+escapeProperty(RuleID(f.err)))
+
+		if _, err := fmt.Fprintf(w, "::%s %s::%s\n", command, strings.Join(properties, ","), escapeData(text(f.err))); err != nil {
+			return err
+		}
+	}
+	for _, recommendation := range report.Recommendations {
+		if _, err := fmt.Fprintf(w, "::notice::%s\n", escapeData(recommendation)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func escapeData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+func escapeProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
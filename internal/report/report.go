@@ -0,0 +1,141 @@
+// Package report renders validation results in machine-readable formats
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// Format is an output format of the validate command
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatSARIF  Format = "sarif"
+	FormatJUnit  Format = "junit"
+	FormatGitHub Format = "github"
+)
+
+// GetAllFormats returns all output formats
+func GetAllFormats() []Format {
+	return []Format{FormatText, FormatJSON, FormatSARIF, FormatJUnit, FormatGitHub}
+}
+
+// IsValid reports whether the format is known
+func (f Format) IsValid() bool {
+	for _, format := range GetAllFormats() {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the format name
+func (f Format) String() string {
+	return string(f)
+}
+
+// ParseFormat resolves a format name
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	if !format.IsValid() {
+		names := make([]string, 0, len(GetAllFormats()))
+		for _, f := range GetAllFormats() {
+			names = append(names, f.String())
+		}
+		return "", domain.NewValidationError(
+			domain.ErrUnknownOutputFormat,
+			"Unknown output format",
+			fmt.Sprintf("'%s' is not one of %s", name, strings.Join(names, ", ")),
+		)
+	}
+	return format, nil
+}
+
+// Level is the severity of a finding in a report
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+// Check is the outcome of one area of validation, e.g. the GoReleaser configuration
+type Check struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // valid, invalid or missing
+}
+
+// Report is everything validate found, independent of the output format
+type Report struct {
+	Tool            string
+	Version         string
+	Checks          []Check
+	Errors          []*domain.DomainError
+	Warnings        []*domain.DomainError
+	Recommendations []string
+	ExitCode        int
+
+	// RuleDescriptions describes rule ids for formats that list rules, like SARIF
+	RuleDescriptions map[string]string
+}
+
+// RuleID returns the stable rule id of a finding: the lint rule when the
+// finding comes from one, the error code otherwise
+func RuleID(err *domain.DomainError) string {
+	if err.Rule != "" {
+		return err.Rule
+	}
+	return string(err.Code)
+}
+
+// Write renders the report in a machine-readable format
+func Write(w io.Writer, format Format, report *Report) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, report)
+	case FormatSARIF:
+		return writeSARIF(w, report)
+	case FormatJUnit:
+		return writeJUnit(w, report)
+	case FormatGitHub:
+		return writeGitHub(w, report)
+	default:
+		return domain.NewValidationError(
+			domain.ErrUnknownOutputFormat,
+			"Unsupported output format",
+			fmt.Sprintf("'%s' is rendered by the caller", format),
+		)
+	}
+}
+
+// finding pairs a problem with its level, in report order
+type finding struct {
+	level Level
+	err   *domain.DomainError
+}
+
+func (r *Report) findings() []finding {
+	findings := make([]finding, 0, len(r.Errors)+len(r.Warnings))
+	for _, err := range r.Errors {
+		findings = append(findings, finding{level: LevelError, err: err})
+	}
+	for _, warning := range r.Warnings {
+		findings = append(findings, finding{level: LevelWarning, err: warning})
+	}
+	return findings
+}
+
+// text returns the full message of a problem including its details
+func text(err *domain.DomainError) string {
+	if err.Details == "" {
+		return err.Message
+	}
+	return err.Message + ": " + err.Details
+}
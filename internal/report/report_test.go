@@ -0,0 +1,145 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func sampleReport() *Report {
+	return &Report{
+		Tool:     "goreleaser-wizard",
+		Version:  "1.0.0",
+		Checks:   []Check{{Name: "goreleaser", Status: "invalid"}},
+		ExitCode: 1,
+		Errors: []*domain.DomainError{
+			domain.NewValidationError(domain.ErrLintViolation, "Duplicate build id", "Build id 'app' is already used").
+				WithContext(".goreleaser.yaml").WithPosition(6, 9).WithRule("duplicate-build-id"),
+			domain.NewSystemError(domain.ErrFileNotFound, "Main file not found", "", nil),
+		},
+		Warnings: []*domain.DomainError{
+			domain.NewValidationError(domain.ErrYAMLTabIndentation, "Tab, in: indentation", "100% tabs\nhere").
+				WithContext("dir,a/release.yml").WithPosition(3, 1),
+		},
+		Recommendations:  []string{"Install GoReleaser"},
+		RuleDescriptions: map[string]string{"duplicate-build-id": "Build ids must be unique"},
+	}
+}
+
+func TestRuleID(t *testing.T) {
+	report := sampleReport()
+	if id := RuleID(report.Errors[0]); id != "duplicate-build-id" {
+		t.Errorf("RuleID(lint) = %s", id)
+	}
+	if id := RuleID(report.Errors[1]); id != "FILE_NOT_FOUND" {
+		t.Errorf("RuleID(code) = %s", id)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range GetAllFormats() {
+		if parsed, err := ParseFormat(strings.ToUpper(format.String())); err != nil || parsed != format {
+			t.Errorf("ParseFormat(%s) = %s, %v", format, parsed, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); !domain.IsErrorCode(err, domain.ErrUnknownOutputFormat) {
+		t.Errorf("ParseFormat(xml) error = %v", err)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.ExitCode != 1 || len(decoded.Findings) != 3 || len(decoded.Recommendations) != 1 {
+		t.Fatalf("unexpected report: %+v", decoded)
+	}
+	first := decoded.Findings[0]
+	if first.RuleID != "duplicate-build-id" || first.File != ".goreleaser.yaml" || first.Line != 6 || first.Level != LevelError {
+		t.Errorf("first finding = %+v", first)
+	}
+	if decoded.Findings[2].Level != LevelWarning {
+		t.Errorf("warning level = %s", decoded.Findings[2].Level)
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatSARIF, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid SARIF: %v", err)
+	}
+	run := decoded.Runs[0]
+	if decoded.Version != "2.1.0" || len(run.Tool.Driver.Rules) != 3 || len(run.Results) != 3 {
+		t.Fatalf("unexpected SARIF: %s", buf.String())
+	}
+	if rule := run.Tool.Driver.Rules[0]; rule.ShortDescription.Text != "Build ids must be unique" {
+		t.Errorf("rule description = %q", rule.ShortDescription.Text)
+	}
+
+	located := run.Results[0].Locations[0].PhysicalLocation
+	if located.ArtifactLocation.URI != ".goreleaser.yaml" || located.Region.StartLine != 6 || located.Region.StartColumn != 9 {
+		t.Errorf("location = %+v", located)
+	}
+	if len(run.Results[1].Locations) != 0 {
+		t.Errorf("finding without file has locations: %+v", run.Results[1].Locations)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJUnit, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid XML: %v", err)
+	}
+	if decoded.Tests != 3 || decoded.Failures != 2 {
+		t.Errorf("tests = %d, failures = %d", decoded.Tests, decoded.Failures)
+	}
+	if name := decoded.Suites[0].Cases[0].Name; name != ".goreleaser.yaml:6:9 duplicate-build-id: Duplicate build id" {
+		t.Errorf("test case name = %q", name)
+	}
+
+	// A clean run still reports a passing test
+	buf.Reset()
+	if err := Write(&buf, FormatJUnit, &Report{Tool: "goreleaser-wizard"}); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `tests="1" failures="0"`) {
+		t.Errorf("clean run:\n%s", buf.String())
+	}
+}
+
+func TestWriteGitHub(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatGitHub, sampleReport()); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"::error file=.goreleaser.yaml,line=6,col=9,title=duplicate-build-id::Duplicate build id: Build id 'app' is already used",
+		"::error title=FILE_NOT_FOUND::Main file not found",
+		"::warning file=dir%2Ca/release.yml,line=3,col=1,title=YAML_TAB_INDENTATION::Tab, in: indentation: 100%25 tabs%0Ahere",
+		"::notice::Install GoReleaser",
+	}
+	if got := strings.TrimSpace(buf.String()); got != strings.Join(expected, "\n") {
+		t.Errorf("annotations =\n%s\nexpected\n%s", got, strings.Join(expected, "\n"))
+	}
+}
@@ -0,0 +1,228 @@
+// Package schema validates GoReleaser configurations offline against bundled JSON schemas
+package schema
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Version identifies a bundled schema
+type Version string
+
+const (
+	VersionV1  Version = "v1"
+	VersionV2  Version = "v2"
+	VersionPro Version = "pro"
+)
+
+// GetAllVersions returns every bundled schema version
+func GetAllVersions() []Version {
+	return []Version{VersionV1, VersionV2, VersionPro}
+}
+
+// IsValid returns true if Version is a bundled schema
+func (v Version) IsValid() bool {
+	switch v {
+	case VersionV1, VersionV2, VersionPro:
+		return true
+	default:
+		return false
+	}
+}
+
+// String returns the string representation
+func (v Version) String() string {
+	return string(v)
+}
+
+// Description describes the GoReleaser releases a schema covers
+func (v Version) Description() string {
+	switch v {
+	case VersionV1:
+		return "GoReleaser v1.x (no 'version: 2' header)"
+	case VersionV2:
+		return "GoReleaser v2.x"
+	case VersionPro:
+		return "GoReleaser Pro v2.x"
+	default:
+		return ""
+	}
+}
+
+// ParseVersion parses a schema version name
+func ParseVersion(name string) (Version, error) {
+	version := Version(strings.ToLower(strings.TrimSpace(name)))
+	if !version.IsValid() {
+		names := make([]string, 0, len(GetAllVersions()))
+		for _, v := range GetAllVersions() {
+			names = append(names, v.String())
+		}
+		return "", domain.NewValidationError(
+			domain.ErrUnknownSchemaVersion,
+			"Unknown schema version",
+			fmt.Sprintf("'%s' is not one of %s", name, strings.Join(names, ", ")),
+		)
+	}
+	return version, nil
+}
+
+// Schema is the subset of JSON Schema used by the bundled schemas
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+
+	// never is set for the boolean schema false, which matches nothing
+	never bool
+}
+
+// UnmarshalJSON accepts boolean schemas as well as schema objects
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = Schema{}
+		return nil
+	case "false":
+		*s = Schema{never: true}
+		return nil
+	}
+
+	type plain Schema
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// Types is the JSON Schema type keyword, a single name or a list of names
+type Types []string
+
+// UnmarshalJSON accepts a string or an array of strings
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = Types{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Load returns the bundled schema of a version. The v1 and Pro schemas are
+// stored as RFC 7396 merge patches on top of the v2 schema.
+func Load(version Version) (*Schema, error) {
+	if !version.IsValid() {
+		return nil, domain.NewValidationError(
+			domain.ErrUnknownSchemaVersion,
+			"Unknown schema version",
+			fmt.Sprintf("No bundled schema for '%s'", version),
+		)
+	}
+
+	document, err := readJSON("schemas/v2.json")
+	if err != nil {
+		return nil, err
+	}
+
+	if version != VersionV2 {
+		patch, err := readJSON(fmt.Sprintf("schemas/%s.patch.json", version))
+		if err != nil {
+			return nil, err
+		}
+		document = mergePatch(document, patch)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	schema := &Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, bundleError(string(version), err)
+	}
+	return schema, nil
+}
+
+func readJSON(name string) (any, error) {
+	data, err := schemaFS.ReadFile(name)
+	if err != nil {
+		return nil, bundleError(name, err)
+	}
+	var document any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, bundleError(name, err)
+	}
+	return document, nil
+}
+
+func bundleError(name string, err error) *domain.DomainError {
+	return domain.NewTemplateError(
+		domain.ErrTemplateSyntaxError,
+		"Invalid bundled schema",
+		fmt.Sprintf("Cannot load %s: %v", name, err),
+	).WithCause(err)
+}
+
+// mergePatch applies an RFC 7396 JSON merge patch to target
+func mergePatch(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	result := make(map[string]any, len(targetObject))
+	for key, value := range targetObject {
+		result[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatch(result[key], value)
+	}
+	return result
+}
+
+// resolve follows a local "#/$defs/name" reference
+func (s *Schema) resolve(root *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/$defs/")
+		s = root.Defs[name]
+	}
+	return s
+}
+
+// allows reports whether the type keyword admits a JSON type. Strings accept
+// every scalar, as GoReleaser decodes unquoted values like 386 into strings.
+func (t Types) allows(name string) bool {
+	if len(t) == 0 {
+		return true
+	}
+	scalar := name == "integer" || name == "number" || name == "boolean"
+	for _, typ := range t {
+		if typ == name || (typ == "number" && name == "integer") || (typ == "string" && scalar) {
+			return true
+		}
+	}
+	return false
+}
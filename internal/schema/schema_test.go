@@ -0,0 +1,254 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"github.com/LarsArtmann/template-GoReleaser/internal/generator"
+	"go.yaml.in/yaml/v3"
+)
+
+func parse(t *testing.T, content string) *yaml.Node {
+	t.Helper()
+	var document yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &document); err != nil {
+		t.Fatalf("invalid test YAML: %v", err)
+	}
+	return document.Content[0]
+}
+
+func TestLoadAllVersions(t *testing.T) {
+	for _, version := range GetAllVersions() {
+		schema, err := Load(version)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", version, err)
+		}
+		if _, ok := schema.Properties["builds"]; !ok {
+			t.Errorf("Load(%s) schema has no builds", version)
+		}
+	}
+
+	if _, err := Load("v3"); !domain.IsErrorCode(err, domain.ErrUnknownSchemaVersion) {
+		t.Errorf("Load(v3) error = %v, expected %s", err, domain.ErrUnknownSchemaVersion)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		version Version
+		config  string
+		code    domain.ErrorCode
+		field   string
+		line    int
+		details string
+		warning bool
+	}{
+		{
+			name:    "valid",
+			version: VersionV2,
+			config:  "version: 2\nproject_name: app\nbuilds:\n  - goos: [linux]\n    ldflags: -s -w\n",
+		},
+		{
+			name:    "misspelled_top_level_key",
+			version: VersionV2,
+			config:  "version: 2\nbuidls:\n  - main: .\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "buidls",
+			line:    2,
+			details: "Did you mean 'builds'?",
+			warning: true,
+		},
+		{
+			name:    "unmodelled_top_level_key",
+			version: VersionV2,
+			config:  "version: 2\ndockers_v2:\n  - images: [acme/app]\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "dockers_v2",
+			line:    2,
+			details: "not a top-level key the bundled schema knows",
+			warning: true,
+		},
+		{
+			name:    "misspelled_nested_key",
+			version: VersionV2,
+			config:  "version: 2\nbuilds:\n  - main: .\n    goarh: [amd64]\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "builds[0].goarh",
+			line:    4,
+			details: "Did you mean 'goarch'?",
+		},
+		{
+			name:    "wrong_type",
+			version: VersionV2,
+			config:  "version: 2\nbuilds:\n  - goos: linux\n",
+			code:    domain.ErrSchemaTypeMismatch,
+			field:   "builds[0].goos",
+			line:    3,
+			details: "must be an array, found a string",
+		},
+		{
+			name:    "any_of_type",
+			version: VersionV2,
+			config:  "version: 2\nbuilds:\n  - flags: {trimpath: true}\n",
+			code:    domain.ErrSchemaTypeMismatch,
+			field:   "builds[0].flags",
+			details: "must be a string or an array",
+			line:    3,
+		},
+		{
+			name:    "enum_violation",
+			version: VersionV2,
+			config:  "version: 2\nchecksum:\n  algorithm: sha265\n",
+			code:    domain.ErrSchemaEnumViolation,
+			field:   "checksum.algorithm",
+			line:    3,
+			details: "Did you mean 'sha256'?",
+		},
+		{
+			name:    "templated_enum_skipped",
+			version: VersionV2,
+			config:  "version: 2\nchecksum:\n  algorithm: \"{{ .Env.ALGO }}\"\n",
+		},
+		{
+			name:    "merge_key_expanded",
+			version: VersionV2,
+			config:  "version: 2\nbuilds:\n  - &d\n    goos: [linux]\n  - <<: *d\n    goos: linux\n",
+			code:    domain.ErrSchemaTypeMismatch,
+			field:   "builds[1].goos",
+			line:    6,
+		},
+		{
+			name:    "v1_keys_removed_in_v2",
+			version: VersionV2,
+			config:  "version: 2\nsnapshot:\n  name_template: next\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "snapshot.name_template",
+			line:    3,
+		},
+		{
+			name:    "v1_keys_accepted_by_v1",
+			version: VersionV1,
+			config:  "snapshot:\n  name_template: next\nbrews:\n  - tap: {owner: acme}\n    folder: Formula\n",
+		},
+		{
+			name:    "v2_keys_rejected_by_v1",
+			version: VersionV1,
+			config:  "snapshot:\n  version_template: next\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "snapshot.version_template",
+			line:    2,
+		},
+		{
+			name:    "pro_keys_rejected_by_v2",
+			version: VersionV2,
+			config:  "version: 2\nmonorepo:\n  tag_prefix: app/\n",
+			code:    domain.ErrSchemaUnknownKey,
+			field:   "monorepo",
+			line:    2,
+			warning: true,
+		},
+		{
+			name:    "pro_keys_accepted_by_pro",
+			version: VersionPro,
+			config:  "version: 2\nmonorepo:\n  tag_prefix: app/\npartial:\n  by: target\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Load(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			result := schema.Validate(parse(t, tt.config))
+			problems, other := result.Errors, result.Warnings
+			if tt.warning {
+				problems, other = other, problems
+			}
+			if len(other) != 0 {
+				t.Errorf("Validate() unexpected problems: %v", other)
+			}
+
+			if tt.code == "" {
+				if len(problems) != 0 {
+					t.Errorf("Validate() unexpected problems: %v", problems)
+				}
+				return
+			}
+			if len(problems) != 1 {
+				t.Fatalf("Validate() expected 1 problem, got %v", problems)
+			}
+
+			problem := problems[0]
+			if problem.Code != tt.code || problem.Field != tt.field || problem.Line != tt.line {
+				t.Errorf("problem = %s %s line %d, expected %s %s line %d",
+					problem.Code, problem.Field, problem.Line, tt.code, tt.field, tt.line)
+			}
+			if !strings.Contains(problem.Details, tt.details) {
+				t.Errorf("details = %q, expected to contain %q", problem.Details, tt.details)
+			}
+		})
+	}
+}
+
+func TestGeneratedConfigMatchesSchema(t *testing.T) {
+	config := domain.NewSafeProjectConfig()
+	config.ProjectName = "app"
+	config.BinaryName = "app"
+	config.MainPath = "./cmd/app"
+	config.Platforms = []domain.Platform{domain.PlatformLinux, domain.PlatformDarwin, domain.PlatformWindows}
+	config.Architectures = []domain.Architecture{domain.ArchitectureAMD64, domain.ArchitectureARM64, domain.Architecture386}
+	config.DockerSupport = domain.DockerSupportBoth
+	config.DockerRegistry = domain.DockerRegistryGitHub
+	config.SigningLevel = domain.SigningLevelAdvanced
+	config.SBOM = true
+	config.Homebrew = true
+	config.Snap = true
+	config.BuildTags = []domain.BuildTag{{Name: "netgo"}}
+
+	content, err := generator.New().GenerateGoReleaserConfig(context.Background(), config)
+	if err != nil {
+		t.Fatalf("GenerateGoReleaserConfig() error = %v", err)
+	}
+
+	root := parse(t, content)
+	if version := Detect(root); version != VersionV2 {
+		t.Errorf("Detect() = %s, expected %s", version, VersionV2)
+	}
+	schema, err := Load(VersionV2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := schema.Validate(root); len(result.Errors) != 0 || len(result.Warnings) != 0 {
+		t.Errorf("generated config violates the schema: %v %v", result.Errors, result.Warnings)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		config   string
+		expected Version
+	}{
+		{config: "project_name: app\n", expected: VersionV1},
+		{config: "version: 2\nproject_name: app\n", expected: VersionV2},
+		{config: "version: 2\nincludes:\n  - from_file: {path: base.yaml}\n", expected: VersionPro},
+	}
+
+	for _, tt := range tests {
+		if got := Detect(parse(t, tt.config)); got != tt.expected {
+			t.Errorf("Detect(%q) = %s, expected %s", tt.config, got, tt.expected)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	if version, err := ParseVersion(" PRO "); err != nil || version != VersionPro {
+		t.Errorf("ParseVersion(PRO) = %s, %v", version, err)
+	}
+	if _, err := ParseVersion("v0"); !domain.IsErrorCode(err, domain.ErrUnknownSchemaVersion) {
+		t.Errorf("ParseVersion(v0) error = %v", err)
+	}
+}
@@ -0,0 +1,345 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// Detect selects the schema for a parsed configuration: v1 without a
+// 'version: 2' header, Pro when the configuration uses Pro-only keys
+func Detect(root *yaml.Node) Version {
+	version := lookup(root, "version")
+	if version == nil || version.Value != "2" {
+		return VersionV1
+	}
+
+	v2, errV2 := Load(VersionV2)
+	pro, errPro := Load(VersionPro)
+	if errV2 != nil || errPro != nil {
+		return VersionV2
+	}
+	for _, pair := range pairs(root) {
+		key := pair[0].Value
+		if _, ok := v2.Properties[key]; ok {
+			continue
+		}
+		if _, ok := pro.Properties[key]; ok {
+			return VersionPro
+		}
+	}
+	return VersionV2
+}
+
+// Validate checks a parsed configuration and reports unknown keys, wrong
+// types and enum violations with their position. Unknown top-level keys are
+// warnings: the bundled schema only models the common sections, and newer
+// GoReleaser releases add sections such as dockers_v2.
+func (s *Schema) Validate(root *yaml.Node) *domain.ValidationResult {
+	v := &validator{root: s}
+	if root != nil {
+		v.validate(s, root, "")
+	}
+	result := domain.NewValidationResult(v.problems)
+	result.Warnings = append(result.Warnings, v.warnings...)
+	return result
+}
+
+type validator struct {
+	root     *Schema
+	problems domain.ValidationErrors
+	warnings domain.ValidationErrors
+}
+
+func (v *validator) validate(schema *Schema, node *yaml.Node, path string) {
+	schema = schema.resolve(v.root)
+	if schema == nil {
+		return
+	}
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	// An empty value leaves the setting at its default
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	if len(schema.AnyOf) > 0 {
+		v.validateAnyOf(schema, node, path)
+		return
+	}
+
+	if !schema.Type.allows(typeOf(node)) {
+		v.problems = append(v.problems, domain.NewValidationError(
+			domain.ErrSchemaTypeMismatch,
+			"Wrong value type",
+			fmt.Sprintf("%s must be %s, found %s", describePath(path), describeTypes(schema.Type), article(typeOf(node))),
+		).WithField(path).WithPosition(node.Line, node.Column))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(schema, node, path)
+	case yaml.SequenceNode:
+		if schema.Items != nil {
+			for i, item := range node.Content {
+				v.validate(schema.Items, item, path+"["+strconv.Itoa(i)+"]")
+			}
+		}
+	case yaml.ScalarNode:
+		v.validateEnum(schema, node, path)
+	}
+}
+
+func (v *validator) validateMapping(schema *Schema, node *yaml.Node, path string) {
+	for _, pair := range pairs(node) {
+		key, value := pair[0], pair[1]
+		field := key.Value
+		if path != "" {
+			field = path + "." + key.Value
+		}
+
+		if property, ok := schema.Properties[key.Value]; ok {
+			v.validate(property, value, field)
+			continue
+		}
+
+		additional := schema.AdditionalProperties
+		if additional == nil {
+			continue
+		}
+		if !additional.never {
+			v.validate(additional, value, field)
+			continue
+		}
+
+		details := fmt.Sprintf("'%s' is not a top-level key the bundled schema knows", key.Value)
+		if path != "" {
+			details = fmt.Sprintf("'%s' is not a valid key in %s", key.Value, path)
+		}
+		if suggestion := closest(key.Value, propertyNames(schema)); suggestion != "" {
+			details += fmt.Sprintf(". Did you mean '%s'?", suggestion)
+		}
+		problem := domain.NewValidationError(
+			domain.ErrSchemaUnknownKey,
+			"Unknown key",
+			details,
+		).WithField(field).WithPosition(key.Line, key.Column)
+		if path == "" {
+			v.warnings = append(v.warnings, problem)
+		} else {
+			v.problems = append(v.problems, problem)
+		}
+	}
+}
+
+func (v *validator) validateEnum(schema *Schema, node *yaml.Node, path string) {
+	// Templated values are only known at release time
+	if len(schema.Enum) == 0 || strings.Contains(node.Value, "{{") {
+		return
+	}
+
+	allowed := make([]string, 0, len(schema.Enum))
+	for _, value := range schema.Enum {
+		name := fmt.Sprint(value)
+		if name == node.Value {
+			return
+		}
+		allowed = append(allowed, name)
+	}
+
+	details := fmt.Sprintf("'%s' is not one of %s", node.Value, strings.Join(quoteAll(allowed), ", "))
+	if suggestion := closest(node.Value, allowed); suggestion != "" {
+		details += fmt.Sprintf(". Did you mean '%s'?", suggestion)
+	}
+	v.problems = append(v.problems, domain.NewValidationError(
+		domain.ErrSchemaEnumViolation,
+		"Invalid value",
+		details,
+	).WithField(path).WithPosition(node.Line, node.Column))
+}
+
+// validateAnyOf validates against the first alternative accepting the node's
+// type, so nested problems are reported for the alternative the user meant
+func (v *validator) validateAnyOf(schema *Schema, node *yaml.Node, path string) {
+	var types Types
+	for _, alternative := range schema.AnyOf {
+		resolved := alternative.resolve(v.root)
+		if resolved == nil {
+			continue
+		}
+		if resolved.Type.allows(typeOf(node)) {
+			v.validate(resolved, node, path)
+			return
+		}
+		types = append(types, resolved.Type...)
+	}
+
+	v.problems = append(v.problems, domain.NewValidationError(
+		domain.ErrSchemaTypeMismatch,
+		"Wrong value type",
+		fmt.Sprintf("%s must be %s, found %s", describePath(path), describeTypes(types), article(typeOf(node))),
+	).WithField(path).WithPosition(node.Line, node.Column))
+}
+
+// typeOf returns the JSON type a YAML node decodes to
+func typeOf(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch node.Tag {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	default:
+		return "string"
+	}
+}
+
+// pairs returns the key/value pairs of a mapping, expanding merge keys.
+// Explicit keys follow merged ones so they are reported at their own position.
+func pairs(node *yaml.Node) [][2]*yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var merged, explicit [][2]*yaml.Node
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != "<<" {
+			explicit = append(explicit, [2]*yaml.Node{key, value})
+			continue
+		}
+
+		sources := []*yaml.Node{value}
+		if value.Kind == yaml.SequenceNode {
+			sources = value.Content
+		}
+		for _, source := range sources {
+			if source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			merged = append(merged, pairs(source)...)
+		}
+	}
+
+	// Keys set explicitly override merged ones
+	seen := make(map[string]bool)
+	for _, pair := range explicit {
+		seen[pair[0].Value] = true
+	}
+	result := make([][2]*yaml.Node, 0, len(merged)+len(explicit))
+	for _, pair := range merged {
+		if !seen[pair[0].Value] {
+			seen[pair[0].Value] = true
+			result = append(result, pair)
+		}
+	}
+	return append(result, explicit...)
+}
+
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for _, pair := range pairs(mapping) {
+		if pair[0].Value == key {
+			return pair[1]
+		}
+	}
+	return nil
+}
+
+func propertyNames(schema *Schema) []string {
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// closest returns the candidate nearest to word, or "" when none is close
+// enough to be a plausible typo
+func closest(word string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, candidate := range candidates {
+		distance := levenshtein(strings.ToLower(word), strings.ToLower(candidate))
+		if bestDistance < 0 || distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+
+	limit := len(word) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	if bestDistance < 0 || bestDistance > limit {
+		return ""
+	}
+	return best
+}
+
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func describePath(path string) string {
+	if path == "" {
+		return "the top level"
+	}
+	return path
+}
+
+func describeTypes(types Types) string {
+	names := make([]string, 0, len(types))
+	for _, typ := range types {
+		names = append(names, article(typ))
+	}
+	return strings.Join(names, " or ")
+}
+
+func article(typ string) string {
+	switch typ {
+	case "array", "object", "integer":
+		return "an " + typ
+	case "null":
+		return "null"
+	default:
+		return "a " + typ
+	}
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, "'"+value+"'")
+	}
+	return quoted
+}
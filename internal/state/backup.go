@@ -0,0 +1,302 @@
+package state
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// backupsDir holds one directory per recorded run
+const backupsDir = "backups"
+
+// manifestName is the file describing a run inside its backup directory
+const manifestName = "manifest.json"
+
+// runIDFormat is the timestamp prefix of run ids; it sorts chronologically
+const runIDFormat = "20060102-150405"
+
+// Manifest lists every file a wizard run touched and how to restore it
+type Manifest struct {
+	ID           string       `json:"id"`
+	Command      string       `json:"command"`
+	CreatedAt    time.Time    `json:"created_at"`
+	RolledBackAt *time.Time   `json:"rolled_back_at,omitempty"`
+	Files        []BackupFile `json:"files"`
+}
+
+// BackupFile is one file in a run. Files that did not exist before the run
+// are removed on restore.
+type BackupFile struct {
+	Path    string `json:"path"` // slash-separated, relative to the project root
+	Existed bool   `json:"existed"`
+}
+
+// Has reports whether the run recorded path
+func (m *Manifest) Has(path string) bool {
+	for _, file := range m.Files {
+		if file.Path == path {
+			return true
+		}
+	}
+	return false
+}
+
+// BeginRun creates an empty run in the backup catalog
+func (s *Store) BeginRun(command string, now time.Time) (*Manifest, error) {
+	id := now.Format(runIDFormat) + "-" + command
+	for n := 2; ; n++ {
+		if _, err := os.Stat(s.Path(backupsDir, id)); os.IsNotExist(err) {
+			break
+		}
+		id = now.Format(runIDFormat) + "-" + command + "-" + strconv.Itoa(n)
+	}
+
+	manifest := &Manifest{ID: id, Command: command, CreatedAt: now, Files: []BackupFile{}}
+	if err := s.saveManifest(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Record saves the current content of path into the run before it is changed.
+// Recording the same path twice keeps the first, original content.
+func (s *Store) Record(manifest *Manifest, path string) error {
+	if err := checkPath(path); err != nil {
+		return err
+	}
+	if manifest.Has(path) {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.projectPath(path))
+	existed := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to back up file",
+			fmt.Sprintf("Cannot read %s", path),
+			err,
+		).WithContext(path)
+	}
+
+	if existed {
+		if err := s.write(s.backupPath(manifest.ID, path), data); err != nil {
+			return err
+		}
+	}
+
+	manifest.Files = append(manifest.Files, BackupFile{Path: path, Existed: existed})
+	return s.saveManifest(manifest)
+}
+
+// Runs returns all recorded runs, newest first
+func (s *Store) Runs() ([]*Manifest, error) {
+	entries, err := os.ReadDir(s.Path(backupsDir))
+	if os.IsNotExist(err) {
+		return []*Manifest{}, nil
+	}
+	if err != nil {
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read backup catalog",
+			fmt.Sprintf("Cannot list %s", s.Path(backupsDir)),
+			err,
+		)
+	}
+
+	runs := []*Manifest{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := s.LoadRun(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, manifest)
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].CreatedAt.After(runs[j].CreatedAt)
+	})
+	return runs, nil
+}
+
+// LoadRun reads the manifest of a run
+func (s *Store) LoadRun(id string) (*Manifest, error) {
+	if !filepath.IsLocal(id) || filepath.Base(id) != id {
+		return nil, backupNotFoundError(id)
+	}
+
+	data, err := os.ReadFile(s.Path(backupsDir, id, manifestName))
+	if os.IsNotExist(err) {
+		return nil, backupNotFoundError(id)
+	}
+	if err != nil {
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read backup manifest",
+			fmt.Sprintf("Cannot read manifest of run %s", id),
+			err,
+		).WithContext(id)
+	}
+
+	manifest := &Manifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, domain.NewTemplateError(
+			domain.ErrTemplateSyntaxError,
+			"Invalid backup manifest",
+			err.Error(),
+		).WithContext(id).WithCause(err)
+	}
+	return manifest, nil
+}
+
+// LatestRun returns the newest run that was not rolled back and is not itself a rollback
+func (s *Store) LatestRun(rollbackCommand string) (*Manifest, error) {
+	runs, err := s.Runs()
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		if run.RolledBackAt == nil && run.Command != rollbackCommand {
+			return run, nil
+		}
+	}
+	return nil, backupNotFoundError("latest")
+}
+
+// RestoreFile restores a single recorded file of a run
+func (s *Store) RestoreFile(manifest *Manifest, path string) error {
+	for _, file := range manifest.Files {
+		if file.Path == path {
+			data, err := s.backupContent(manifest, file)
+			if err != nil {
+				return err
+			}
+			return s.apply(file.Path, data, file.Existed)
+		}
+	}
+	return nil
+}
+
+// RestoreRun restores every file of a run. If a file cannot be restored,
+// the files already restored are put back so the project is never left half restored.
+func (s *Store) RestoreRun(manifest *Manifest) error {
+	// Read every backup first so a missing one aborts before anything changes
+	backups := make([][]byte, len(manifest.Files))
+	for i, file := range manifest.Files {
+		if err := checkPath(file.Path); err != nil {
+			return err
+		}
+		data, err := s.backupContent(manifest, file)
+		if err != nil {
+			return err
+		}
+		backups[i] = data
+	}
+
+	// Snapshot the current state to undo a partial restore
+	current := make([][]byte, len(manifest.Files))
+	present := make([]bool, len(manifest.Files))
+	for i, file := range manifest.Files {
+		data, err := os.ReadFile(s.projectPath(file.Path))
+		current[i], present[i] = data, err == nil
+	}
+
+	for i, file := range manifest.Files {
+		if err := s.apply(file.Path, backups[i], file.Existed); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				s.apply(manifest.Files[j].Path, current[j], present[j])
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkRolledBack records that a run was restored
+func (s *Store) MarkRolledBack(manifest *Manifest, now time.Time) error {
+	manifest.RolledBackAt = &now
+	return s.saveManifest(manifest)
+}
+
+func (s *Store) backupContent(manifest *Manifest, file BackupFile) ([]byte, error) {
+	if !file.Existed {
+		return nil, nil
+	}
+	data, err := os.ReadFile(s.backupPath(manifest.ID, file.Path))
+	if err != nil {
+		return nil, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Backup is incomplete",
+			fmt.Sprintf("Run %s has no saved copy of %s", manifest.ID, file.Path),
+			err,
+		).WithContext(manifest.ID)
+	}
+	return data, nil
+}
+
+// apply writes data to path, or removes path when it should not exist
+func (s *Store) apply(path string, data []byte, exists bool) error {
+	target := s.projectPath(path)
+	if !exists {
+		if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+			return domain.FileWriteFailedError(target, err)
+		}
+		return nil
+	}
+
+	// Write next to the target and rename so readers never see a partial file
+	tmp := target + ".restore"
+	if err := s.write(tmp, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, target); err != nil {
+		os.Remove(tmp)
+		return domain.FileWriteFailedError(target, err)
+	}
+	return nil
+}
+
+func (s *Store) saveManifest(manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.write(s.Path(backupsDir, manifest.ID, manifestName), append(data, '\n'))
+}
+
+func (s *Store) backupPath(id, path string) string {
+	return s.Path(backupsDir, id, "files", filepath.FromSlash(path))
+}
+
+func (s *Store) projectPath(path string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path))
+}
+
+// checkPath rejects manifest paths that would escape the project root
+func checkPath(path string) error {
+	if !filepath.IsLocal(filepath.FromSlash(path)) {
+		return domain.NewValidationError(
+			domain.ErrInvalidCharacters,
+			"Invalid backup path",
+			fmt.Sprintf("'%s' is not a path inside the project", path),
+		)
+	}
+	return nil
+}
+
+func backupNotFoundError(id string) *domain.DomainError {
+	return domain.NewValidationError(
+		domain.ErrBackupNotFound,
+		"Backup not found",
+		fmt.Sprintf("No backup run '%s' in %s", id, Dir),
+	)
+}
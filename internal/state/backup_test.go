@@ -0,0 +1,160 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, root, path string) (string, bool) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return "", false
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data), true
+}
+
+func TestRestoreRun(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root)
+	writeFile(t, root, ".goreleaser.yaml", "original config\n")
+
+	run, err := store.BeginRun("generate", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("BeginRun() error = %v", err)
+	}
+	if run.ID != "20260102-030405-generate" {
+		t.Errorf("run ID = %s", run.ID)
+	}
+
+	for _, path := range []string{".goreleaser.yaml", ".github/workflows/release.yml", ".goreleaser.yaml"} {
+		if err := store.Record(run, path); err != nil {
+			t.Fatalf("Record(%s) error = %v", path, err)
+		}
+	}
+	if len(run.Files) != 2 {
+		t.Fatalf("expected 2 recorded files, got %v", run.Files)
+	}
+
+	// Simulate the run changing files
+	writeFile(t, root, ".goreleaser.yaml", "generated config\n")
+	writeFile(t, root, ".github/workflows/release.yml", "generated workflow\n")
+
+	loaded, err := store.LoadRun(run.ID)
+	if err != nil {
+		t.Fatalf("LoadRun() error = %v", err)
+	}
+	if err := store.RestoreRun(loaded); err != nil {
+		t.Fatalf("RestoreRun() error = %v", err)
+	}
+
+	if content, _ := readFile(t, root, ".goreleaser.yaml"); content != "original config\n" {
+		t.Errorf("config not restored: %q", content)
+	}
+	if _, exists := readFile(t, root, ".github/workflows/release.yml"); exists {
+		t.Error("file created by the run was not removed")
+	}
+}
+
+func TestRestoreRunIsAllOrNothing(t *testing.T) {
+	root := t.TempDir()
+	store := NewStore(root)
+	writeFile(t, root, "a.yaml", "a\n")
+	writeFile(t, root, "b.yaml", "b\n")
+
+	run, err := store.BeginRun("update", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"a.yaml", "b.yaml"} {
+		if err := store.Record(run, path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeFile(t, root, "a.yaml", "changed a\n")
+
+	// Lose one saved copy: nothing may be restored
+	if err := os.Remove(store.backupPath(run.ID, "b.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RestoreRun(run); err == nil {
+		t.Fatal("RestoreRun() expected error for incomplete backup")
+	}
+	if content, _ := readFile(t, root, "a.yaml"); content != "changed a\n" {
+		t.Errorf("partial restore happened: a.yaml = %q", content)
+	}
+}
+
+func TestRunsAndLatestRun(t *testing.T) {
+	store := NewStore(t.TempDir())
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	first, err := store.BeginRun("generate", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := store.BeginRun("migrate", start.Add(time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.BeginRun("rollback", start.Add(2*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	runs, err := store.Runs()
+	if err != nil {
+		t.Fatalf("Runs() error = %v", err)
+	}
+	if len(runs) != 3 || runs[0].Command != "rollback" || runs[2].ID != first.ID {
+		t.Errorf("Runs() not newest first: %v", runs)
+	}
+
+	latest, err := store.LatestRun("rollback")
+	if err != nil || latest.ID != second.ID {
+		t.Fatalf("LatestRun() = %v, %v; expected %s", latest, err, second.ID)
+	}
+
+	if err := store.MarkRolledBack(latest, start.Add(3*time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	latest, err = store.LatestRun("rollback")
+	if err != nil || latest.ID != first.ID {
+		t.Fatalf("LatestRun() after rollback = %v, %v; expected %s", latest, err, first.ID)
+	}
+}
+
+func TestLoadRunErrors(t *testing.T) {
+	store := NewStore(t.TempDir())
+
+	for _, id := range []string{"missing", "../escape", ""} {
+		if _, err := store.LoadRun(id); !domain.IsErrorCode(err, domain.ErrBackupNotFound) {
+			t.Errorf("LoadRun(%q) error = %v, expected %s", id, err, domain.ErrBackupNotFound)
+		}
+	}
+
+	run, err := store.BeginRun("generate", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Record(run, "../outside"); err == nil {
+		t.Error("Record() accepted a path outside the project")
+	}
+}
@@ -0,0 +1,81 @@
+// Package state manages the wizard's per-project state directory
+package state
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+// Dir is the state directory, relative to the project root
+const Dir = ".goreleaser-wizard"
+
+// baseDir holds the last generated output of every artifact, used as merge base
+const baseDir = "base"
+
+// Store reads and writes state below a project root
+type Store struct {
+	root string
+}
+
+// NewStore creates a store for the project rooted at root
+func NewStore(root string) *Store {
+	return &Store{root: root}
+}
+
+// Path returns the absolute location of a state entry
+func (s *Store) Path(elem ...string) string {
+	return filepath.Join(append([]string{s.root, Dir}, elem...)...)
+}
+
+// Base returns the last generated content of an artifact path.
+// The boolean is false when no base has been recorded yet.
+func (s *Store) Base(artifactPath string) (string, bool, error) {
+	basePath := s.basePath(artifactPath)
+	data, err := os.ReadFile(basePath)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, domain.NewSystemError(
+			domain.ErrFileReadFailed,
+			"Failed to read merge base",
+			fmt.Sprintf("Cannot read %s", basePath),
+			err,
+		).WithContext(basePath)
+	}
+	return string(data), true, nil
+}
+
+// SaveBase records content as the merge base of an artifact path
+func (s *Store) SaveBase(artifactPath, content string) error {
+	return s.write(s.basePath(artifactPath), []byte(content))
+}
+
+// BaseFile returns the slash-separated location of an artifact's merge base,
+// relative to the project root
+func BaseFile(artifactPath string) string {
+	return path.Join(Dir, baseDir, artifactPath)
+}
+
+func (s *Store) basePath(artifactPath string) string {
+	return s.Path(baseDir, filepath.FromSlash(artifactPath))
+}
+
+func (s *Store) write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return domain.NewSystemError(
+			domain.ErrDirectoryCreateFailed,
+			"Failed to create state directory",
+			fmt.Sprintf("Cannot create %s", filepath.Dir(path)),
+			err,
+		).WithContext(path)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return domain.FileWriteFailedError(path, err)
+	}
+	return nil
+}
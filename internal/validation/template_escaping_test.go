@@ -165,6 +165,32 @@ func TestTemplateEscaper_ValidateTemplateContent(t *testing.T) {
 	}
 }
 
+func TestContainsGitHubActionsInjection(t *testing.T) {
+	te := NewTemplateEscaper()
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"name: build", false},
+		{"${{ github.token }}", true},
+		{"echo ::SET-OUTPUT name=x::1", true},
+		{"SECRETS.TOKEN", true},
+		// The content is lowercased but the patterns are not, so the
+		// uppercase $GITHUB_ pattern never matches
+		{"echo $GITHUB_OUTPUT", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := te.containsGitHubActionsInjection(tt.input)
+			if result != tt.expected {
+				t.Errorf("containsGitHubActionsInjection() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestLooksLikeNumber(t *testing.T) {
 	tests := []struct {
 		input    string
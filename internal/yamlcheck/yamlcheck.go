@@ -0,0 +1,336 @@
+// Package yamlcheck parses YAML files and reports problems with their exact position
+package yamlcheck
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+	"go.yaml.in/yaml/v3"
+)
+
+// Kinds maps key paths to the node kind expected there. Path segments are
+// separated by dots, "[]" matches any sequence item and "*" any mapping key,
+// for example "builds[].goos" or "jobs.*.steps".
+type Kinds map[string]yaml.Kind
+
+// GoReleaserKinds describes the collections of a .goreleaser.yaml
+var GoReleaserKinds = Kinds{
+	"project_name":                   yaml.ScalarNode,
+	"version":                        yaml.ScalarNode,
+	"env":                            yaml.SequenceNode,
+	"before":                         yaml.MappingNode,
+	"before.hooks":                   yaml.SequenceNode,
+	"builds":                         yaml.SequenceNode,
+	"builds[]":                       yaml.MappingNode,
+	"builds[].goos":                  yaml.SequenceNode,
+	"builds[].goarch":                yaml.SequenceNode,
+	"builds[].goarm":                 yaml.SequenceNode,
+	"builds[].env":                   yaml.SequenceNode,
+	"builds[].ignore":                yaml.SequenceNode,
+	"archives":                       yaml.SequenceNode,
+	"archives[]":                     yaml.MappingNode,
+	"archives[].files":               yaml.SequenceNode,
+	"archives[].format_overrides":    yaml.SequenceNode,
+	"checksum":                       yaml.MappingNode,
+	"snapshot":                       yaml.MappingNode,
+	"changelog":                      yaml.MappingNode,
+	"changelog.filters":              yaml.MappingNode,
+	"changelog.filters.exclude":      yaml.SequenceNode,
+	"release":                        yaml.MappingNode,
+	"release.github":                 yaml.MappingNode,
+	"dockers":                        yaml.SequenceNode,
+	"dockers[]":                      yaml.MappingNode,
+	"dockers[].image_templates":      yaml.SequenceNode,
+	"dockers[].build_flag_templates": yaml.SequenceNode,
+	"docker_manifests":               yaml.SequenceNode,
+	"nfpms":                          yaml.SequenceNode,
+	"nfpms[].formats":                yaml.SequenceNode,
+	"brews":                          yaml.SequenceNode,
+	"scoops":                         yaml.SequenceNode,
+	"signs":                          yaml.SequenceNode,
+	"sboms":                          yaml.SequenceNode,
+	"upx":                            yaml.SequenceNode,
+}
+
+// WorkflowKinds describes the collections of a GitHub Actions workflow
+var WorkflowKinds = Kinds{
+	"name":                        yaml.ScalarNode,
+	"env":                         yaml.MappingNode,
+	"jobs":                        yaml.MappingNode,
+	"jobs.*":                      yaml.MappingNode,
+	"jobs.*.env":                  yaml.MappingNode,
+	"jobs.*.strategy":             yaml.MappingNode,
+	"jobs.*.strategy.matrix":      yaml.MappingNode,
+	"jobs.*.steps":                yaml.SequenceNode,
+	"jobs.*.steps[]":              yaml.MappingNode,
+	"jobs.*.steps[].with":         yaml.MappingNode,
+	"jobs.*.steps[].env":          yaml.MappingNode,
+	"jobs.*.permissions.*":        yaml.ScalarNode,
+	"permissions.*":               yaml.ScalarNode,
+	"jobs.*.steps[].run":          yaml.ScalarNode,
+	"jobs.*.steps[].uses":         yaml.ScalarNode,
+	"jobs.*.steps[].name":         yaml.ScalarNode,
+	"jobs.*.steps[].with.args":    yaml.ScalarNode,
+	"jobs.*.steps[].with.version": yaml.ScalarNode,
+}
+
+// syntaxErrorPattern extracts the line from the parser's error messages
+var syntaxErrorPattern = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// zeroBasedProblems are reported by the parser with a 0-based line, unlike
+// scanner problems. The line is where the enclosing collection starts.
+var zeroBasedProblems = map[string]bool{
+	"did not find expected ',' or ']'":       true,
+	"did not find expected ',' or '}'":       true,
+	"did not find expected '-' indicator":    true,
+	"did not find expected <document start>": true,
+	"did not find expected key":              true,
+	"did not find expected node content":     true,
+}
+
+// Parse parses a single YAML document and returns its root node together with
+// every problem found: syntax errors, tabs in indentation, duplicate keys and
+// aliases whose anchor has a different kind than kinds expects at that path.
+// The root is nil when the document is empty or cannot be parsed.
+func Parse(data []byte, kinds Kinds) (*yaml.Node, domain.ValidationErrors) {
+	var problems domain.ValidationErrors
+
+	var document yaml.Node
+	parseErr := yaml.Unmarshal(data, &document)
+
+	problems = append(problems, checkTabs(data, &document)...)
+
+	if parseErr != nil {
+		// A tab in the indentation is the usual cause of the parser error,
+		// which then only repeats the problem with a vaguer message
+		if len(problems) == 0 {
+			problems = append(problems, syntaxError(data, parseErr))
+		}
+		return nil, problems
+	}
+
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil, problems
+	}
+
+	root := document.Content[0]
+	checker := &checker{kinds: kinds}
+	checker.walk(root, nil)
+	return root, append(problems, checker.problems...)
+}
+
+// Lookup returns the value of key in a mapping node, or nil
+func Lookup(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// syntaxError converts a parser error. The parser only reports lines, so the
+// column points at the first character of the offending line.
+func syntaxError(data []byte, err error) *domain.DomainError {
+	match := syntaxErrorPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return domain.NewTemplateError(
+			domain.ErrYAMLSyntax,
+			"Invalid YAML syntax",
+			strings.TrimPrefix(err.Error(), "yaml: "),
+		).WithCause(err)
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	if zeroBasedProblems[match[2]] {
+		line++
+	}
+	return domain.NewTemplateError(
+		domain.ErrYAMLSyntax,
+		"Invalid YAML syntax",
+		match[2],
+	).WithCause(err).WithPosition(line, firstColumn(data, line))
+}
+
+// checkTabs reports tabs in the indentation of lines outside block scalars
+func checkTabs(data []byte, document *yaml.Node) domain.ValidationErrors {
+	scalarLines := blockScalarLines(document)
+
+	var problems domain.ValidationErrors
+	for i, line := range strings.Split(string(data), "\n") {
+		if scalarLines[i+1] {
+			continue
+		}
+		for column, char := range line {
+			if char == '\t' {
+				problems = append(problems, domain.NewValidationError(
+					domain.ErrYAMLTabIndentation,
+					"Tab used for indentation",
+					fmt.Sprintf("Line %d is indented with a tab", i+1),
+				).WithPosition(i+1, column+1))
+				break
+			}
+			if char != ' ' {
+				break
+			}
+		}
+	}
+	return problems
+}
+
+// blockScalarLines returns the content lines of literal and folded scalars,
+// where tabs are part of the value
+func blockScalarLines(node *yaml.Node) map[int]bool {
+	lines := make(map[int]bool)
+	var visit func(*yaml.Node)
+	visit = func(n *yaml.Node) {
+		if n.Kind == yaml.ScalarNode && (n.Style&(yaml.LiteralStyle|yaml.FoldedStyle)) != 0 {
+			for i := 1; i <= strings.Count(n.Value, "\n"); i++ {
+				lines[n.Line+i] = true
+			}
+		}
+		for _, child := range n.Content {
+			visit(child)
+		}
+	}
+	visit(node)
+	return lines
+}
+
+// firstColumn returns the 1-based column of the first non-blank character of line
+func firstColumn(data []byte, line int) int {
+	lines := strings.Split(string(data), "\n")
+	if line < 1 || line > len(lines) {
+		return 1
+	}
+	text := lines[line-1]
+	return len(text) - len(strings.TrimLeft(text, " \t")) + 1
+}
+
+// checker walks a document collecting structural problems
+type checker struct {
+	kinds    Kinds
+	problems domain.ValidationErrors
+}
+
+func (c *checker) walk(node *yaml.Node, path []string) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		c.checkAlias(node, path)
+
+	case yaml.MappingNode:
+		seen := make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			if key.Value == "<<" {
+				c.checkMerge(value)
+				continue
+			}
+
+			if first, ok := seen[key.Value]; ok {
+				c.problems = append(c.problems, domain.NewValidationError(
+					domain.ErrYAMLDuplicateKey,
+					"Duplicate key",
+					fmt.Sprintf("'%s' is already defined at line %d", key.Value, first.Line),
+				).WithField(joinPath(append(path, key.Value))).WithPosition(key.Line, key.Column))
+			} else {
+				seen[key.Value] = key
+			}
+			c.walk(value, append(path, key.Value))
+		}
+
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			c.walk(item, append(path, "["+strconv.Itoa(i)+"]"))
+		}
+	}
+}
+
+// checkAlias compares the kind of an alias target with the expected kind
+func (c *checker) checkAlias(alias *yaml.Node, path []string) {
+	expected, ok := c.kinds.expected(path)
+	if !ok || alias.Alias == nil || alias.Alias.Kind == expected {
+		return
+	}
+	c.problems = append(c.problems, domain.NewValidationError(
+		domain.ErrYAMLAliasTypeMismatch,
+		"Alias resolves to the wrong type",
+		fmt.Sprintf("*%s is a %s but %s expects a %s", alias.Value, kindName(alias.Alias.Kind), joinPath(path), kindName(expected)),
+	).WithField(joinPath(path)).WithPosition(alias.Line, alias.Column))
+}
+
+// checkMerge verifies that a merge key refers to mappings only
+func (c *checker) checkMerge(value *yaml.Node) {
+	targets := []*yaml.Node{value}
+	if value.Kind == yaml.SequenceNode {
+		targets = value.Content
+	}
+	for _, target := range targets {
+		resolved := target
+		if target.Kind == yaml.AliasNode && target.Alias != nil {
+			resolved = target.Alias
+		}
+		if resolved.Kind == yaml.MappingNode {
+			continue
+		}
+		c.problems = append(c.problems, domain.NewValidationError(
+			domain.ErrYAMLAliasTypeMismatch,
+			"Merge key requires a mapping",
+			fmt.Sprintf("'<<' can only merge mappings, found a %s", kindName(resolved.Kind)),
+		).WithPosition(target.Line, target.Column))
+	}
+}
+
+// expected returns the kind registered for a concrete path
+func (k Kinds) expected(path []string) (yaml.Kind, bool) {
+	for pattern, kind := range k {
+		if matchPath(splitPattern(pattern), path) {
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+func splitPattern(pattern string) []string {
+	return strings.Split(strings.ReplaceAll(pattern, "[]", ".[]"), ".")
+}
+
+func matchPath(pattern, path []string) bool {
+	if len(pattern) != len(path) {
+		return false
+	}
+	for i, segment := range pattern {
+		index := strings.HasPrefix(path[i], "[")
+		if segment == path[i] || (segment == "[]" && index) || (segment == "*" && !index) {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// joinPath renders path segments as a dotted key path like builds[0].goos
+func joinPath(path []string) string {
+	return strings.ReplaceAll(strings.Join(path, "."), ".[", "[")
+}
+
+func kindName(kind yaml.Kind) string {
+	switch kind {
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.ScalarNode:
+		return "scalar"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "document"
+	}
+}
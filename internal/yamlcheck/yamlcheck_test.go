@@ -0,0 +1,131 @@
+package yamlcheck
+
+import (
+	"testing"
+
+	"github.com/LarsArtmann/template-GoReleaser/internal/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		code    domain.ErrorCode
+		line    int
+		column  int
+		field   string
+		hasRoot bool
+	}{
+		{
+			name:    "valid",
+			content: "project_name: app\nbuilds:\n  - goos: [linux]\n",
+			hasRoot: true,
+		},
+		{
+			name:    "syntax_error",
+			content: "project_name: app\nbuilds:\n  - goos: [linux\n",
+			code:    domain.ErrYAMLSyntax,
+			line:    3,
+			column:  3,
+		},
+		{
+			name:    "unclosed_quote",
+			content: "project_name: app\nbuilds:\n    main: \"./cmd\n",
+			code:    domain.ErrYAMLSyntax,
+			line:    3,
+			column:  5,
+		},
+		{
+			name:    "bad_indentation",
+			content: "release:\n  github:\n    owner: me\n   name: app\n",
+			code:    domain.ErrYAMLSyntax,
+			line:    2,
+			column:  3,
+		},
+		{
+			name:    "tab_indentation",
+			content: "builds:\n\t- goos: [linux]\n",
+			code:    domain.ErrYAMLTabIndentation,
+			line:    2,
+			column:  1,
+		},
+		{
+			name:    "duplicate_key",
+			content: "builds:\n  - goos: [linux]\n    goarch: [amd64]\n    goos: [darwin]\n",
+			code:    domain.ErrYAMLDuplicateKey,
+			line:    4,
+			column:  5,
+			field:   "builds[0].goos",
+			hasRoot: true,
+		},
+		{
+			name:    "alias_wrong_type",
+			content: "env: &defaults\n  - CGO_ENABLED=0\nrelease: *defaults\n",
+			code:    domain.ErrYAMLAliasTypeMismatch,
+			line:    3,
+			column:  10,
+			field:   "release",
+			hasRoot: true,
+		},
+		{
+			name:    "merge_non_mapping",
+			content: "x: &list [a]\nrelease:\n  <<: *list\n",
+			code:    domain.ErrYAMLAliasTypeMismatch,
+			line:    3,
+			column:  7,
+			hasRoot: true,
+		},
+		{
+			name:    "alias_right_type",
+			content: "x: &platforms [linux, darwin]\nbuilds:\n  - goos: *platforms\n  - <<: {goos: *platforms}\n",
+			hasRoot: true,
+		},
+		{
+			name:    "tab_in_block_scalar",
+			content: "before:\n  hooks:\n    - |\n      make\n      \techo ok\n",
+			hasRoot: true,
+		},
+		{
+			name:    "empty",
+			content: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, problems := Parse([]byte(tt.content), GoReleaserKinds)
+			if (root != nil) != tt.hasRoot {
+				t.Errorf("Parse() root = %v, expected root: %v", root, tt.hasRoot)
+			}
+
+			if tt.code == "" {
+				if len(problems) != 0 {
+					t.Errorf("Parse() unexpected problems: %v", problems)
+				}
+				return
+			}
+
+			if len(problems) != 1 {
+				t.Fatalf("Parse() expected 1 problem, got %v", problems)
+			}
+			problem := problems[0]
+			if problem.Code != tt.code {
+				t.Errorf("code = %s, expected %s", problem.Code, tt.code)
+			}
+			if problem.Line != tt.line || problem.Column != tt.column {
+				t.Errorf("position = %d:%d, expected %d:%d", problem.Line, problem.Column, tt.line, tt.column)
+			}
+			if problem.Field != tt.field {
+				t.Errorf("field = %q, expected %q", problem.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestParseWorkflowKinds(t *testing.T) {
+	workflow := "x: &step {uses: actions/checkout@v4}\njobs:\n  release:\n    steps: *step\n"
+	_, problems := Parse([]byte(workflow), WorkflowKinds)
+	if len(problems) != 1 || problems[0].Field != "jobs.release.steps" {
+		t.Fatalf("expected a mismatch on jobs.release.steps, got %v", problems)
+	}
+}